@@ -0,0 +1,156 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+//------------------------------------------------------------------------------
+
+// `Affine` is a single-precision affine transformation, stored as a matrix
+// with 4 columns and 3 rows. It is equivalent to a `Mat4` whose bottom row is
+// (0, 0, 0, 1), but takes 25% less memory and fewer operations to multiply.
+//
+// The first three columns are the linear part, and the last one is the
+// translation.
+//
+// Note: matrices are stored in column-major order, so when writing literals
+// remember to use the transpose.
+type Affine [4][3]float32
+
+//------------------------------------------------------------------------------
+
+// `AffineIdentity` returns the affine transformation that leaves every point
+// unchanged.
+func AffineIdentity() Affine {
+	return Affine{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+		{0, 0, 0},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Affine` returns the top three rows of `m`. The conversion is lossless if
+// the bottom row of `m` is (0, 0, 0, 1), i.e. if `m` has no projection.
+//
+// See also `Affine.Mat4`.
+func (m Mat4) Affine() Affine {
+	return Affine{
+		{m[0][0], m[0][1], m[0][2]},
+		{m[1][0], m[1][1], m[1][2]},
+		{m[2][0], m[2][1], m[2][2]},
+		{m[3][0], m[3][1], m[3][2]},
+	}
+}
+
+// `Mat4` returns the 4x4 matrix equivalent to `a`.
+//
+// See also `Mat4.Affine`.
+func (a Affine) Mat4() Mat4 {
+	return Mat4{
+		{a[0][0], a[0][1], a[0][2], 0},
+		{a[1][0], a[1][1], a[1][2], 0},
+		{a[2][0], a[2][1], a[2][2], 0},
+		{a[3][0], a[3][1], a[3][2], 1},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Times` returns the composition of `a` and `o`, i.e. the transformation
+// applying `o` first, then `a`.
+//
+// See also `Multiply`.
+func (a *Affine) Times(o *Affine) Affine {
+	return Affine{
+		{
+			a[0][0]*o[0][0] + a[1][0]*o[0][1] + a[2][0]*o[0][2],
+			a[0][1]*o[0][0] + a[1][1]*o[0][1] + a[2][1]*o[0][2],
+			a[0][2]*o[0][0] + a[1][2]*o[0][1] + a[2][2]*o[0][2],
+		},
+		{
+			a[0][0]*o[1][0] + a[1][0]*o[1][1] + a[2][0]*o[1][2],
+			a[0][1]*o[1][0] + a[1][1]*o[1][1] + a[2][1]*o[1][2],
+			a[0][2]*o[1][0] + a[1][2]*o[1][1] + a[2][2]*o[1][2],
+		},
+		{
+			a[0][0]*o[2][0] + a[1][0]*o[2][1] + a[2][0]*o[2][2],
+			a[0][1]*o[2][0] + a[1][1]*o[2][1] + a[2][1]*o[2][2],
+			a[0][2]*o[2][0] + a[1][2]*o[2][1] + a[2][2]*o[2][2],
+		},
+		{
+			a[0][0]*o[3][0] + a[1][0]*o[3][1] + a[2][0]*o[3][2] + a[3][0],
+			a[0][1]*o[3][0] + a[1][1]*o[3][1] + a[2][1]*o[3][2] + a[3][1],
+			a[0][2]*o[3][0] + a[1][2]*o[3][1] + a[2][2]*o[3][2] + a[3][2],
+		},
+	}
+}
+
+// `Multiply` sets `r` to the composition of `a` and `o`.
+//
+// `r` must not be `a` or `o`.
+//
+// See also `Times`.
+func (r *Affine) Multiply(a, o *Affine) {
+	r[0][0] = a[0][0]*o[0][0] + a[1][0]*o[0][1] + a[2][0]*o[0][2]
+	r[0][1] = a[0][1]*o[0][0] + a[1][1]*o[0][1] + a[2][1]*o[0][2]
+	r[0][2] = a[0][2]*o[0][0] + a[1][2]*o[0][1] + a[2][2]*o[0][2]
+
+	r[1][0] = a[0][0]*o[1][0] + a[1][0]*o[1][1] + a[2][0]*o[1][2]
+	r[1][1] = a[0][1]*o[1][0] + a[1][1]*o[1][1] + a[2][1]*o[1][2]
+	r[1][2] = a[0][2]*o[1][0] + a[1][2]*o[1][1] + a[2][2]*o[1][2]
+
+	r[2][0] = a[0][0]*o[2][0] + a[1][0]*o[2][1] + a[2][0]*o[2][2]
+	r[2][1] = a[0][1]*o[2][0] + a[1][1]*o[2][1] + a[2][1]*o[2][2]
+	r[2][2] = a[0][2]*o[2][0] + a[1][2]*o[2][1] + a[2][2]*o[2][2]
+
+	r[3][0] = a[0][0]*o[3][0] + a[1][0]*o[3][1] + a[2][0]*o[3][2] + a[3][0]
+	r[3][1] = a[0][1]*o[3][0] + a[1][1]*o[3][1] + a[2][1]*o[3][2] + a[3][1]
+	r[3][2] = a[0][2]*o[3][0] + a[1][2]*o[3][1] + a[2][2]*o[3][2] + a[3][2]
+}
+
+//------------------------------------------------------------------------------
+
+// `TransformPoint` returns the point `p` transformed by `a` (i.e. with the
+// translation applied).
+//
+// See also `TransformDirection`.
+func (a *Affine) TransformPoint(p Vec3) Vec3 {
+	return Vec3{
+		a[0][0]*p.X + a[1][0]*p.Y + a[2][0]*p.Z + a[3][0],
+		a[0][1]*p.X + a[1][1]*p.Y + a[2][1]*p.Z + a[3][1],
+		a[0][2]*p.X + a[1][2]*p.Y + a[2][2]*p.Z + a[3][2],
+	}
+}
+
+// `TransformDirection` returns the direction `v` transformed by the linear
+// part of `a` (i.e. ignoring the translation).
+//
+// See also `TransformPoint`.
+func (a *Affine) TransformDirection(v Vec3) Vec3 {
+	return Vec3{
+		a[0][0]*v.X + a[1][0]*v.Y + a[2][0]*v.Z,
+		a[0][1]*v.X + a[1][1]*v.Y + a[2][1]*v.Z,
+		a[0][2]*v.X + a[1][2]*v.Y + a[2][2]*v.Z,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Inverse` returns the inverse of `a`, and true; or, if the linear part of
+// `a` is singular (with the same criterion as `Mat3.Inverse`), the zero value
+// and false.
+//
+// Since there is no projection, only the linear part needs a general
+// inversion: this is much cheaper than `Mat4.Inverse`.
+func (a Affine) Inverse() (Affine, bool) {
+	l, ok := Mat3{a[0], a[1], a[2]}.Inverse()
+	if !ok {
+		return Affine{}, false
+	}
+	t := l.TimesVec3(Vec3{a[3][0], a[3][1], a[3][2]})
+	return Affine{l[0], l[1], l[2], {-t.X, -t.Y, -t.Z}}, true
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,138 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+// randomAffineMat4 returns a matrix like randomMat4, without the projection part.
+func randomAffineMat4(r *rand.Rand) Mat4 {
+	m := randomMat4(r)
+	m[0][3], m[1][3], m[2][3], m[3][3] = 0, 0, 0, 1
+	return m
+}
+
+func isRoughlyEqualAffine(a, b Affine, epsilon float32) bool {
+	for c := range a {
+		if !isRoughlyEqualVec3(Vec3{a[c][0], a[c][1], a[c][2]}, Vec3{b[c][0], b[c][1], b[c][2]}, epsilon) {
+			return false
+		}
+	}
+	return true
+}
+
+//------------------------------------------------------------------------------
+
+func TestAffine_Mat4(t *testing.T) {
+	if a := Identity().Affine(); a != AffineIdentity() {
+		t.Errorf("Wrong result: %#v", a)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m := randomAffineMat4(r)
+		if n := m.Affine().Mat4(); n != m {
+			t.Errorf("No round-trip for %#v: %#v", m, n)
+		}
+	}
+	a := Translation(Vec3{1, 2, 3}).Affine()
+	if a[3] != [3]float32{1, 2, 3} {
+		t.Errorf("Wrong translation: %#v", a)
+	}
+}
+
+func TestAffine_Times(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m, n := randomAffineMat4(r), randomAffineMat4(r)
+		a, b := m.Affine(), n.Affine()
+		e := m.Times(&n)
+		if p := a.Times(&b); !isRoughlyEqualMat4(p.Mat4(), e, 1e-5) {
+			t.Errorf("Wrong result: %#v instead of %#v", p, e)
+		}
+		var p Affine
+		p.Multiply(&a, &b)
+		if p != a.Times(&b) {
+			t.Errorf("Multiply and Times differ: %#v", p)
+		}
+	}
+}
+
+func TestAffine_TransformPoint(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m := randomAffineMat4(r)
+		a := m.Affine()
+		v := Vec3{r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5}
+		e := m.TimesVec4(Vec4{v.X, v.Y, v.Z, 1})
+		if p := a.TransformPoint(v); !isRoughlyEqualVec3(p, Vec3{e.X, e.Y, e.Z}, 1e-6) {
+			t.Errorf("Wrong point: %#v instead of %#v", p, e)
+		}
+		e = m.TimesVec4(Vec4{v.X, v.Y, v.Z, 0})
+		if d := a.TransformDirection(v); !isRoughlyEqualVec3(d, Vec3{e.X, e.Y, e.Z}, 1e-6) {
+			t.Errorf("Wrong direction: %#v instead of %#v", d, e)
+		}
+	}
+}
+
+func TestAffine_Inverse(t *testing.T) {
+	if inv, ok := AffineIdentity().Inverse(); !ok || inv != AffineIdentity() {
+		t.Errorf("Wrong inverse of identity: %#v", inv)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m := randomAffineMat4(r)
+		a := m.Affine()
+		inv, ok := a.Inverse()
+		if !ok {
+			t.Fatalf("Reported singular: %#v", a)
+		}
+		if p := a.Times(&inv); !isRoughlyEqualAffine(p, AffineIdentity(), 1e-5) {
+			t.Errorf("A * A⁻¹ is not identity: %#v", p)
+		}
+		e, _ := m.Inverse()
+		if !isRoughlyEqualMat4(inv.Mat4(), e, 1e-5) {
+			t.Errorf("Wrong result: %#v instead of %#v", inv, e)
+		}
+	}
+	a := Scaling(Vec3{1, 0, 1}).Affine()
+	if _, ok := a.Inverse(); ok {
+		t.Errorf("Singular transformation not detected")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// Composition of a skinning palette with its inverse bind poses.
+
+func BenchmarkAffine_palette(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	pose, bind, palette := make([]Affine, 256), make([]Affine, 256), make([]Affine, 256)
+	for i := range pose {
+		pose[i], bind[i] = randomAffineMat4(r).Affine(), randomAffineMat4(r).Affine()
+	}
+	for i := 0; i < b.N; i++ {
+		for j := range palette {
+			palette[j].Multiply(&pose[j], &bind[j])
+		}
+	}
+}
+
+func BenchmarkMat4_palette(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	pose, bind, palette := make([]Mat4, 256), make([]Mat4, 256), make([]Mat4, 256)
+	for i := range pose {
+		pose[i], bind[i] = randomAffineMat4(r), randomAffineMat4(r)
+	}
+	for i := 0; i < b.N; i++ {
+		for j := range palette {
+			palette[j].Multiply(&pose[j], &bind[j])
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
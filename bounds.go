@@ -0,0 +1,167 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "github.com/drakmaniso/glam/math"
+
+//------------------------------------------------------------------------------
+
+// `BoundingSphere` returns a sphere enclosing all `points`.
+//
+// It uses Ritter's algorithm: the result is not the minimal sphere, but is
+// usually only 5 to 20% larger, and generally tighter than the sphere around
+// the bounding box. The returned radius is the exact distance from `center` to
+// the farthest point, so no point lies outside.
+//
+// If `points` is empty, the zero vector and a zero radius are returned.
+func BoundingSphere(points []Vec3) (center Vec3, radius float32) {
+	if len(points) == 0 {
+		return Vec3{}, 0
+	}
+
+	// Start with the sphere around two far apart points
+	y := farthest(points, points[0])
+	z := farthest(points, y)
+	center = Vec3{(y.X + z.X) / 2, (y.Y + z.Y) / 2, (y.Z + z.Z) / 2}
+	radius = z.Minus(y).Length() / 2
+
+	// Grow it to include any point left outside
+	for _, p := range points {
+		d := p.Minus(center)
+		l := d.Length()
+		if l > radius {
+			r := (radius + l) / 2
+			center.Add(d.Times((r - radius) / l))
+			radius = r
+		}
+	}
+
+	// Adjust the radius to the farthest point, to be robust to rounding
+	var r2 float32
+	for _, p := range points {
+		d := p.Minus(center)
+		if l2 := d.Dot(d); l2 > r2 {
+			r2 = l2
+		}
+	}
+	return center, math.Sqrt(r2)
+}
+
+// `farthest` returns the point in `points` farthest from `from`.
+func farthest(points []Vec3, from Vec3) Vec3 {
+	var f Vec3
+	max := float32(-1)
+	for _, p := range points {
+		d := p.Minus(from)
+		if l2 := d.Dot(d); l2 > max {
+			max = l2
+			f = p
+		}
+	}
+	return f
+}
+
+//------------------------------------------------------------------------------
+
+// `OBB` is an oriented bounding box.
+//
+// `Axes` is a rotation whose columns are the local axes of the box, and
+// `HalfExtents` the half sizes of the box along each of them.
+type OBB struct {
+	Center      Vec3
+	Axes        Mat3
+	HalfExtents Vec3
+}
+
+// `OBBFromPoints` returns an oriented box enclosing all `points`.
+//
+// The axes of the box are the principal axes of the points (the eigenvectors
+// of their covariance matrix, see `SymmetricEigen`). The result is not the
+// minimal box, but it is usually much tighter than the axis-aligned one for
+// elongated shapes.
+//
+// If `points` is empty, the result is an empty box at the origin.
+func OBBFromPoints(points []Vec3) OBB {
+	if len(points) == 0 {
+		return OBB{Axes: Mat3Identity()}
+	}
+
+	_, axes := Covariance(points).SymmetricEigen()
+	a := [3]Vec3{
+		{axes[0][0], axes[0][1], axes[0][2]},
+		{axes[1][0], axes[1][1], axes[1][2]},
+		{axes[2][0], axes[2][1], axes[2][2]},
+	}
+	pinf, ninf := math.Inf(1), math.Inf(-1)
+	min := Vec3{pinf, pinf, pinf}
+	max := Vec3{ninf, ninf, ninf}
+	for _, p := range points {
+		l := Vec3{p.Dot(a[0]), p.Dot(a[1]), p.Dot(a[2])}
+		min = min.Min(l)
+		max = max.Max(l)
+	}
+
+	return OBB{
+		Center:      axes.TimesVec3(Vec3{(min.X + max.X) / 2, (min.Y + max.Y) / 2, (min.Z + max.Z) / 2}),
+		Axes:        axes,
+		HalfExtents: Vec3{(max.X - min.X) / 2, (max.Y - min.Y) / 2, (max.Z - min.Z) / 2},
+	}
+}
+
+// `local` returns the coordinates of `p` in the frame of `b`.
+func (b OBB) local(p Vec3) Vec3 {
+	d := p.Minus(b.Center)
+	t := b.Axes.Transposed()
+	return t.TimesVec3(d)
+}
+
+// `Contains` returns true if `p` is inside `b`, or on its boundary.
+func (b OBB) Contains(p Vec3) bool {
+	l := b.local(p)
+	return math.Abs(l.X) <= b.HalfExtents.X &&
+		math.Abs(l.Y) <= b.HalfExtents.Y &&
+		math.Abs(l.Z) <= b.HalfExtents.Z
+}
+
+// `ClosestPoint` returns the point of `b` (inside or on the boundary) closest
+// to `p`. If `p` is inside `b`, it is returned unchanged.
+func (b OBB) ClosestPoint(p Vec3) Vec3 {
+	if b.Contains(p) {
+		return p
+	}
+	l := b.local(p)
+	h := b.HalfExtents
+	l = l.Max(h.Inverse()).Min(h)
+	return b.Axes.TimesVec3(l).Plus(b.Center)
+}
+
+//------------------------------------------------------------------------------
+
+// `Capsule` is the set of points within `Radius` of the segment from `A` to
+// `B`: a cylinder capped by two hemispheres.
+type Capsule struct {
+	A, B   Vec3
+	Radius float32
+}
+
+// `ClosestPoint` returns the point of `c` (inside or on the boundary) closest
+// to `p`. If `p` is inside `c`, it is returned unchanged.
+func (c Capsule) ClosestPoint(p Vec3) Vec3 {
+	s := ClosestPointOnSegment(p, c.A, c.B)
+	d := p.Minus(s)
+	l := d.Length()
+	if l <= c.Radius {
+		return p
+	}
+	return s.Plus(d.Times(c.Radius / l))
+}
+
+// `IntersectsSphere` returns true if `c` and the sphere of given `center` and
+// `radius` overlap, or touch.
+func (c Capsule) IntersectsSphere(center Vec3, radius float32) bool {
+	d := DistanceToSegment(center, c.A, c.B)
+	return d <= c.Radius+radius
+}
+
+//------------------------------------------------------------------------------
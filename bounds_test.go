@@ -0,0 +1,180 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func TestBoundingSphere(t *testing.T) {
+	if c, r := BoundingSphere(nil); c != (Vec3{}) || r != 0 {
+		t.Errorf("Wrong result for empty slice: %#v, %v", c, r)
+	}
+	if c, r := BoundingSphere([]Vec3{{1, 2, 3}}); c != (Vec3{1, 2, 3}) || r != 0 {
+		t.Errorf("Wrong result for single point: %#v, %v", c, r)
+	}
+
+	// Points on a sphere: the result must be close to it
+	octa := []Vec3{{5, 1, 1}, {-3, 1, 1}, {1, 5, 1}, {1, -3, 1}, {1, 1, 5}, {1, 1, -3}}
+	c, r := BoundingSphere(octa)
+	if !isRoughlyEqualVec3(c, Vec3{1, 1, 1}, 1e-5) || !math.IsRoughlyEqual(r, 4, 1e-5) {
+		t.Errorf("Wrong result: %#v, %v", c, r)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		n := 1 + rnd.Intn(200)
+		points := make([]Vec3, n)
+		for j := range points {
+			points[j] = Vec3{rnd.Float32()*20 - 10, rnd.Float32()*4 - 2, rnd.Float32()*100 - 50}
+		}
+		c, r := BoundingSphere(points)
+		for _, p := range points {
+			if d := p.Minus(c).Length(); d > r {
+				t.Errorf("Point %#v outside sphere %#v, %v (distance %v)", p, c, r, d)
+			}
+		}
+		// No sphere can be smaller than half the largest extent
+		min, max := Bounds(points)
+		e := max.Minus(min)
+		h := e.X
+		if e.Y > h {
+			h = e.Y
+		}
+		if e.Z > h {
+			h = e.Z
+		}
+		if r < h/2*0.9999 {
+			t.Errorf("Sphere too small: %v < %v", r, h/2)
+		}
+	}
+}
+
+func BenchmarkBoundingSphere(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	points := make([]Vec3, 1000)
+	for i := range points {
+		points[i] = Vec3{rnd.Float32(), rnd.Float32(), rnd.Float32()}
+	}
+	var c Vec3
+	var r float32
+	for i := 0; i < b.N; i++ {
+		c, r = BoundingSphere(points)
+	}
+	_, _ = c, r
+}
+
+//------------------------------------------------------------------------------
+
+func TestOBB_Contains(t *testing.T) {
+	b := OBB{
+		Center:      Vec3{1, 2, 3},
+		Axes:        Mat3RotationZ(math.Pi / 4),
+		HalfExtents: Vec3{2, 0.5, 1},
+	}
+	x := Vec3{math.Sqrt2 / 2, math.Sqrt2 / 2, 0}
+	y := Vec3{-math.Sqrt2 / 2, math.Sqrt2 / 2, 0}
+	for _, c := range []struct {
+		p      Vec3
+		inside bool
+	}{
+		{Vec3{1, 2, 3}, true},
+		{Vec3{1, 2, 3}.Plus(x.Times(1.9)), true},
+		{Vec3{1, 2, 3}.Plus(x.Times(-1.9)).Plus(y.Times(0.4)), true},
+		{Vec3{1, 2, 3.9}, true},
+		{Vec3{1, 2, 3}.Plus(x.Times(2.1)), false},
+		{Vec3{1, 2, 3}.Plus(y.Times(0.6)), false},
+		{Vec3{1, 2, 4.1}, false},
+		// Inside the axis-aligned box of b, but outside b
+		{Vec3{2.5, 2, 3}, false},
+	} {
+		if b.Contains(c.p) != c.inside {
+			t.Errorf("Wrong result for %#v: %v", c.p, !c.inside)
+		}
+	}
+
+	if p := b.ClosestPoint(Vec3{1, 2, 3.5}); p != (Vec3{1, 2, 3.5}) {
+		t.Errorf("Inside point modified: %#v", p)
+	}
+	p := b.ClosestPoint(Vec3{1, 2, 3}.Plus(x.Times(5)).Plus(y.Times(-3)).Plus(Vec3{0, 0, 0.5}))
+	e := Vec3{1, 2, 3}.Plus(x.Times(2)).Plus(y.Times(-0.5)).Plus(Vec3{0, 0, 0.5})
+	if !isRoughlyEqualVec3(p, e, 1e-5) {
+		t.Errorf("Wrong closest point: %#v instead of %#v", p, e)
+	}
+}
+
+func TestOBBFromPoints(t *testing.T) {
+	if b := OBBFromPoints(nil); b.HalfExtents != (Vec3{}) || b.Center != (Vec3{}) {
+		t.Errorf("Wrong result for empty slice: %#v", b)
+	}
+
+	// Elongated and rotated cloud
+	rnd := rand.New(rand.NewSource(1))
+	r := Mat3RotationAxis(Vec3{0, 0.6, 0.8}, 0.7)
+	points := make([]Vec3, 500)
+	for i := range points {
+		l := Vec3{rnd.Float32()*20 - 10, rnd.Float32()*4 - 2, rnd.Float32() - 0.5}
+		points[i] = r.TimesVec3(l).Plus(Vec3{5, -3, 8})
+	}
+	b := OBBFromPoints(points)
+	if !b.Axes.IsRigid(1e-5) {
+		t.Errorf("Axes not a rotation: %#v", b.Axes)
+	}
+	if !isRoughlyEqualVec3(b.HalfExtents, Vec3{10, 2, 0.5}, 0.3) {
+		t.Errorf("Wrong extents: %#v", b.HalfExtents)
+	}
+	inflated := b
+	inflated.HalfExtents = b.HalfExtents.Times(1 + 1e-5)
+	for _, p := range points {
+		if !inflated.Contains(p) {
+			t.Errorf("Point %#v outside box %#v", p, b)
+		}
+	}
+	min, max := Bounds(points)
+	e := max.Minus(min)
+	h := b.HalfExtents
+	if v, va := 8*h.X*h.Y*h.Z, e.X*e.Y*e.Z; v > va/2 {
+		t.Errorf("Box not tighter than AABB: volume %v instead of less than %v", v, va)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestCapsule(t *testing.T) {
+	c := Capsule{A: Vec3{0, 0, 0}, B: Vec3{0, 4, 0}, Radius: 1}
+	for _, k := range []struct{ p, e Vec3 }{
+		{Vec3{3, 2, 0}, Vec3{1, 2, 0}},       // beside the body
+		{Vec3{0, 7, 0}, Vec3{0, 5, 0}},       // above the top cap
+		{Vec3{0, -3, 4}, Vec3{0, -0.6, 0.8}}, // below the bottom cap
+		{Vec3{0.5, 3, 0}, Vec3{0.5, 3, 0}},   // inside
+	} {
+		if p := c.ClosestPoint(k.p); !isRoughlyEqualVec3(p, k.e, 1e-6) {
+			t.Errorf("Wrong closest point to %v: %#v instead of %#v", k.p, p, k.e)
+		}
+	}
+	for _, k := range []struct {
+		center Vec3
+		radius float32
+		ok     bool
+	}{
+		{Vec3{3, 2, 0}, 2, true},     // touching the body
+		{Vec3{3, 2, 0}, 1.9, false},  // close to the body
+		{Vec3{0, 4, 3}, 2, true},     // touching the top cap
+		{Vec3{2, 6, 0}, 1.9, true},   // overlapping the top cap
+		{Vec3{2, 6, 0}, 1.5, false},  // close to the top cap
+		{Vec3{0, -2, 0}, 0.9, false}, // below the bottom cap
+		{Vec3{0.2, 1, 0}, 0.1, true}, // inside
+	} {
+		if ok := c.IntersectsSphere(k.center, k.radius); ok != k.ok {
+			t.Errorf("Wrong result for sphere %v, %v: %v", k.center, k.radius, ok)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
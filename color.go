@@ -0,0 +1,255 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"image/color"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+// `RGBToHSV` converts the color `rgb` to (hue, saturation, value).
+//
+// All components are in [0,1], including the hue (which is a fraction of a
+// full turn). The hue of a gray color is undefined, and returned as 0.
+//
+// See also `HSVToRGB`.
+func (rgb Vec3) RGBToHSV() Vec3 {
+	max, min, h := hueOf(rgb)
+	var s float32
+	if max > 0 {
+		s = (max - min) / max
+	}
+	return Vec3{h, s, max}
+}
+
+// `HSVToRGB` converts the color `hsv`, given as (hue, saturation, value), to
+// RGB.
+//
+// The hue wraps around, so that any value is accepted; saturation and value
+// are clamped to [0,1].
+//
+// See also `RGBToHSV`.
+func (hsv Vec3) HSVToRGB() Vec3 {
+	s := math.Saturate(hsv.Y)
+	v := math.Saturate(hsv.Z)
+	c := v * s
+	return hueToRGB(hsv.X, c, v-c)
+}
+
+//------------------------------------------------------------------------------
+
+// `RGBToHSL` converts the color `rgb` to (hue, saturation, lightness).
+//
+// All components are in [0,1], including the hue (which is a fraction of a
+// full turn). The hue of a gray color is undefined, and returned as 0.
+//
+// See also `HSLToRGB`.
+func (rgb Vec3) RGBToHSL() Vec3 {
+	max, min, h := hueOf(rgb)
+	l := (max + min) / 2
+	var s float32
+	if d := max - min; d > 0 {
+		s = d / (1 - math.Abs(2*l-1))
+	}
+	return Vec3{h, s, l}
+}
+
+// `HSLToRGB` converts the color `hsl`, given as (hue, saturation, lightness),
+// to RGB.
+//
+// The hue wraps around, so that any value is accepted; saturation and
+// lightness are clamped to [0,1].
+//
+// See also `RGBToHSL`.
+func (hsl Vec3) HSLToRGB() Vec3 {
+	s := math.Saturate(hsl.Y)
+	l := math.Saturate(hsl.Z)
+	c := (1 - math.Abs(2*l-1)) * s
+	return hueToRGB(hsl.X, c, l-c/2)
+}
+
+//------------------------------------------------------------------------------
+
+// `LinearToSRGB` converts the linear color `c` to the sRGB color space, using
+// the piecewise sRGB transfer function.
+//
+// Components are clamped to [0,1].
+//
+// See also `SRGBToLinear`.
+func (c Vec3) LinearToSRGB() Vec3 {
+	return Vec3{linearToSRGB(c.X), linearToSRGB(c.Y), linearToSRGB(c.Z)}
+}
+
+// `SRGBToLinear` converts the sRGB color `c` to linear color space, using the
+// piecewise sRGB transfer function.
+//
+// Components are clamped to [0,1].
+//
+// See also `LinearToSRGB`.
+func (c Vec3) SRGBToLinear() Vec3 {
+	return Vec3{srgbToLinear(c.X), srgbToLinear(c.Y), srgbToLinear(c.Z)}
+}
+
+// `LinearToSRGB` converts the linear color `c` to the sRGB color space. The
+// alpha channel `c.W` is left untouched.
+//
+// See `Vec3.LinearToSRGB`.
+func (c Vec4) LinearToSRGB() Vec4 {
+	return Vec4{linearToSRGB(c.X), linearToSRGB(c.Y), linearToSRGB(c.Z), c.W}
+}
+
+// `SRGBToLinear` converts the sRGB color `c` to linear color space. The alpha
+// channel `c.W` is left untouched.
+//
+// See `Vec3.SRGBToLinear`.
+func (c Vec4) SRGBToLinear() Vec4 {
+	return Vec4{srgbToLinear(c.X), srgbToLinear(c.Y), srgbToLinear(c.Z), c.W}
+}
+
+func linearToSRGB(x float32) float32 {
+	x = math.Saturate(x)
+	if x <= 0.0031308 {
+		return 12.92 * x
+	}
+	return 1.055*math.Pow(x, 1/2.4) - 0.055
+}
+
+func srgbToLinear(x float32) float32 {
+	x = math.Saturate(x)
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
+
+//------------------------------------------------------------------------------
+
+// `PackRGBA8` returns the color `c` packed into 8 bits per channel, as
+// 0xAABBGGRR: red is in the least significant byte and alpha in the most
+// significant one. On little-endian machines, this is the same byte order as
+// the pixels of `image.RGBA` (i.e. R, G, B, A in memory).
+//
+// Components are clamped to [0,1], and rounded to the nearest integer.
+//
+// See also `UnpackRGBA8`.
+func (c Vec4) PackRGBA8() uint32 {
+	return uint32(toByte(c.X)) |
+		uint32(toByte(c.Y))<<8 |
+		uint32(toByte(c.Z))<<16 |
+		uint32(toByte(c.W))<<24
+}
+
+// `UnpackRGBA8` returns the color packed in `p` as 0xAABBGGRR, with each
+// component in [0,1].
+//
+// See also `PackRGBA8`.
+func UnpackRGBA8(p uint32) Vec4 {
+	return Vec4{
+		float32(p&0xFF) / 255,
+		float32(p>>8&0xFF) / 255,
+		float32(p>>16&0xFF) / 255,
+		float32(p>>24) / 255,
+	}
+}
+
+func toByte(x float32) uint8 {
+	return uint8(math.Saturate(x)*255 + 0.5)
+}
+
+//------------------------------------------------------------------------------
+
+// `RGBA` implements the `color.Color` interface of the standard library, so
+// that `c` can be used directly with the `image` packages.
+//
+// `c` is interpreted as a non-premultiplied color, with components clamped
+// to [0,1]. There is no sRGB conversion: the components are used as they are
+// (see `LinearToSRGB` if `c` is in linear space).
+//
+// See also `FromColor`.
+func (c Vec4) RGBA() (r, g, b, a uint32) {
+	alpha := math.Saturate(c.W)
+	r = uint32(math.Saturate(c.X)*alpha*0xFFFF + 0.5)
+	g = uint32(math.Saturate(c.Y)*alpha*0xFFFF + 0.5)
+	b = uint32(math.Saturate(c.Z)*alpha*0xFFFF + 0.5)
+	a = uint32(alpha*0xFFFF + 0.5)
+	return r, g, b, a
+}
+
+// `FromColor` converts any color of the standard library to a
+// non-premultiplied `Vec4`, with components in [0,1]. A fully transparent
+// color returns the zero vector.
+//
+// As with `RGBA`, there is no sRGB conversion: the components are returned as
+// they are stored (see `SRGBToLinear`).
+func FromColor(c color.Color) Vec4 {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return Vec4{}
+	}
+	fa := float32(a)
+	return Vec4{float32(r) / fa, float32(g) / fa, float32(b) / fa, fa / 0xFFFF}
+}
+
+//------------------------------------------------------------------------------
+
+// `hueOf` returns the largest and smallest components of `rgb`, and its hue
+// in [0,1).
+func hueOf(rgb Vec3) (max, min, hue float32) {
+	max, min = rgb.X, rgb.X
+	if rgb.Y > max {
+		max = rgb.Y
+	}
+	if rgb.Z > max {
+		max = rgb.Z
+	}
+	if rgb.Y < min {
+		min = rgb.Y
+	}
+	if rgb.Z < min {
+		min = rgb.Z
+	}
+
+	d := max - min
+	switch {
+	case d == 0:
+		return max, min, 0
+	case max == rgb.X:
+		hue = (rgb.Y - rgb.Z) / d
+		if hue < 0 {
+			hue += 6
+		}
+	case max == rgb.Y:
+		hue = (rgb.Z-rgb.X)/d + 2
+	default:
+		hue = (rgb.X-rgb.Y)/d + 4
+	}
+	return max, min, hue / 6
+}
+
+// `hueToRGB` returns the color of hue `h` (wrapped into [0,1)), chroma `c`,
+// and smallest component `m`.
+func hueToRGB(h, c, m float32) Vec3 {
+	h = (h - math.Floor(h)) * 6
+	x := c * (1 - math.Abs(h-2*math.Floor(h/2)-1))
+
+	switch {
+	case h < 1:
+		return Vec3{c + m, x + m, m}
+	case h < 2:
+		return Vec3{x + m, c + m, m}
+	case h < 3:
+		return Vec3{m, c + m, x + m}
+	case h < 4:
+		return Vec3{m, x + m, c + m}
+	case h < 5:
+		return Vec3{x + m, m, c + m}
+	default:
+		return Vec3{c + m, m, x + m}
+	}
+}
+
+//------------------------------------------------------------------------------
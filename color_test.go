@@ -0,0 +1,261 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+var testColors = []Vec3{
+	{0, 0, 0},
+	{1, 1, 1},
+	{0.5, 0.5, 0.5},
+	{0.2, 0.2, 0.2},
+	{1, 0, 0},
+	{0, 1, 0},
+	{0, 0, 1},
+	{1, 1, 0},
+	{0, 1, 1},
+	{1, 0, 1},
+	{0.8, 0.3, 0.1},
+	{0.1, 0.9, 0.4},
+	{0.25, 0.5, 0.75},
+	{0.6, 0.1, 0.7},
+	{0.99, 0.01, 0.5},
+}
+
+//------------------------------------------------------------------------------
+
+func TestVec3_RGBToHSV(t *testing.T) {
+	cases := []struct{ rgb, hsv Vec3 }{
+		{Vec3{1, 0, 0}, Vec3{0, 1, 1}},
+		{Vec3{0, 1, 0}, Vec3{1.0 / 3, 1, 1}},
+		{Vec3{0, 0, 1}, Vec3{2.0 / 3, 1, 1}},
+		{Vec3{1, 0, 1}, Vec3{5.0 / 6, 1, 1}},
+		{Vec3{0.5, 0.25, 0.25}, Vec3{0, 0.5, 0.5}},
+		{Vec3{0.5, 0.5, 0.5}, Vec3{0, 0, 0.5}},
+		{Vec3{0, 0, 0}, Vec3{0, 0, 0}},
+	}
+	for _, c := range cases {
+		hsv := c.rgb.RGBToHSV()
+		if !isRoughlyEqualVec3(hsv, c.hsv, 1e-6) {
+			t.Errorf("Wrong result for %#v: %#v", c.rgb, hsv)
+		}
+	}
+}
+
+func TestVec3_HSVToRGB(t *testing.T) {
+	for _, c := range testColors {
+		hsv := c.RGBToHSV()
+		if hsv.X < 0 || hsv.X >= 1 || hsv.Y < 0 || hsv.Y > 1 || hsv.Z < 0 || hsv.Z > 1 {
+			t.Errorf("Out of range for %#v: %#v", c, hsv)
+		}
+		rgb := hsv.HSVToRGB()
+		if !isRoughlyEqualVec3(rgb, c, 1e-6) {
+			t.Errorf("No round-trip for %#v: %#v", c, rgb)
+		}
+	}
+	a := Vec3{1.25, 1, 1}.HSVToRGB()
+	b := Vec3{-0.75, 1, 1}.HSVToRGB()
+	c := Vec3{0.25, 1, 1}.HSVToRGB()
+	if !isRoughlyEqualVec3(a, c, 1e-6) || !isRoughlyEqualVec3(b, c, 1e-6) {
+		t.Errorf("Hue not wrapped: %#v, %#v instead of %#v", a, b, c)
+	}
+	d := Vec3{0, 2, 1.5}.HSVToRGB()
+	if d != (Vec3{1, 0, 0}) {
+		t.Errorf("Not clamped: %#v", d)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestVec3_RGBToHSL(t *testing.T) {
+	cases := []struct{ rgb, hsl Vec3 }{
+		{Vec3{1, 0, 0}, Vec3{0, 1, 0.5}},
+		{Vec3{0, 1, 0}, Vec3{1.0 / 3, 1, 0.5}},
+		{Vec3{0.5, 0.25, 0.25}, Vec3{0, 1.0 / 3, 0.375}},
+		{Vec3{0.5, 0.5, 0.5}, Vec3{0, 0, 0.5}},
+		{Vec3{1, 1, 1}, Vec3{0, 0, 1}},
+	}
+	for _, c := range cases {
+		hsl := c.rgb.RGBToHSL()
+		if !isRoughlyEqualVec3(hsl, c.hsl, 1e-6) {
+			t.Errorf("Wrong result for %#v: %#v", c.rgb, hsl)
+		}
+	}
+}
+
+func TestVec3_HSLToRGB(t *testing.T) {
+	for _, c := range testColors {
+		hsl := c.RGBToHSL()
+		if hsl.X < 0 || hsl.X >= 1 || hsl.Y < 0 || hsl.Y > 1 || hsl.Z < 0 || hsl.Z > 1 {
+			t.Errorf("Out of range for %#v: %#v", c, hsl)
+		}
+		rgb := hsl.HSLToRGB()
+		if !isRoughlyEqualVec3(rgb, c, 1e-6) {
+			t.Errorf("No round-trip for %#v: %#v", c, rgb)
+		}
+	}
+	a := Vec3{2.5, 1, 0.5}.HSLToRGB()
+	b := Vec3{0.5, 1, 0.5}.HSLToRGB()
+	if !isRoughlyEqualVec3(a, b, 1e-6) {
+		t.Errorf("Hue not wrapped: %#v instead of %#v", a, b)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestVec3_LinearToSRGB(t *testing.T) {
+	cases := []struct{ linear, srgb float32 }{
+		{0, 0},
+		{1, 1},
+		{0.0031308, 0.04045},
+		{0.001, 0.01292},
+		{0.5, 0.7353570},
+		{0.2140411, 0.5},
+		{0.0404500, 0.2222055},
+	}
+	for _, c := range cases {
+		s := Vec3{c.linear, c.linear, c.linear}.LinearToSRGB()
+		if !isRoughlyEqualVec3(s, Vec3{c.srgb, c.srgb, c.srgb}, 2e-6) {
+			t.Errorf("Wrong result for %v: %#v instead of %v", c.linear, s, c.srgb)
+		}
+		l := Vec3{c.srgb, c.srgb, c.srgb}.SRGBToLinear()
+		if !isRoughlyEqualVec3(l, Vec3{c.linear, c.linear, c.linear}, 2e-6) {
+			t.Errorf("Wrong result for %v: %#v instead of %v", c.srgb, l, c.linear)
+		}
+	}
+}
+
+func TestVec3_SRGBToLinear(t *testing.T) {
+	// Both branches must meet at the breakpoint
+	below := Vec3{0.04045, 0, 0}.SRGBToLinear().X
+	above := Vec3{0.0404501, 0, 0}.SRGBToLinear().X
+	if above < below || above-below > 1e-6 {
+		t.Errorf("Discontinuity at breakpoint: %v, %v", below, above)
+	}
+	for i := 0; i <= 100; i++ {
+		x := float32(i) / 100
+		c := Vec3{x, x * x, 1 - x}
+		r := c.LinearToSRGB().SRGBToLinear()
+		if !isRoughlyEqualVec3(r, c, 2e-6) {
+			t.Errorf("No round-trip for %#v: %#v", c, r)
+		}
+	}
+	c := Vec3{-1, 2, 0.5}.SRGBToLinear()
+	if c.X != 0 || c.Y != 1 {
+		t.Errorf("Not clamped: %#v", c)
+	}
+}
+
+func TestVec4_LinearToSRGB(t *testing.T) {
+	a := Vec4{0.5, 0.25, 0.001, 0.3}
+	b := a.LinearToSRGB()
+	c := Vec3{a.X, a.Y, a.Z}.LinearToSRGB()
+	if b.X != c.X || b.Y != c.Y || b.Z != c.Z || b.W != 0.3 {
+		t.Errorf("Wrong result: %#v", b)
+	}
+	d := b.SRGBToLinear()
+	if d.W != 0.3 {
+		t.Errorf("Alpha modified: %#v", d)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestVec4_PackRGBA8(t *testing.T) {
+	p := Vec4{1, 0, 0.5, 1}.PackRGBA8()
+	if p != 0xFF8000FF {
+		t.Errorf("Wrong result: %#x", p)
+	}
+	p = Vec4{-0.5, 2, 0.2, -1}.PackRGBA8()
+	if p != 0x0033FF00 {
+		t.Errorf("Not clamped: %#x", p)
+	}
+	for i := 0; i < 256; i++ {
+		b := uint32(i)
+		p := b | (255-b)<<8 | (b*7&0xFF)<<16 | (b*13&0xFF)<<24
+		c := UnpackRGBA8(p)
+		if c.PackRGBA8() != p {
+			t.Errorf("No round-trip for %#x: %#v", p, c)
+		}
+	}
+}
+
+func TestUnpackRGBA8(t *testing.T) {
+	c := UnpackRGBA8(0x80FF0033)
+	if c != (Vec4{0.2, 0, 1, 128.0 / 255}) {
+		t.Errorf("Wrong result: %#v", c)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+var _ color.Color = Vec4{}
+
+func TestVec4_RGBA(t *testing.T) {
+	for _, c := range []color.RGBA{
+		{0, 0, 0, 255}, {255, 255, 255, 255}, {255, 128, 0, 255}, {12, 34, 56, 255},
+	} {
+		v := UnpackRGBA8(uint32(c.R) | uint32(c.G)<<8 | uint32(c.B)<<16 | uint32(c.A)<<24)
+		r, g, b, a := v.RGBA()
+		er, eg, eb, ea := c.RGBA()
+		if r != er || g != eg || b != eb || a != ea {
+			t.Errorf("Wrong result for %#v: %d, %d, %d, %d", c, r, g, b, a)
+		}
+	}
+	for _, c := range []color.NRGBA{
+		{255, 128, 0, 128}, {10, 200, 30, 64}, {255, 255, 255, 0},
+	} {
+		v := UnpackRGBA8(uint32(c.R) | uint32(c.G)<<8 | uint32(c.B)<<16 | uint32(c.A)<<24)
+		r, g, b, a := v.RGBA()
+		er, eg, eb, ea := c.RGBA()
+		if absDiff(r, er) > 1 || absDiff(g, eg) > 1 || absDiff(b, eb) > 1 || a != ea {
+			t.Errorf("Wrong result for %#v: %d, %d, %d, %d", c, r, g, b, a)
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, Vec4{1, 0.5, 0, 1})
+	if p := img.RGBAAt(0, 0); p != (color.RGBA{255, 128, 0, 255}) {
+		t.Errorf("Wrong pixel: %#v", p)
+	}
+}
+
+func TestFromColor(t *testing.T) {
+	c := FromColor(color.RGBA{255, 51, 0, 255})
+	if c != (Vec4{1, 0.2, 0, 1}) {
+		t.Errorf("Wrong result: %#v", c)
+	}
+	c = FromColor(color.NRGBA{255, 51, 0, 128})
+	if !isRoughlyEqualVec4(c, Vec4{1, 0.2, 0, 128.0 / 255}, 1e-4) {
+		t.Errorf("Wrong result: %#v", c)
+	}
+	c = FromColor(color.RGBA{128, 64, 0, 128})
+	if !isRoughlyEqualVec4(c, Vec4{1, 0.5, 0, 128.0 / 255}, 1e-4) {
+		t.Errorf("Not un-premultiplied: %#v", c)
+	}
+	if c = FromColor(color.Transparent); c != (Vec4{}) {
+		t.Errorf("Wrong result for transparent: %#v", c)
+	}
+	for _, v := range []Vec4{{1, 0.2, 0.6, 1}, {0.3, 0.9, 0.1, 0.5}} {
+		w := FromColor(v)
+		if !isRoughlyEqualVec4(w, v, 1e-4) {
+			t.Errorf("No round-trip for %#v: %#v", v, w)
+		}
+	}
+}
+
+func absDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+//------------------------------------------------------------------------------
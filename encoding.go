@@ -0,0 +1,114 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+// The binary encoding of a vector is its components in order, each as the
+// little-endian IEEE 754 representation of a float32. It does not depend on
+// the names of the fields, nor on the platform.
+
+// `putFloat32s` writes the binary encoding of `f` into a new slice.
+func putFloat32s(f ...float32) []byte {
+	b := make([]byte, 4*len(f))
+	for i := range f {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(f[i]))
+	}
+	return b
+}
+
+// `getFloat32s` reads the binary encoding `b` into `f`.
+func getFloat32s(method string, b []byte, f ...*float32) error {
+	if len(b) != 4*len(f) {
+		return fmt.Errorf("glam.%s: %d bytes instead of %d", method, len(b), 4*len(f))
+	}
+	for i := range f {
+		*f[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// `MarshalBinary` implements `encoding.BinaryMarshaler`: the result is the 8
+// bytes of the components, as little-endian float32.
+func (a Vec2) MarshalBinary() ([]byte, error) {
+	return putFloat32s(a.X, a.Y), nil
+}
+
+// `UnmarshalBinary` implements `encoding.BinaryUnmarshaler`. See
+// `MarshalBinary`.
+func (a *Vec2) UnmarshalBinary(b []byte) error {
+	return getFloat32s("Vec2.UnmarshalBinary", b, &a.X, &a.Y)
+}
+
+// `GobEncode` implements `gob.GobEncoder`, with the same encoding as
+// `MarshalBinary`.
+func (a Vec2) GobEncode() ([]byte, error) {
+	return a.MarshalBinary()
+}
+
+// `GobDecode` implements `gob.GobDecoder`. See `GobEncode`.
+func (a *Vec2) GobDecode(b []byte) error {
+	return getFloat32s("Vec2.GobDecode", b, &a.X, &a.Y)
+}
+
+//------------------------------------------------------------------------------
+
+// `MarshalBinary` implements `encoding.BinaryMarshaler`: the result is the 12
+// bytes of the components, as little-endian float32.
+func (a Vec3) MarshalBinary() ([]byte, error) {
+	return putFloat32s(a.X, a.Y, a.Z), nil
+}
+
+// `UnmarshalBinary` implements `encoding.BinaryUnmarshaler`. See
+// `MarshalBinary`.
+func (a *Vec3) UnmarshalBinary(b []byte) error {
+	return getFloat32s("Vec3.UnmarshalBinary", b, &a.X, &a.Y, &a.Z)
+}
+
+// `GobEncode` implements `gob.GobEncoder`, with the same encoding as
+// `MarshalBinary`.
+func (a Vec3) GobEncode() ([]byte, error) {
+	return a.MarshalBinary()
+}
+
+// `GobDecode` implements `gob.GobDecoder`. See `GobEncode`.
+func (a *Vec3) GobDecode(b []byte) error {
+	return getFloat32s("Vec3.GobDecode", b, &a.X, &a.Y, &a.Z)
+}
+
+//------------------------------------------------------------------------------
+
+// `MarshalBinary` implements `encoding.BinaryMarshaler`: the result is the 16
+// bytes of the components, as little-endian float32.
+func (a Vec4) MarshalBinary() ([]byte, error) {
+	return putFloat32s(a.X, a.Y, a.Z, a.W), nil
+}
+
+// `UnmarshalBinary` implements `encoding.BinaryUnmarshaler`. See
+// `MarshalBinary`.
+func (a *Vec4) UnmarshalBinary(b []byte) error {
+	return getFloat32s("Vec4.UnmarshalBinary", b, &a.X, &a.Y, &a.Z, &a.W)
+}
+
+// `GobEncode` implements `gob.GobEncoder`, with the same encoding as
+// `MarshalBinary`.
+func (a Vec4) GobEncode() ([]byte, error) {
+	return a.MarshalBinary()
+}
+
+// `GobDecode` implements `gob.GobDecoder`. See `GobEncode`.
+func (a *Vec4) GobDecode(b []byte) error {
+	return getFloat32s("Vec4.GobDecode", b, &a.X, &a.Y, &a.Z, &a.W)
+}
+
+//------------------------------------------------------------------------------
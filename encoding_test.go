@@ -0,0 +1,81 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func TestVec3_MarshalBinary(t *testing.T) {
+	b, err := Vec3{1, -2, 0.5}.MarshalBinary()
+	e := []byte{
+		0x00, 0x00, 0x80, 0x3f,
+		0x00, 0x00, 0x00, 0xc0,
+		0x00, 0x00, 0x00, 0x3f,
+	}
+	if err != nil || !bytes.Equal(b, e) {
+		t.Errorf("Wrong result: %#v, %v", b, err)
+	}
+	var v Vec3
+	if err := v.UnmarshalBinary(b); err != nil || v != (Vec3{1, -2, 0.5}) {
+		t.Errorf("Wrong result: %#v, %v", v, err)
+	}
+	if g, err := v.GobEncode(); err != nil || !bytes.Equal(g, b) {
+		t.Errorf("Gob and binary encodings differ: %#v", g)
+	}
+	if err := v.UnmarshalBinary(b[:8]); err == nil {
+		t.Errorf("Wrong length not detected")
+	}
+	var w Vec4
+	if err := w.GobDecode(b); err == nil {
+		t.Errorf("Wrong length not detected")
+	}
+}
+
+func TestVec_Gob(t *testing.T) {
+	type scene struct {
+		Points  []Vec3
+		Origin  Vec2
+		Color   Vec4
+		Special []Vec3
+	}
+	s := scene{
+		Points: []Vec3{{1, 2, 3}, {-4.5, 0, 1e-30}, {}},
+		Origin: Vec2{7, -8},
+		Color:  Vec4{0.1, 0.2, 0.3, 1},
+		Special: []Vec3{
+			{math.Inf(1), math.Inf(-1), 0},
+			{math.MaxFloat32, math.SmallestNonzeroFloat32, -1},
+		},
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		t.Fatalf("Encoding error: %v", err)
+	}
+	var d scene
+	if err := gob.NewDecoder(&buf).Decode(&d); err != nil {
+		t.Fatalf("Decoding error: %v", err)
+	}
+	if d.Origin != s.Origin || d.Color != s.Color || len(d.Points) != len(s.Points) || len(d.Special) != len(s.Special) {
+		t.Fatalf("Wrong result: %#v", d)
+	}
+	for i := range s.Points {
+		if d.Points[i] != s.Points[i] {
+			t.Errorf("Wrong point %d: %#v", i, d.Points[i])
+		}
+	}
+	for i := range s.Special {
+		if d.Special[i] != s.Special[i] {
+			t.Errorf("Wrong special value %d: %#v", i, d.Special[i])
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
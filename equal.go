@@ -0,0 +1,40 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "github.com/drakmaniso/glam/math"
+
+//------------------------------------------------------------------------------
+
+// `roughlyEqual` returns true if each element of `a` is within `epsilon` of
+// the corresponding element of `b` (as with `math.IsRoughlyEqual`).
+func roughlyEqual(a, b []float32, epsilon float32) bool {
+	for i := range a {
+		if !math.IsRoughlyEqual(a[i], b[i], epsilon) {
+			return false
+		}
+	}
+	return true
+}
+
+// `nearlyEqual` returns true if each element of `a` is within `epsilon` times
+// the largest absolute element of `a` and `b` of the corresponding element of
+// `b`.
+func nearlyEqual(a, b []float32, epsilon float32) bool {
+	var diff, largest float32
+	for i := range a {
+		if d := math.Abs(a[i] - b[i]); d > diff {
+			diff = d
+		}
+		if x := math.Abs(a[i]); x > largest {
+			largest = x
+		}
+		if x := math.Abs(b[i]); x > largest {
+			largest = x
+		}
+	}
+	return diff == 0 || diff < epsilon*largest
+}
+
+//------------------------------------------------------------------------------
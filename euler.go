@@ -0,0 +1,161 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"fmt"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+// `EulerOrder` is the order in which the three rotations of a set of Euler
+// angles are applied. The rotations are around the fixed axes of the parent
+// space (extrinsic rotations): `EulerXYZ` rotates around X first, then around
+// Y, then around Z, i.e. its matrix is Rz * Ry * Rx.
+//
+// This is the same as rotating around the axes of the rotated object in the
+// reverse order (intrinsic rotations): `EulerXYZ` is also Z, then the new Y,
+// then the newest X.
+type EulerOrder int
+
+// The six orders using three distinct axes (Tait-Bryan angles).
+const (
+	EulerXYZ EulerOrder = iota
+	EulerXZY
+	EulerYXZ
+	EulerYZX
+	EulerZXY
+	EulerZYX
+)
+
+// `String` returns the name of the order, e.g. "XYZ".
+func (o EulerOrder) String() string {
+	switch o {
+	case EulerXYZ:
+		return "XYZ"
+	case EulerXZY:
+		return "XZY"
+	case EulerYXZ:
+		return "YXZ"
+	case EulerYZX:
+		return "YZX"
+	case EulerZXY:
+		return "ZXY"
+	case EulerZYX:
+		return "ZYX"
+	default:
+		return fmt.Sprintf("EulerOrder(%d)", int(o))
+	}
+}
+
+// `axes` returns the indices of the first, second and third axes of
+// rotation of `o`, and true if the permutation is cyclic.
+func (o EulerOrder) axes() (i, j, k int, even bool) {
+	switch o {
+	case EulerXZY:
+		return 0, 2, 1, false
+	case EulerYXZ:
+		return 1, 0, 2, false
+	case EulerYZX:
+		return 1, 2, 0, true
+	case EulerZXY:
+		return 2, 0, 1, true
+	case EulerZYX:
+		return 2, 1, 0, false
+	default:
+		return 0, 1, 2, true
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Mat3FromEuler` returns the rotation matrix for the Euler `angles` (in
+// radians) applied in `order`. Each coordinate of `angles` is the angle
+// around the corresponding axis: `angles.X` around X, and so on.
+//
+// See also `EulerAngles` and `QuatFromEuler`.
+func Mat3FromEuler(angles Vec3, order EulerOrder) Mat3 {
+	r := [3]Mat3{
+		Mat3RotationX(angles.X),
+		Mat3RotationY(angles.Y),
+		Mat3RotationZ(angles.Z),
+	}
+	i, j, k, _ := order.axes()
+	m := r[k].Times(&r[j])
+	return m.Times(&r[i])
+}
+
+// `QuatFromEuler` returns the quaternion for the Euler `angles` (in radians)
+// applied in `order`. It is the same rotation as `Mat3FromEuler`.
+func QuatFromEuler(angles Vec3, order EulerOrder) Quat {
+	half := [3]float32{angles.X / 2, angles.Y / 2, angles.Z / 2}
+	var r [3]Quat
+	for a := range r {
+		r[a].W = math.Cos(half[a])
+	}
+	r[0].X = math.Sin(half[0])
+	r[1].Y = math.Sin(half[1])
+	r[2].Z = math.Sin(half[2])
+	i, j, k, _ := order.axes()
+	return r[k].Times(r[j]).Times(r[i])
+}
+
+// `EulerAngles` returns the Euler angles (in radians) for the rotation `m`,
+// when applied in `order`, and true. It is the inverse of `Mat3FromEuler`. The
+// matrix must be a rotation (see `IsRigid`).
+//
+// The second angle of `order` is in [-Pi/2, Pi/2], and the two others in
+// [-Pi, Pi]. When the second angle is ±Pi/2 (gimbal lock), only the sum or
+// difference of the other two is defined: the third angle is then set to
+// zero, and the boolean is false. The returned angles still rebuild `m`.
+func (m Mat3) EulerAngles(order EulerOrder) (Vec3, bool) {
+	i, j, k, even := order.axes()
+	s := float32(1)
+	if !even {
+		s = -1
+	}
+	// at returns the element at row r and column c
+	at := func(r, c int) float32 { return m[c][r] }
+	var a [3]float32
+
+	sb := -s * at(k, i)
+	cb := math.Sqrt(at(i, i)*at(i, i) + at(j, i)*at(j, i))
+	a[j] = math.Atan2(sb, cb)
+	if cb > 1e-5 {
+		a[i] = math.Atan2(s*at(k, j), at(k, k))
+		a[k] = math.Atan2(s*at(j, i), at(i, i))
+		return Vec3{a[0], a[1], a[2]}, true
+	}
+
+	// Gimbal lock: without the third rotation, m is Rj * Ri, so Ri is
+	// Rjᵀ * m, from which the first angle is read.
+	r := [3]func(float32) Mat3{Mat3RotationX, Mat3RotationY, Mat3RotationZ}
+	rj := r[j](a[j]).Transposed()
+	ri := rj.Times(&m)
+	p, q := (i+1)%3, (i+2)%3
+	a[i] = math.Atan2(ri[p][q], ri[p][p])
+	return Vec3{a[0], a[1], a[2]}, false
+}
+
+// `Euler` returns the Euler angles (in radians) of the rotation `q`, which must
+// be normalized, when applied in `order`. It is the inverse of
+// `QuatFromEuler`, with the same ranges and gimbal lock convention as
+// `Mat3.EulerAngles`.
+//
+// The second angle is computed with an arc tangent rather than an arc sine, so
+// rounding errors never produce a NaN.
+func (q Quat) Euler(order EulerOrder) Vec3 {
+	a, _ := q.Mat3().EulerAngles(order)
+	return a
+}
+
+// `EulerAngles` returns the Euler angles of the rotation part of `m`. See
+// `Mat3.EulerAngles`.
+func (m Mat4) EulerAngles(order EulerOrder) (Vec3, bool) {
+	return m.Mat3().EulerAngles(order)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,162 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+var eulerOrders = []EulerOrder{EulerXYZ, EulerXZY, EulerYXZ, EulerYZX, EulerZXY, EulerZYX}
+
+func TestMat3FromEuler(t *testing.T) {
+	a := Vec3{0.3, -0.5, 1.2}
+	rx, ry, rz := Mat3RotationX(a.X), Mat3RotationY(a.Y), Mat3RotationZ(a.Z)
+	e := rz.Times(&ry)
+	e = e.Times(&rx)
+	if m := Mat3FromEuler(a, EulerXYZ); !isRoughlyEqualMat3(m, e, 1e-6) {
+		t.Errorf("Wrong XYZ rotation: %#v instead of %#v", m, e)
+	}
+	e = rx.Times(&ry)
+	e = e.Times(&rz)
+	if m := Mat3FromEuler(a, EulerZYX); !isRoughlyEqualMat3(m, e, 1e-6) {
+		t.Errorf("Wrong ZYX rotation: %#v instead of %#v", m, e)
+	}
+}
+
+func TestQuatFromEuler(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	axes := [3]Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for _, o := range eulerOrders {
+		i, j, k, _ := o.axes()
+		for n := 0; n < 50; n++ {
+			a := [3]float32{
+				(r.Float32()*2 - 1) * math.Pi,
+				(r.Float32()*2 - 1) * math.Pi,
+				(r.Float32()*2 - 1) * math.Pi,
+			}
+			angles := Vec3{a[0], a[1], a[2]}
+			q := QuatFromEuler(angles, o)
+			qi := QuatFromAxisAngle(axes[i], a[i])
+			qj := QuatFromAxisAngle(axes[j], a[j])
+			qk := QuatFromAxisAngle(axes[k], a[k])
+			if e := qk.Times(qj.Times(qi)); !isRoughlyEqualQuat(q, e, 1e-6) {
+				t.Errorf("Wrong result for order %v, %v: %#v instead of %#v", o, angles, q, e)
+			}
+			if m, e := q.Mat3(), Mat3FromEuler(angles, o); !isRoughlyEqualMat3(m, e, 1e-5) {
+				t.Errorf("Differs from Mat3FromEuler for order %v, %v: %#v instead of %#v", o, angles, m, e)
+			}
+		}
+	}
+	if q := QuatFromEuler(Vec3{}, EulerZXY); q != QuatIdentity() {
+		t.Errorf("Wrong result for zero angles: %#v", q)
+	}
+}
+
+func TestEulerOrder_String(t *testing.T) {
+	names := []string{"XYZ", "XZY", "YXZ", "YZX", "ZXY", "ZYX"}
+	for i, o := range eulerOrders {
+		if s := o.String(); s != names[i] {
+			t.Errorf("Wrong name for %d: %q", int(o), s)
+		}
+	}
+	if s := EulerOrder(42).String(); s != "EulerOrder(42)" {
+		t.Errorf("Wrong name for invalid order: %q", s)
+	}
+}
+
+func TestMat3_EulerAngles(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, o := range eulerOrders {
+		i, j, k, _ := o.axes()
+		for n := 0; n < 200; n++ {
+			var a [3]float32
+			a[i] = (r.Float32()*2 - 1) * math.Pi
+			a[j] = (r.Float32()*2 - 1) * math.Pi / 2 * 0.99
+			a[k] = (r.Float32()*2 - 1) * math.Pi
+			angles := Vec3{a[0], a[1], a[2]}
+			m := Mat3FromEuler(angles, o)
+			b, ok := m.EulerAngles(o)
+			if !ok {
+				t.Errorf("Gimbal lock reported for order %v, %v", o, angles)
+			}
+			if !isRoughlyEqualVec3(b, angles, 1e-3) {
+				t.Errorf("Wrong angles for order %v: %v instead of %v", o, b, angles)
+			}
+			if n := Mat3FromEuler(b, o); !isRoughlyEqualMat3(n, m, 1e-5) {
+				t.Errorf("No round-trip for order %v, %v: %#v instead of %#v", o, angles, n, m)
+			}
+		}
+		// Gimbal lock
+		for _, mid := range []float32{math.Pi / 2, -math.Pi / 2} {
+			for n := 0; n < 20; n++ {
+				var a [3]float32
+				a[i] = (r.Float32()*2 - 1) * math.Pi
+				a[j] = mid
+				a[k] = (r.Float32()*2 - 1) * math.Pi
+				angles := Vec3{a[0], a[1], a[2]}
+				m := Mat3FromEuler(angles, o)
+				b, ok := m.EulerAngles(o)
+				if ok {
+					t.Errorf("Gimbal lock not reported for order %v, %v", o, angles)
+				}
+				if c := [3]float32{b.X, b.Y, b.Z}; c[k] != 0 || !math.IsRoughlyEqual(c[j], mid, 1e-3) {
+					t.Errorf("Wrong convention for gimbal lock, order %v: %v", o, b)
+				}
+				if n := Mat3FromEuler(b, o); !isRoughlyEqualMat3(n, m, 1e-5) {
+					t.Errorf("No round-trip for order %v, %v: %#v instead of %#v", o, angles, n, m)
+				}
+			}
+		}
+	}
+	if a, ok := Identity().EulerAngles(EulerYXZ); !ok || a != (Vec3{}) {
+		t.Errorf("Wrong angles for identity: %v", a)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestQuat_Euler(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vs := []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0.3, -2, 1.5}}
+	check := func(q Quat, o EulerOrder, epsilon float32) Vec3 {
+		a := q.Euler(o)
+		if math.IsNaN(a.X) || math.IsNaN(a.Y) || math.IsNaN(a.Z) {
+			t.Errorf("NaN for order %v, %#v: %v", o, q, a)
+			return a
+		}
+		p := QuatFromEuler(a, o)
+		for _, v := range vs {
+			if e, w := q.Rotate(v), p.Rotate(v); !isRoughlyEqualVec3(w, e, epsilon) {
+				t.Errorf("No round-trip for order %v, %#v: %v rotated to %#v instead of %#v", o, q, v, w, e)
+			}
+		}
+		return a
+	}
+	for _, o := range eulerOrders {
+		i, j, k, _ := o.axes()
+		for n := 0; n < 100; n++ {
+			check(randomQuat(r), o, 1e-5)
+		}
+		// Gimbal lock
+		for _, mid := range []float32{math.Pi / 2, -math.Pi / 2} {
+			var a [3]float32
+			a[i], a[j], a[k] = 0.7, mid, -1.1
+			q := QuatFromEuler(Vec3{a[0], a[1], a[2]}, o)
+			b := check(q, o, 1e-5)
+			if c := [3]float32{b.X, b.Y, b.Z}; c[k] != 0 || !math.IsRoughlyEqual(c[j], mid, 1e-3) {
+				t.Errorf("Wrong convention for gimbal lock, order %v: %v", o, b)
+			}
+			// Slightly denormalized, pushing the sine past 1
+			q = Quat{q.X * 1.000001, q.Y * 1.000001, q.Z * 1.000001, q.W * 1.000001}
+			check(q, o, 1e-4)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,50 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"strconv"
+	"strings"
+)
+
+//------------------------------------------------------------------------------
+
+// `formatMatrix` returns the elements of `rows` printed with `digits` digits
+// after the decimal point, one row per line, with the columns right-aligned.
+func formatMatrix(rows [][]float32, digits int) string {
+	if digits < 0 {
+		digits = 0
+	}
+	cells := make([][]string, len(rows))
+	widths := make([]int, len(rows[0]))
+	for r, row := range rows {
+		cells[r] = make([]string, len(row))
+		for c, x := range row {
+			// Adding zero turns negative zero into zero
+			s := strconv.FormatFloat(float64(x+0), 'f', digits, 32)
+			cells[r][c] = s
+			if len(s) > widths[c] {
+				widths[c] = len(s)
+			}
+		}
+	}
+	var b strings.Builder
+	for r, row := range cells {
+		if r > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteByte('[')
+		for c, s := range row {
+			if c > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(strings.Repeat(" ", widths[c]-len(s)))
+			b.WriteString(s)
+		}
+		b.WriteByte(']')
+	}
+	return b.String()
+}
+
+//------------------------------------------------------------------------------
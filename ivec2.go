@@ -12,3 +12,134 @@ type IVec2 struct {
 }
 
 //------------------------------------------------------------------------------
+
+// `Neighbors4` returns the four edge neighbors of `a`, in the order -X, +X,
+// -Y, +Y.
+//
+// See also `Neighbors8`.
+func (a IVec2) Neighbors4() [4]IVec2 {
+	return [4]IVec2{
+		{a.X - 1, a.Y},
+		{a.X + 1, a.Y},
+		{a.X, a.Y - 1},
+		{a.X, a.Y + 1},
+	}
+}
+
+// `Neighbors8` returns the eight neighbors of `a` sharing an edge or a corner
+// with it, ordered with X varying fastest, then Y.
+//
+// See also `Neighbors4`.
+func (a IVec2) Neighbors8() [8]IVec2 {
+	return [8]IVec2{
+		{a.X - 1, a.Y - 1},
+		{a.X, a.Y - 1},
+		{a.X + 1, a.Y - 1},
+		{a.X - 1, a.Y},
+		{a.X + 1, a.Y},
+		{a.X - 1, a.Y + 1},
+		{a.X, a.Y + 1},
+		{a.X + 1, a.Y + 1},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `ManhattanDistance` returns the sum of the absolute differences between the
+// coordinates of `a` and `b`. See `IVec3.ManhattanDistance`.
+func (a IVec2) ManhattanDistance(b IVec2) int32 {
+	return absInt32(a.X-b.X) + absInt32(a.Y-b.Y)
+}
+
+// `ChebyshevDistance` returns the largest absolute difference between the
+// coordinates of `a` and `b`. See `IVec3.ChebyshevDistance`.
+func (a IVec2) ChebyshevDistance(b IVec2) int32 {
+	d := absInt32(a.X - b.X)
+	if e := absInt32(a.Y - b.Y); e > d {
+		d = e
+	}
+	return d
+}
+
+//------------------------------------------------------------------------------
+
+// `RasterizeLine` returns the cells of the line from `a` to `b` (both
+// included), computed with Bresenham's algorithm. There is exactly one cell
+// per step along the major axis, and consecutive cells are neighbors (see
+// `Neighbors8`).
+//
+// See also `RasterizeLineSupercover`.
+func RasterizeLine(a, b IVec2) []IVec2 {
+	dx, dy := int64(b.X)-int64(a.X), int64(b.Y)-int64(a.Y)
+	sx, sy := int32(1), int32(1)
+	if dx < 0 {
+		sx, dx = -1, -dx
+	}
+	if dy < 0 {
+		sy, dy = -1, -dy
+	}
+	n := dx
+	if dy > n {
+		n = dy
+	}
+	cells := make([]IVec2, 0, n+1)
+	p := a
+	err := dx - dy
+	for {
+		cells = append(cells, p)
+		if p == b {
+			return cells
+		}
+		e := 2 * err
+		if e >= -dy {
+			err -= dy
+			p.X += sx
+		}
+		if e <= dx {
+			err += dx
+			p.Y += sy
+		}
+	}
+}
+
+// `RasterizeLineSupercover` returns all the cells touched by the segment
+// joining the centers of `a` and `b` (both included). Consecutive cells share
+// an edge (see `Neighbors4`), except when the segment goes exactly through a
+// corner: the two cells on each side of the corner are then both included
+// before the diagonal one.
+//
+// See also `RasterizeLine`.
+func RasterizeLineSupercover(a, b IVec2) []IVec2 {
+	dx, dy := int64(b.X)-int64(a.X), int64(b.Y)-int64(a.Y)
+	sx, sy := int32(1), int32(1)
+	if dx < 0 {
+		sx, dx = -1, -dx
+	}
+	if dy < 0 {
+		sy, dy = -1, -dy
+	}
+	cells := make([]IVec2, 0, dx+dy+1)
+	p := a
+	cells = append(cells, p)
+	for ix, iy := int64(0), int64(0); ix < dx || iy < dy; {
+		// Compare the distances to the next vertical and horizontal grid lines
+		switch d := (1+2*ix)*dy - (1+2*iy)*dx; {
+		case d == 0:
+			cells = append(cells, IVec2{p.X + sx, p.Y}, IVec2{p.X, p.Y + sy})
+			p.X += sx
+			p.Y += sy
+			ix++
+			iy++
+		case d < 0:
+			p.X += sx
+			ix++
+		default:
+			p.Y += sy
+			iy++
+		}
+		cells = append(cells, p)
+	}
+	return cells
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,132 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "testing"
+
+//------------------------------------------------------------------------------
+
+func TestIVec2_Neighbors(t *testing.T) {
+	a := IVec2{-3, 4}
+	check := func(n []IVec2, max int32) {
+		seen := map[IVec2]bool{}
+		for _, b := range n {
+			dx, dy := absInt32(b.X-a.X), absInt32(b.Y-a.Y)
+			if b == a || dx > 1 || dy > 1 || dx+dy > max {
+				t.Errorf("Wrong neighbor of %v: %v", a, b)
+			}
+			if seen[b] {
+				t.Errorf("Duplicate neighbor of %v: %v", a, b)
+			}
+			seen[b] = true
+		}
+	}
+	n4 := a.Neighbors4()
+	check(n4[:], 1)
+	n8 := a.Neighbors8()
+	check(n8[:], 2)
+}
+
+//------------------------------------------------------------------------------
+
+func TestIVec2_ManhattanDistance(t *testing.T) {
+	for _, c := range []struct {
+		a, b       IVec2
+		man, cheby int32
+	}{
+		{IVec2{0, 0}, IVec2{0, 0}, 0, 0},
+		{IVec2{1, 2}, IVec2{4, 6}, 7, 4},
+		{IVec2{-2, 5}, IVec2{3, -1}, 11, 6},
+	} {
+		if d := c.a.ManhattanDistance(c.b); d != c.man || c.b.ManhattanDistance(c.a) != d {
+			t.Errorf("Wrong Manhattan distance between %v and %v: %d", c.a, c.b, d)
+		}
+		if d := c.a.ChebyshevDistance(c.b); d != c.cheby || c.b.ChebyshevDistance(c.a) != d {
+			t.Errorf("Wrong Chebyshev distance between %v and %v: %d", c.a, c.b, d)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestRasterizeLine(t *testing.T) {
+	for _, c := range []struct {
+		a, b IVec2
+	}{
+		{IVec2{0, 0}, IVec2{0, 0}},
+		{IVec2{0, 0}, IVec2{5, 0}},
+		{IVec2{2, 3}, IVec2{2, -4}},
+		{IVec2{0, 0}, IVec2{4, 4}},
+		{IVec2{1, -1}, IVec2{-3, 3}},
+		{IVec2{0, 0}, IVec2{7, 3}},
+		{IVec2{-2, 5}, IVec2{1, -6}},
+		{IVec2{3, 1}, IVec2{-8, -2}},
+	} {
+		l := RasterizeLine(c.a, c.b)
+		if len(l) == 0 || l[0] != c.a || l[len(l)-1] != c.b {
+			t.Errorf("Wrong ends for %v to %v: %v", c.a, c.b, l)
+			continue
+		}
+		if n := int(c.a.ChebyshevDistance(c.b)) + 1; len(l) != n {
+			t.Errorf("Wrong number of cells for %v to %v: %d instead of %d", c.a, c.b, len(l), n)
+		}
+		for i := 1; i < len(l); i++ {
+			if l[i].ChebyshevDistance(l[i-1]) != 1 {
+				t.Errorf("Gap in line from %v to %v: %v", c.a, c.b, l)
+				break
+			}
+		}
+
+		s := RasterizeLineSupercover(c.a, c.b)
+		if len(s) == 0 || s[0] != c.a || s[len(s)-1] != c.b {
+			t.Errorf("Wrong ends for supercover %v to %v: %v", c.a, c.b, s)
+			continue
+		}
+		if n := int(c.a.ManhattanDistance(c.b)) + 1; len(s) < n {
+			t.Errorf("Too few cells for supercover %v to %v: %d", c.a, c.b, len(s))
+		}
+		seen := map[IVec2]bool{}
+		for i := range s {
+			if seen[s[i]] {
+				t.Errorf("Duplicate cell in supercover %v to %v: %v", c.a, c.b, s)
+			}
+			seen[s[i]] = true
+		}
+		// The supercover contains the Bresenham line
+		for _, p := range l {
+			if !seen[p] {
+				t.Errorf("Cell %v of line missing from supercover %v to %v: %v", p, c.a, c.b, s)
+			}
+		}
+	}
+
+	// Ties are rounded towards the end
+	e := []IVec2{{0, 0}, {1, 1}, {2, 1}, {3, 2}, {4, 2}}
+	if l := RasterizeLine(IVec2{0, 0}, IVec2{4, 2}); !equalIVec2s(l, e) {
+		t.Errorf("Wrong line: %v", l)
+	}
+	// Through the corners of the cells
+	e = []IVec2{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 1}, {1, 2}, {2, 2}}
+	if s := RasterizeLineSupercover(IVec2{0, 0}, IVec2{2, 2}); !equalIVec2s(s, e) {
+		t.Errorf("Wrong diagonal supercover: %v", s)
+	}
+	e = []IVec2{{0, 0}, {1, 0}, {1, 1}, {2, 1}, {3, 1}, {3, 2}, {4, 2}}
+	if s := RasterizeLineSupercover(IVec2{0, 0}, IVec2{4, 2}); !equalIVec2s(s, e) {
+		t.Errorf("Wrong supercover: %v", s)
+	}
+}
+
+func equalIVec2s(a, b []IVec2) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//------------------------------------------------------------------------------
@@ -13,3 +13,128 @@ type IVec3 struct {
 }
 
 //------------------------------------------------------------------------------
+
+// `ToIndex` returns the position of `a` in a dense 3D array of size `dims`,
+// i.e. `x + y*dims.X + z*dims.X*dims.Y`. The array is stored with X varying
+// fastest, then Y, then Z (this is row-major order for an array indexed as
+// [z][y][x]).
+//
+// Each coordinate of `a` must be in [0, dims); this is not checked.
+//
+// See also `IVec3FromIndex`.
+func (a IVec3) ToIndex(dims IVec3) int {
+	return int(a.X) + int(dims.X)*(int(a.Y)+int(dims.Y)*int(a.Z))
+}
+
+// `IVec3FromIndex` returns the coordinates of the element at position `i` in a
+// dense 3D array of size `dims`, with the same layout as `ToIndex`.
+//
+// `i` must be in [0, dims.X*dims.Y*dims.Z); this is not checked.
+func IVec3FromIndex(i int, dims IVec3) IVec3 {
+	sx, sy := int(dims.X), int(dims.Y)
+	return IVec3{
+		X: int32(i % sx),
+		Y: int32(i / sx % sy),
+		Z: int32(i / (sx * sy)),
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Neighbors6` returns the six face neighbors of `a`, in the order -X, +X, -Y,
+// +Y, -Z, +Z.
+//
+// See also `Neighbors26`.
+func (a IVec3) Neighbors6() [6]IVec3 {
+	return [6]IVec3{
+		{a.X - 1, a.Y, a.Z},
+		{a.X + 1, a.Y, a.Z},
+		{a.X, a.Y - 1, a.Z},
+		{a.X, a.Y + 1, a.Z},
+		{a.X, a.Y, a.Z - 1},
+		{a.X, a.Y, a.Z + 1},
+	}
+}
+
+// `Neighbors26` returns the 26 neighbors of `a` sharing a face, an edge or a
+// corner with it, ordered with X varying fastest, then Y, then Z (as with
+// `ToIndex`).
+//
+// See also `Neighbors6`.
+func (a IVec3) Neighbors26() [26]IVec3 {
+	var n [26]IVec3
+	i := 0
+	for z := int32(-1); z <= 1; z++ {
+		for y := int32(-1); y <= 1; y++ {
+			for x := int32(-1); x <= 1; x++ {
+				if x == 0 && y == 0 && z == 0 {
+					continue
+				}
+				n[i] = IVec3{a.X + x, a.Y + y, a.Z + z}
+				i++
+			}
+		}
+	}
+	return n
+}
+
+//------------------------------------------------------------------------------
+
+// `ManhattanDistance` returns the sum of the absolute differences between the
+// coordinates of `a` and `b`. This is the length of the shortest path on an
+// empty 6-connected grid, and the usual A* heuristic for such grids.
+//
+// See also `ChebyshevDistance`.
+func (a IVec3) ManhattanDistance(b IVec3) int32 {
+	return absInt32(a.X-b.X) + absInt32(a.Y-b.Y) + absInt32(a.Z-b.Z)
+}
+
+// `ChebyshevDistance` returns the largest absolute difference between the
+// coordinates of `a` and `b`. This is the length of the shortest path on an
+// empty 26-connected grid, and the usual A* heuristic for such grids.
+//
+// See also `ManhattanDistance`.
+func (a IVec3) ChebyshevDistance(b IVec3) int32 {
+	d := absInt32(a.X - b.X)
+	if e := absInt32(a.Y - b.Y); e > d {
+		d = e
+	}
+	if e := absInt32(a.Z - b.Z); e > d {
+		d = e
+	}
+	return d
+}
+
+func absInt32(x int32) int32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+//------------------------------------------------------------------------------
+
+// `Hash` returns a pseudo-random value determined by `a` and `seed`, suitable
+// for seeding per-cell procedural generation. Neighboring cells give
+// uncorrelated results, and all bits of the result are equally well mixed.
+//
+// The result only depends on its arguments, and is the same on every
+// platform.
+func (a IVec3) Hash(seed uint64) uint64 {
+	xy := uint64(uint32(a.X)) | uint64(uint32(a.Y))<<32
+	// The offset avoids the fixed point of fmix64 at zero
+	h := fmix64((seed ^ xy*0x9e3779b97f4a7c15) + 0x632be59bd9b4e019)
+	return fmix64(h ^ uint64(uint32(a.Z))*0xc2b2ae3d27d4eb4f)
+}
+
+// `fmix64` is the 64-bit finalizer of MurmurHash3.
+func fmix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,153 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "testing"
+
+//------------------------------------------------------------------------------
+
+func TestIVec3_ToIndex(t *testing.T) {
+	dims := IVec3{4, 3, 5}
+	i := 0
+	for z := int32(0); z < dims.Z; z++ {
+		for y := int32(0); y < dims.Y; y++ {
+			for x := int32(0); x < dims.X; x++ {
+				a := IVec3{x, y, z}
+				if j := a.ToIndex(dims); j != i {
+					t.Errorf("Wrong index for %v: %d instead of %d", a, j, i)
+				}
+				if b := IVec3FromIndex(i, dims); b != a {
+					t.Errorf("Wrong coordinates for %d: %v instead of %v", i, b, a)
+				}
+				i++
+			}
+		}
+	}
+	if j := (IVec3{1, 2, 3}).ToIndex(dims); j != 1+2*4+3*4*3 {
+		t.Errorf("Wrong result: %d", j)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestIVec3_Neighbors(t *testing.T) {
+	a := IVec3{5, -2, 7}
+	check := func(n []IVec3, max int32) {
+		seen := map[IVec3]bool{}
+		for _, b := range n {
+			d := IVec3{b.X - a.X, b.Y - a.Y, b.Z - a.Z}
+			m := absInt32(d.X) + absInt32(d.Y) + absInt32(d.Z)
+			if b == a || absInt32(d.X) > 1 || absInt32(d.Y) > 1 || absInt32(d.Z) > 1 || m > max {
+				t.Errorf("Wrong neighbor of %v: %v", a, b)
+			}
+			if seen[b] {
+				t.Errorf("Duplicate neighbor of %v: %v", a, b)
+			}
+			seen[b] = true
+		}
+	}
+	n6 := a.Neighbors6()
+	check(n6[:], 1)
+	n26 := a.Neighbors26()
+	check(n26[:], 3)
+	if n26[0] != (IVec3{4, -3, 6}) || n26[25] != (IVec3{6, -1, 8}) || n26[12] != (IVec3{4, -2, 7}) {
+		t.Errorf("Wrong order: %v", n26)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestIVec3_ManhattanDistance(t *testing.T) {
+	for _, c := range []struct {
+		a, b       IVec3
+		man, cheby int32
+	}{
+		{IVec3{0, 0, 0}, IVec3{0, 0, 0}, 0, 0},
+		{IVec3{1, 2, 3}, IVec3{4, 6, 3}, 7, 4},
+		{IVec3{-2, 5, 1}, IVec3{3, -1, -6}, 18, 7},
+		{IVec3{0, 0, 0}, IVec3{1, -1, 1}, 3, 1},
+	} {
+		if d := c.a.ManhattanDistance(c.b); d != c.man || c.b.ManhattanDistance(c.a) != d {
+			t.Errorf("Wrong Manhattan distance between %v and %v: %d", c.a, c.b, d)
+		}
+		if d := c.a.ChebyshevDistance(c.b); d != c.cheby || c.b.ChebyshevDistance(c.a) != d {
+			t.Errorf("Wrong Chebyshev distance between %v and %v: %d", c.a, c.b, d)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestIVec3_Hash(t *testing.T) {
+	// Reproducibility: these values must never change
+	for _, c := range []struct {
+		a    IVec3
+		seed uint64
+		h    uint64
+	}{
+		{IVec3{0, 0, 0}, 0, 0xcab120fef3a2d79b},
+		{IVec3{1, 2, 3}, 42, 0x8d52873387ef2337},
+		{IVec3{-5, 7, -9}, 0xdeadbeef, 0xd19e864f25ad44ec},
+	} {
+		if h := c.a.Hash(c.seed); h != c.h {
+			t.Errorf("Wrong hash for %v, %d: %#x", c.a, c.seed, h)
+		}
+	}
+
+	// Distribution over a block of adjacent cells
+	const n = 32
+	var bits [64]int
+	var buckets [256]int
+	seen := map[uint64]bool{}
+	flips := 0
+	for z := int32(-n / 2); z < n/2; z++ {
+		for y := int32(-n / 2); y < n/2; y++ {
+			for x := int32(-n / 2); x < n/2; x++ {
+				h := IVec3{x, y, z}.Hash(7)
+				seen[h] = true
+				for b := range bits {
+					bits[b] += int(h >> uint(b) & 1)
+				}
+				buckets[h&0xFF]++
+				flips += popCount64(h ^ IVec3{x + 1, y, z}.Hash(7))
+				flips += popCount64(h ^ IVec3{x, y, z + 1}.Hash(7))
+			}
+		}
+	}
+	const total = n * n * n
+	if len(seen) != total {
+		t.Errorf("Collisions: %d distinct hashes for %d cells", len(seen), total)
+	}
+	for b, c := range bits {
+		if c < total/2-total/20 || c > total/2+total/20 {
+			t.Errorf("Biased bit %d: set %d times out of %d", b, c, total)
+		}
+	}
+	// Chi-squared test on the low 8 bits (255 degrees of freedom)
+	var chi2 float64
+	for _, c := range buckets {
+		d := float64(c) - total/256
+		chi2 += d * d / (total / 256)
+	}
+	if chi2 > 350 {
+		t.Errorf("Low bits badly distributed: chi-squared is %v", chi2)
+	}
+	// Neighbors should differ in about half of their bits
+	if f := float64(flips) / (2 * total * 64); f < 0.48 || f > 0.52 {
+		t.Errorf("Neighbor hashes correlated: %v of bits differ", f)
+	}
+	if (IVec3{1, 2, 3}).Hash(1) == (IVec3{1, 2, 3}).Hash(2) {
+		t.Errorf("Seed ignored")
+	}
+}
+
+func popCount64(x uint64) int {
+	n := 0
+	for ; x != 0; x &= x - 1 {
+		n++
+	}
+	return n
+}
+
+//------------------------------------------------------------------------------
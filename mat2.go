@@ -0,0 +1,280 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "github.com/drakmaniso/glam/math"
+
+//------------------------------------------------------------------------------
+
+// `Mat2` is a single-precision matrix with 2 columns and 2 rows.
+//
+// Note: matrices are stored in column-major order, so when writing literals
+// remember to use the transpose.
+type Mat2 [2][2]float32
+
+//------------------------------------------------------------------------------
+
+// `singularEpsilon` is the tolerance used to decide that a matrix is singular.
+//
+// The determinant is considered zero when it is smaller than `singularEpsilon`
+// times the product of the lengths of the columns (which is, by Hadamard's
+// inequality, the largest value it could have). This makes the test
+// independent of the scale of the matrix.
+const singularEpsilon = 1e-6
+
+//------------------------------------------------------------------------------
+
+// `NewMat2` allocates and returns a new matrix. The elements are stored in
+// alphabetical order (column-major order).
+//
+// See also `MakeMat2` and `SetTo`.
+func NewMat2(
+	a, c,
+	b, d float32,
+) *Mat2 {
+	return &Mat2{
+		{a, b},
+		{c, d},
+	}
+}
+
+// `MakeMat2` returns a matrix. The elements are stored in
+// alphabetical order (column-major order).
+//
+// See also `NewMat2` and `SetTo`.
+func MakeMat2(
+	a, c,
+	b, d float32,
+) Mat2 {
+	return Mat2{
+		{a, b},
+		{c, d},
+	}
+}
+
+// `SetTo` initializes `matrix`. The elements are stored in
+// alphabetical order (column-major order).
+//
+// See also `NewMat2` and `MakeMat2`.
+func (matrix *Mat2) SetTo(
+	a, c,
+	b, d float32,
+) {
+	matrix[0][0] = a
+	matrix[0][1] = b
+
+	matrix[1][0] = c
+	matrix[1][1] = d
+}
+
+//------------------------------------------------------------------------------
+
+// `Mat2Identity` returns a 2x2 identity matrix.
+func Mat2Identity() Mat2 {
+	return Mat2{
+		{1, 0},
+		{0, 1},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `At` returns the element at '(row, column)`.
+func (m Mat2) At(row, column int) float32 {
+	return m[column][row]
+}
+
+// `Set` sets the element at `(row, column)` to `value`.
+func (m *Mat2) Set(row, column int, value float32) {
+	m[column][row] = value
+}
+
+//------------------------------------------------------------------------------
+
+// `Rotation2D` returns a matrix rotating counter-clockwise by `angle`.
+func Rotation2D(angle float32) Mat2 {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+
+	return Mat2{
+		{c, s},
+		{-s, c},
+	}
+}
+
+// `Scaling2D` returns a matrix scaling by `s.X` along the X axis, and by `s.Y`
+// along the Y axis.
+func Scaling2D(s Vec2) Mat2 {
+	return Mat2{
+		{s.X, 0},
+		{0, s.Y},
+	}
+}
+
+// `Shear2D` returns a shearing matrix, where `x` is added to the X coordinate
+// for each unit of Y, and `y` to the Y coordinate for each unit of X (e.g.
+// `Shear2D(0.2, 0)` slants text to the right).
+//
+// If only one amount is non-zero, the determinant is 1; otherwise it is
+// `1 - x*y`.
+func Shear2D(x, y float32) Mat2 {
+	return Mat2{
+		{1, y},
+		{x, 1},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Transposed` returns the transpose of `m`.
+//
+// See also `Transpose`.
+func (m Mat2) Transposed() Mat2 {
+	return Mat2{
+		{m[0][0], m[1][0]},
+		{m[0][1], m[1][1]},
+	}
+}
+
+// `Transpose` sets `m` to its transpose.
+//
+// More efficient than `Transposed`.
+func (m *Mat2) Transpose() {
+	m[0][1], m[1][0] = m[1][0], m[0][1]
+}
+
+//------------------------------------------------------------------------------
+
+// `Determinant` returns the determinant of `m`.
+func (m Mat2) Determinant() float32 {
+	return m[0][0]*m[1][1] - m[1][0]*m[0][1]
+}
+
+// `Trace` returns the sum of the elements on the main diagonal of `m`.
+func (m Mat2) Trace() float32 {
+	return m[0][0] + m[1][1]
+}
+
+// `Inverse` returns the inverse of `m`, and true; or, if `m` is singular (or
+// too close to be inverted in single precision), the zero matrix and false.
+func (m Mat2) Inverse() (Mat2, bool) {
+	det := m[0][0]*m[1][1] - m[1][0]*m[0][1]
+	c0 := m[0][0]*m[0][0] + m[0][1]*m[0][1]
+	c1 := m[1][0]*m[1][0] + m[1][1]*m[1][1]
+	if math.Abs(det) <= singularEpsilon*math.Sqrt(c0*c1) {
+		return Mat2{}, false
+	}
+
+	inv := 1 / det
+	return Mat2{
+		{m[1][1] * inv, -m[0][1] * inv},
+		{-m[1][0] * inv, m[0][0] * inv},
+	}, true
+}
+
+// `Solve` returns the vector `x` such that `m·x = b`, and true; or, if `m` is
+// singular (with the same criterion as `Inverse`), the zero vector and false.
+//
+// The system is solved with Cramer's rule, which is cheaper than computing
+// the inverse first.
+func (m Mat2) Solve(b Vec2) (Vec2, bool) {
+	det := m[0][0]*m[1][1] - m[1][0]*m[0][1]
+	c0 := m[0][0]*m[0][0] + m[0][1]*m[0][1]
+	c1 := m[1][0]*m[1][0] + m[1][1]*m[1][1]
+	if math.Abs(det) <= singularEpsilon*math.Sqrt(c0*c1) {
+		return Vec2{}, false
+	}
+
+	return Vec2{
+		(b.X*m[1][1] - m[1][0]*b.Y) / det,
+		(m[0][0]*b.Y - b.X*m[0][1]) / det,
+	}, true
+}
+
+//------------------------------------------------------------------------------
+
+// `Times` returns the matrix product of `m` and `o`.
+//
+// The result transforms a vector by `o` first, then by `m`.
+//
+// See also `Multiply` and `TimesVec2`.
+func (m *Mat2) Times(o *Mat2) Mat2 {
+	return Mat2{
+		{
+			m[0][0]*o[0][0] + m[1][0]*o[0][1],
+			m[0][1]*o[0][0] + m[1][1]*o[0][1],
+		},
+		{
+			m[0][0]*o[1][0] + m[1][0]*o[1][1],
+			m[0][1]*o[1][0] + m[1][1]*o[1][1],
+		},
+	}
+}
+
+// `Multiply` sets `r` to the matrix product of `m` and `o`.
+//
+// `r` must not be `m` or `o`.
+//
+// See also `Times` and `TimesVec2`.
+func (r *Mat2) Multiply(m, o *Mat2) {
+	r[0][0] = m[0][0]*o[0][0] + m[1][0]*o[0][1]
+	r[0][1] = m[0][1]*o[0][0] + m[1][1]*o[0][1]
+
+	r[1][0] = m[0][0]*o[1][0] + m[1][0]*o[1][1]
+	r[1][1] = m[0][1]*o[1][0] + m[1][1]*o[1][1]
+}
+
+// `TimesVec2` returns the product of `m` with the column vector `v`.
+func (m *Mat2) TimesVec2(v Vec2) Vec2 {
+	return Vec2{
+		m[0][0]*v.X + m[1][0]*v.Y,
+		m[0][1]*v.X + m[1][1]*v.Y,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Equal` returns true if `m` and `o` are exactly equal (this is the same as
+// `m == o`).
+func (m Mat2) Equal(o Mat2) bool {
+	return m == o
+}
+
+// `RoughlyEqual` returns true if the absolute difference between each
+// element of `m` and `o` is less than `epsilon`.
+func (m Mat2) RoughlyEqual(o Mat2, epsilon float32) bool {
+	return roughlyEqual([]float32{m[0][0], m[0][1], m[1][0], m[1][1]}, []float32{o[0][0], o[0][1], o[1][0], o[1][1]}, epsilon)
+}
+
+// `NearlyEqual` returns true if the difference between each element of `m`
+// and `o` is less than `epsilon` times the largest absolute element of
+// either matrix.
+//
+// See `Mat4.NearlyEqual`.
+func (m Mat2) NearlyEqual(o Mat2, epsilon float32) bool {
+	return nearlyEqual([]float32{m[0][0], m[0][1], m[1][0], m[1][1]}, []float32{o[0][0], o[0][1], o[1][0], o[1][1]}, epsilon)
+}
+
+//------------------------------------------------------------------------------
+
+// `String` returns `m` printed with 3 digits after the decimal point, one row
+// per line. See `Mat4.String`.
+func (m Mat2) String() string {
+	return m.StringPrec(3)
+}
+
+// `StringPrec` returns `m` printed with `digits` digits after the decimal
+// point. See `Mat4.StringPrec`.
+func (m Mat2) StringPrec(digits int) string {
+	rows := make([][]float32, 2)
+	for r := range rows {
+		rows[r] = make([]float32, 2)
+		for c := range rows[r] {
+			rows[r][c] = m[c][r]
+		}
+	}
+	return formatMatrix(rows, digits)
+}
+
+//------------------------------------------------------------------------------
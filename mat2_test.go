@@ -0,0 +1,205 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func isRoughlyEqualMat2(a, b Mat2, epsilon float32) bool {
+	return math.IsRoughlyEqual(a[0][0], b[0][0], epsilon) &&
+		math.IsRoughlyEqual(a[0][1], b[0][1], epsilon) &&
+		math.IsRoughlyEqual(a[1][0], b[1][0], epsilon) &&
+		math.IsRoughlyEqual(a[1][1], b[1][1], epsilon)
+}
+
+func isRoughlyEqualVec2(a, b Vec2, epsilon float32) bool {
+	return math.IsRoughlyEqual(a.X, b.X, epsilon) &&
+		math.IsRoughlyEqual(a.Y, b.Y, epsilon)
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat2_literals(t *testing.T) {
+	a := MakeMat2(
+		1.1, 11.1,
+		2.2, 22.2,
+	)
+	if a[0][0] != 1.1 || a[1][0] != 11.1 || a[0][1] != 2.2 || a[1][1] != 22.2 {
+		t.Errorf("Not maked: %#v", a)
+	}
+	if *NewMat2(1.1, 11.1, 2.2, 22.2) != a {
+		t.Errorf("Not newed")
+	}
+	var b Mat2
+	b.SetTo(
+		1.1, 11.1,
+		2.2, 22.2,
+	)
+	if b != a {
+		t.Errorf("Not set: %#v", b)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestRotation2D(t *testing.T) {
+	for _, angle := range []float32{0, 0.3, 1, math.Pi / 2, 2.5, -1.7} {
+		m := Rotation2D(angle)
+		v := m.TimesVec2(Vec2{1, 0})
+		if !isRoughlyEqualVec2(v, Vec2{math.Cos(angle), math.Sin(angle)}, 1e-6) {
+			t.Errorf("Wrong result for %v: %#v", angle, v)
+		}
+		inv, ok := m.Inverse()
+		if !ok {
+			t.Errorf("Rotation by %v reported singular", angle)
+		}
+		if !isRoughlyEqualMat2(inv, m.Transposed(), 1e-6) {
+			t.Errorf("Inverse is not the transpose for %v: %#v", angle, inv)
+		}
+	}
+}
+
+func TestRotation2D_quarterTurn(t *testing.T) {
+	m := Rotation2D(math.Pi / 2)
+	for _, c := range []struct{ v, e Vec2 }{
+		{Vec2{1, 0}, Vec2{0, 1}},
+		{Vec2{0, 1}, Vec2{-1, 0}},
+		{Vec2{-1, 0}, Vec2{0, -1}},
+		{Vec2{0.6, 0.8}, Vec2{-0.8, 0.6}},
+	} {
+		r := m.TimesVec2(c.v)
+		if !isRoughlyEqualVec2(r, c.e, 1e-6) {
+			t.Errorf("Wrong result for %#v: %#v", c.v, r)
+		}
+	}
+	if d := m.Determinant(); !math.IsRoughlyEqual(d, 1, 1e-6) {
+		t.Errorf("Wrong determinant: %v", d)
+	}
+}
+
+func TestScaling2D(t *testing.T) {
+	m := Scaling2D(Vec2{2, 3})
+	v := m.TimesVec2(Vec2{1.5, -1})
+	if v != (Vec2{3, -3}) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+	if d := m.Determinant(); d != 6 {
+		t.Errorf("Wrong determinant: %v", d)
+	}
+}
+
+func TestShear2D(t *testing.T) {
+	m := Shear2D(0.25, 0)
+	for _, c := range []struct{ v, e Vec2 }{
+		{Vec2{0, 0}, Vec2{0, 0}},
+		{Vec2{1, 0}, Vec2{1, 0}},
+		{Vec2{0, 1}, Vec2{0.25, 1}},
+		{Vec2{1, 1}, Vec2{1.25, 1}},
+	} {
+		if r := m.TimesVec2(c.v); r != c.e {
+			t.Errorf("Wrong result for %#v: %#v", c.v, r)
+		}
+	}
+	if d := m.Determinant(); d != 1 {
+		t.Errorf("Wrong determinant: %v", d)
+	}
+	m = Shear2D(0, 0.5)
+	if r := m.TimesVec2(Vec2{2, 1}); r != (Vec2{2, 2}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if d := Shear2D(0.5, 0.5).Determinant(); d != 0.75 {
+		t.Errorf("Wrong determinant: %v", d)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat2_Inverse(t *testing.T) {
+	m := MakeMat2(
+		4, 7,
+		2, 6,
+	)
+	inv, ok := m.Inverse()
+	if !ok {
+		t.Fatalf("Reported singular")
+	}
+	p := m.Times(&inv)
+	if !isRoughlyEqualMat2(p, Mat2Identity(), 1e-6) {
+		t.Errorf("Wrong result: %#v", p)
+	}
+	_, ok = MakeMat2(
+		1, 2,
+		2, 4,
+	).Inverse()
+	if ok {
+		t.Errorf("Singular matrix not detected")
+	}
+}
+
+func TestMat2_Solve(t *testing.T) {
+	r := Rotation2D(0.7)
+	for _, m := range []Mat2{
+		MakeMat2(4, 7, 2, 6),
+		MakeMat2(0, 1, 1, 0),
+		r.Times(&Mat2{{3, 0}, {0, 0.01}}),
+	} {
+		for _, b := range []Vec2{{1, 0}, {0, 1}, {-3.5, 12}} {
+			x, ok := m.Solve(b)
+			if !ok {
+				t.Errorf("Reported singular: %#v", m)
+				continue
+			}
+			if r := m.TimesVec2(x); !isRoughlyEqualVec2(r, b, 1e-5) {
+				t.Errorf("Wrong result for %#v: %#v gives %#v", b, x, r)
+			}
+		}
+	}
+	x, ok := MakeMat2(4, 7, 2, 6).Solve(Vec2{1, 2})
+	if !ok || !isRoughlyEqualVec2(x, Vec2{-0.8, 0.6}, 1e-6) {
+		t.Errorf("Wrong result: %#v", x)
+	}
+	x, ok = MakeMat2(
+		1, 1,
+		1, 1+1e-7,
+	).Solve(Vec2{1, 2})
+	if ok || x != (Vec2{}) {
+		t.Errorf("Nearly singular matrix not detected: %#v", x)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat2_Times(t *testing.T) {
+	r := Rotation2D(math.Pi / 2)
+	s := Scaling2D(Vec2{2, 1})
+	m := r.Times(&s)
+	v := m.TimesVec2(Vec2{1, 0})
+	if !isRoughlyEqualVec2(v, Vec2{0, 2}, 1e-6) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+	var n Mat2
+	n.Multiply(&r, &s)
+	if n != m {
+		t.Errorf("Multiply differs from Times: %#v", n)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat2_String(t *testing.T) {
+	m := Mat2{{1, -2}, {30, 0.5}}
+	e := "" +
+		"[ 1.000 30.000]\n" +
+		"[-2.000  0.500]"
+	if s := m.String(); s != e {
+		t.Errorf("Wrong result:\n%s\ninstead of:\n%s", s, e)
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,646 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"fmt"
+	stdmath "math"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+// `Mat3` is a single-precision matrix with 3 columns and 3 rows.
+//
+// Note: matrices are stored in column-major order, so when writing literals
+// remember to use the transpose.
+type Mat3 [3][3]float32
+
+//------------------------------------------------------------------------------
+
+// `NewMat3` allocates and returns a new matrix. The elements are stored in
+// alphabetical order (column-major order).
+//
+// See also `MakeMat3` and `SetTo`.
+func NewMat3(
+	a, d, g,
+	b, e, h,
+	c, f, i float32,
+) *Mat3 {
+	return &Mat3{
+		{a, b, c},
+		{d, e, f},
+		{g, h, i},
+	}
+}
+
+// `MakeMat3` returns a matrix. The elements are stored in
+// alphabetical order (column-major order).
+//
+// See also `NewMat3` and `SetTo`.
+func MakeMat3(
+	a, d, g,
+	b, e, h,
+	c, f, i float32,
+) Mat3 {
+	return Mat3{
+		{a, b, c},
+		{d, e, f},
+		{g, h, i},
+	}
+}
+
+// `SetTo` initializes `matrix`. The elements are stored in
+// alphabetical order (column-major order).
+//
+// See also `NewMat3` and `MakeMat3`.
+func (matrix *Mat3) SetTo(
+	a, d, g,
+	b, e, h,
+	c, f, i float32,
+) {
+	matrix[0][0] = a
+	matrix[0][1] = b
+	matrix[0][2] = c
+
+	matrix[1][0] = d
+	matrix[1][1] = e
+	matrix[1][2] = f
+
+	matrix[2][0] = g
+	matrix[2][1] = h
+	matrix[2][2] = i
+}
+
+//------------------------------------------------------------------------------
+
+// `Mat3Identity` returns a 3x3 identity matrix.
+func Mat3Identity() Mat3 {
+	return Mat3{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Mat3FromColumns` returns the matrix whose columns are `c0`, `c1` and `c2`.
+// This is the storage order, so each vector is copied unchanged.
+//
+// See also `Mat3FromRows`.
+func Mat3FromColumns(c0, c1, c2 Vec3) Mat3 {
+	return Mat3{
+		{c0.X, c0.Y, c0.Z},
+		{c1.X, c1.Y, c1.Z},
+		{c2.X, c2.Y, c2.Z},
+	}
+}
+
+// `Mat3FromRows` returns the matrix whose rows are `r0`, `r1` and `r2`.
+//
+// See also `Mat3FromColumns`.
+func Mat3FromRows(r0, r1, r2 Vec3) Mat3 {
+	return Mat3{
+		{r0.X, r1.X, r2.X},
+		{r0.Y, r1.Y, r2.Y},
+		{r0.Z, r1.Z, r2.Z},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `ColumnMajor` returns the elements of `m` in column-major order (the
+// storage order): element `(row, column)` is at index `column*3 + row`.
+//
+// See also `RowMajor` and `Mat3FromColumnMajor`.
+func (m Mat3) ColumnMajor() [9]float32 {
+	return [9]float32{
+		m[0][0], m[0][1], m[0][2],
+		m[1][0], m[1][1], m[1][2],
+		m[2][0], m[2][1], m[2][2],
+	}
+}
+
+// `RowMajor` returns the elements of `m` in row-major order: element
+// `(row, column)` is at index `row*3 + column`.
+//
+// See also `ColumnMajor` and `Mat3FromRowMajor`.
+func (m Mat3) RowMajor() [9]float32 {
+	return [9]float32{
+		m[0][0], m[1][0], m[2][0],
+		m[0][1], m[1][1], m[2][1],
+		m[0][2], m[1][2], m[2][2],
+	}
+}
+
+// `Mat3FromColumnMajor` returns the matrix whose elements are given by `a` in
+// column-major order.
+//
+// See also `ColumnMajor` and `Mat3FromColumnMajorSlice`.
+func Mat3FromColumnMajor(a [9]float32) Mat3 {
+	return Mat3FromColumnMajorSlice(a[:])
+}
+
+// `Mat3FromRowMajor` returns the matrix whose elements are given by `a` in
+// row-major order.
+//
+// See also `RowMajor` and `Mat3FromRowMajorSlice`.
+func Mat3FromRowMajor(a [9]float32) Mat3 {
+	return Mat3FromRowMajorSlice(a[:])
+}
+
+// `Mat3FromColumnMajorSlice` returns the matrix whose elements are given by
+// the first 9 elements of `s`, in column-major order. It panics if `s` is
+// shorter.
+func Mat3FromColumnMajorSlice(s []float32) Mat3 {
+	if len(s) < 9 {
+		panic(fmt.Sprintf("glam.Mat3FromColumnMajorSlice: %d elements instead of 9", len(s)))
+	}
+	return Mat3{
+		{s[0], s[1], s[2]},
+		{s[3], s[4], s[5]},
+		{s[6], s[7], s[8]},
+	}
+}
+
+// `Mat3FromRowMajorSlice` returns the matrix whose elements are given by the
+// first 9 elements of `s`, in row-major order. It panics if `s` is shorter.
+func Mat3FromRowMajorSlice(s []float32) Mat3 {
+	if len(s) < 9 {
+		panic(fmt.Sprintf("glam.Mat3FromRowMajorSlice: %d elements instead of 9", len(s)))
+	}
+	return Mat3{
+		{s[0], s[3], s[6]},
+		{s[1], s[4], s[7]},
+		{s[2], s[5], s[8]},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `At` returns the element at '(row, column)`.
+func (m Mat3) At(row, column int) float32 {
+	return m[column][row]
+}
+
+// `Set` sets the element at `(row, column)` to `value`.
+func (m *Mat3) Set(row, column int, value float32) {
+	m[column][row] = value
+}
+
+//------------------------------------------------------------------------------
+
+// `Mat3` returns the upper-left 3x3 part of `m`.
+//
+// See also `Mat3.Mat4`.
+func (m Mat4) Mat3() Mat3 {
+	return Mat3{
+		{m[0][0], m[0][1], m[0][2]},
+		{m[1][0], m[1][1], m[1][2]},
+		{m[2][0], m[2][1], m[2][2]},
+	}
+}
+
+// `Mat4` returns a 4x4 matrix whose upper-left part is `m`, and whose
+// remaining elements are those of the identity.
+//
+// See also `Mat4.Mat3`.
+func (m Mat3) Mat4() Mat4 {
+	return Mat4{
+		{m[0][0], m[0][1], m[0][2], 0},
+		{m[1][0], m[1][1], m[1][2], 0},
+		{m[2][0], m[2][1], m[2][2], 0},
+		{0, 0, 0, 1},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Mat3RotationX` returns a matrix rotating by `angle` around the X axis.
+func Mat3RotationX(angle float32) Mat3 {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+
+	return Mat3{
+		{1, 0, 0},
+		{0, c, s},
+		{0, -s, c},
+	}
+}
+
+// `Mat3RotationY` returns a matrix rotating by `angle` around the Y axis.
+func Mat3RotationY(angle float32) Mat3 {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+
+	return Mat3{
+		{c, 0, -s},
+		{0, 1, 0},
+		{s, 0, c},
+	}
+}
+
+// `Mat3RotationZ` returns a matrix rotating by `angle` around the Z axis.
+func Mat3RotationZ(angle float32) Mat3 {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+
+	return Mat3{
+		{c, s, 0},
+		{-s, c, 0},
+		{0, 0, 1},
+	}
+}
+
+// `Mat3RotationAxis` returns a matrix rotating by `angle` around `axis`, which
+// must be normalized. A zero `angle` gives exactly the identity.
+//
+// See also `RotationAxis`.
+func Mat3RotationAxis(axis Vec3, angle float32) Mat3 {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+	d := 1 - c
+	x, y, z := axis.X, axis.Y, axis.Z
+
+	return Mat3{
+		{c + x*x*d, z*s + x*y*d, -y*s + x*z*d},
+		{-z*s + y*x*d, c + y*y*d, x*s + y*z*d},
+		{y*s + z*x*d, -x*s + z*y*d, c + z*z*d},
+	}
+}
+
+// `LookRotation` returns the rotation that orients an object so that it looks
+// towards `forward`, with its top towards `up`. As for cameras (see `LookAt`),
+// the front of the object is its -Z axis: the result maps -Z to the direction
+// of `forward`, and +Y to the projection of `up` onto the plane orthogonal to
+// it.
+//
+// Neither vector needs to be normalized. The boolean is false if the
+// orientation is not fully determined by the arguments: when they are parallel
+// (or nearly parallel), or when `up` is zero, the same fallback up vector as
+// `LookAt` is used; when `forward` is zero, the result is the identity.
+//
+// The result is the transpose of the rotation part of `LookAt` (i.e. the
+// orientation of the camera in world space). See also `QuatLookRotation`.
+func LookRotation(forward, up Vec3) (Mat3, bool) {
+	if forward.Dot(forward) == 0 {
+		return Mat3Identity(), false
+	}
+	f := forward.Normalized()
+	s, u, ok := lookBasis(f, up)
+	return Mat3{
+		{s.X, s.Y, s.Z},
+		{u.X, u.Y, u.Z},
+		{-f.X, -f.Y, -f.Z},
+	}, ok
+}
+
+// `Mat3Shear` returns a shearing matrix, with the same parameters as `Shear`.
+func Mat3Shear(xy, xz, yx, yz, zx, zy float32) Mat3 {
+	return Mat3{
+		{1, yx, zx},
+		{xy, 1, zy},
+		{xz, yz, 1},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Outer` returns the outer product of `a` and `b`, i.e. the matrix `a·bᵀ`
+// whose element at `(row, column)` is `a[row]*b[column]`.
+func (a Vec3) Outer(b Vec3) Mat3 {
+	return Mat3{
+		{a.X * b.X, a.Y * b.X, a.Z * b.X},
+		{a.X * b.Y, a.Y * b.Y, a.Z * b.Y},
+		{a.X * b.Z, a.Y * b.Z, a.Z * b.Z},
+	}
+}
+
+// `Covariance` returns the covariance matrix of `points`, i.e. the mean of
+// the outer products of the centered points with themselves. The matrix is
+// symmetric; its eigenvectors give the principal axes of the point set.
+//
+// This is the population covariance (divided by `len(points)`, not
+// `len(points)-1`). If `points` is empty, the result is the zero matrix.
+//
+// See also `Centroid`.
+func Covariance(points []Vec3) Mat3 {
+	var m Mat3
+	if len(points) == 0 {
+		return m
+	}
+	c := Centroid(points)
+	for _, p := range points {
+		d := p.Minus(c)
+		o := d.Outer(d)
+		for i := range m {
+			for j := range m[i] {
+				m[i][j] += o[i][j]
+			}
+		}
+	}
+	n := 1 / float32(len(points))
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] *= n
+		}
+	}
+	return m
+}
+
+// `SymmetricEigen` returns the eigenvalues of `m`, which must be symmetric,
+// and the corresponding eigenvectors as the columns of `vectors`.
+//
+// The eigenvalues are sorted in decreasing order, and `vectors` is a rotation
+// (i.e. its columns are orthonormal and form a right-handed basis). Applied to
+// the result of `Covariance`, this gives the axes of an oriented bounding box.
+//
+// The computation uses the cyclic Jacobi method, in double precision.
+func (m Mat3) SymmetricEigen() (values Vec3, vectors Mat3) {
+	// a is the working copy of m, v accumulates the rotations (both as [row][column])
+	var a, v [3][3]float64
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			a[r][c] = float64(m[c][r])
+		}
+		v[r][r] = 1
+	}
+
+	for sweep := 0; sweep < 32; sweep++ {
+		off := a[0][1]*a[0][1] + a[0][2]*a[0][2] + a[1][2]*a[1][2]
+		diag := a[0][0]*a[0][0] + a[1][1]*a[1][1] + a[2][2]*a[2][2]
+		if off <= 1e-30*diag || off == 0 {
+			break
+		}
+		for _, pq := range [3][2]int{{0, 1}, {0, 2}, {1, 2}} {
+			p, q := pq[0], pq[1]
+			if a[p][q] == 0 {
+				continue
+			}
+			// Rotation zeroing a[p][q] (see Numerical Recipes, section 11.1)
+			theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+			t := 1 / (stdmath.Abs(theta) + stdmath.Sqrt(theta*theta+1))
+			if theta < 0 {
+				t = -t
+			}
+			c := 1 / stdmath.Sqrt(t*t+1)
+			s := t * c
+			for k := 0; k < 3; k++ {
+				akp, akq := a[k][p], a[k][q]
+				a[k][p] = c*akp - s*akq
+				a[k][q] = s*akp + c*akq
+			}
+			for k := 0; k < 3; k++ {
+				apk, aqk := a[p][k], a[q][k]
+				a[p][k] = c*apk - s*aqk
+				a[q][k] = s*apk + c*aqk
+			}
+			for k := 0; k < 3; k++ {
+				vkp, vkq := v[k][p], v[k][q]
+				v[k][p] = c*vkp - s*vkq
+				v[k][q] = s*vkp + c*vkq
+			}
+		}
+	}
+
+	// Sort in decreasing order
+	order := [3]int{0, 1, 2}
+	for i := 1; i < 3; i++ {
+		for j := i; j > 0 && a[order[j]][order[j]] > a[order[j-1]][order[j-1]]; j-- {
+			order[j], order[j-1] = order[j-1], order[j]
+		}
+	}
+	for i, k := range order {
+		vectors[i] = [3]float32{float32(v[0][k]), float32(v[1][k]), float32(v[2][k])}
+	}
+	values = Vec3{
+		float32(a[order[0]][order[0]]),
+		float32(a[order[1]][order[1]]),
+		float32(a[order[2]][order[2]]),
+	}
+
+	if vectors.Determinant() < 0 {
+		vectors[2] = [3]float32{-vectors[2][0], -vectors[2][1], -vectors[2][2]}
+	}
+	return values, vectors
+}
+
+//------------------------------------------------------------------------------
+
+// `Transposed` returns the transpose of `m`.
+//
+// See also `Transpose`.
+func (m Mat3) Transposed() Mat3 {
+	return Mat3{
+		{m[0][0], m[1][0], m[2][0]},
+		{m[0][1], m[1][1], m[2][1]},
+		{m[0][2], m[1][2], m[2][2]},
+	}
+}
+
+// `Transpose` sets `m` to its transpose.
+//
+// More efficient than `Transposed`.
+func (m *Mat3) Transpose() {
+	m[0][1], m[1][0] = m[1][0], m[0][1]
+	m[0][2], m[2][0] = m[2][0], m[0][2]
+	m[1][2], m[2][1] = m[2][1], m[1][2]
+}
+
+//------------------------------------------------------------------------------
+
+// `Determinant` returns the determinant of `m`.
+//
+// The expansion is accumulated in double precision, so that the sign of the
+// result is reliable even for nearly singular matrices.
+func (m Mat3) Determinant() float32 {
+	a00, a01, a02 := float64(m[0][0]), float64(m[0][1]), float64(m[0][2])
+	a10, a11, a12 := float64(m[1][0]), float64(m[1][1]), float64(m[1][2])
+	a20, a21, a22 := float64(m[2][0]), float64(m[2][1]), float64(m[2][2])
+
+	return float32(a00*(a11*a22-a21*a12) -
+		a10*(a01*a22-a21*a02) +
+		a20*(a01*a12-a11*a02))
+}
+
+// `Inverse` returns the inverse of `m`, and true; or, if `m` is singular (or
+// too close to be inverted in single precision), the zero matrix and false.
+func (m Mat3) Inverse() (Mat3, bool) {
+	// Cofactors, stored in transposed position (i.e. the adjugate)
+	a := Mat3{
+		{
+			m[1][1]*m[2][2] - m[2][1]*m[1][2],
+			m[2][1]*m[0][2] - m[0][1]*m[2][2],
+			m[0][1]*m[1][2] - m[1][1]*m[0][2],
+		},
+		{
+			m[2][0]*m[1][2] - m[1][0]*m[2][2],
+			m[0][0]*m[2][2] - m[2][0]*m[0][2],
+			m[1][0]*m[0][2] - m[0][0]*m[1][2],
+		},
+		{
+			m[1][0]*m[2][1] - m[2][0]*m[1][1],
+			m[2][0]*m[0][1] - m[0][0]*m[2][1],
+			m[0][0]*m[1][1] - m[1][0]*m[0][1],
+		},
+	}
+
+	det := m[0][0]*a[0][0] + m[1][0]*a[0][1] + m[2][0]*a[0][2]
+	var p float32 = 1
+	for c := 0; c < 3; c++ {
+		p *= m[c][0]*m[c][0] + m[c][1]*m[c][1] + m[c][2]*m[c][2]
+	}
+	if math.Abs(det) <= singularEpsilon*math.Sqrt(p) {
+		return Mat3{}, false
+	}
+
+	inv := 1 / det
+	for c := range a {
+		for r := range a[c] {
+			a[c][r] *= inv
+		}
+	}
+	return a, true
+}
+
+// `Trace` returns the sum of the elements on the main diagonal of `m`.
+func (m Mat3) Trace() float32 {
+	return m[0][0] + m[1][1] + m[2][2]
+}
+
+// `IsOrthogonal` returns true if the columns of `m` are orthonormal, i.e. if
+// `mᵀ·m` is the identity, with an absolute tolerance of `epsilon` on each
+// element.
+//
+// See also `IsRigid`.
+func (m Mat3) IsOrthogonal(epsilon float32) bool {
+	t := m.Transposed()
+	return t.Times(&m).RoughlyEqual(Mat3Identity(), epsilon)
+}
+
+// `IsRigid` returns true if `m` is a rotation, i.e. if it is orthogonal and
+// its determinant is 1 (with a tolerance of `epsilon`). Reflections are
+// orthogonal but not rigid.
+func (m Mat3) IsRigid(epsilon float32) bool {
+	return m.IsOrthogonal(epsilon) && math.IsRoughlyEqual(m.Determinant(), 1, epsilon)
+}
+
+// `FrobeniusNorm` returns the square root of the sum of the squares of all
+// elements of `m`.
+//
+// See `Mat4.FrobeniusNorm`.
+func (m Mat3) FrobeniusNorm() float32 {
+	var s float32
+	for c := range m {
+		for r := range m[c] {
+			s += m[c][r] * m[c][r]
+		}
+	}
+	return math.Sqrt(s)
+}
+
+//------------------------------------------------------------------------------
+
+// `Times` returns the matrix product of `m` and `o`.
+//
+// The result transforms a vector by `o` first, then by `m`.
+//
+// See also `Multiply` and `TimesVec3`.
+func (m *Mat3) Times(o *Mat3) Mat3 {
+	return Mat3{
+		{
+			m[0][0]*o[0][0] + m[1][0]*o[0][1] + m[2][0]*o[0][2],
+			m[0][1]*o[0][0] + m[1][1]*o[0][1] + m[2][1]*o[0][2],
+			m[0][2]*o[0][0] + m[1][2]*o[0][1] + m[2][2]*o[0][2],
+		},
+		{
+			m[0][0]*o[1][0] + m[1][0]*o[1][1] + m[2][0]*o[1][2],
+			m[0][1]*o[1][0] + m[1][1]*o[1][1] + m[2][1]*o[1][2],
+			m[0][2]*o[1][0] + m[1][2]*o[1][1] + m[2][2]*o[1][2],
+		},
+		{
+			m[0][0]*o[2][0] + m[1][0]*o[2][1] + m[2][0]*o[2][2],
+			m[0][1]*o[2][0] + m[1][1]*o[2][1] + m[2][1]*o[2][2],
+			m[0][2]*o[2][0] + m[1][2]*o[2][1] + m[2][2]*o[2][2],
+		},
+	}
+}
+
+// `Multiply` sets `r` to the matrix product of `m` and `o`.
+//
+// `r` must not be `m` or `o`.
+//
+// See also `Times` and `TimesVec3`.
+func (r *Mat3) Multiply(m, o *Mat3) {
+	r[0][0] = m[0][0]*o[0][0] + m[1][0]*o[0][1] + m[2][0]*o[0][2]
+	r[0][1] = m[0][1]*o[0][0] + m[1][1]*o[0][1] + m[2][1]*o[0][2]
+	r[0][2] = m[0][2]*o[0][0] + m[1][2]*o[0][1] + m[2][2]*o[0][2]
+
+	r[1][0] = m[0][0]*o[1][0] + m[1][0]*o[1][1] + m[2][0]*o[1][2]
+	r[1][1] = m[0][1]*o[1][0] + m[1][1]*o[1][1] + m[2][1]*o[1][2]
+	r[1][2] = m[0][2]*o[1][0] + m[1][2]*o[1][1] + m[2][2]*o[1][2]
+
+	r[2][0] = m[0][0]*o[2][0] + m[1][0]*o[2][1] + m[2][0]*o[2][2]
+	r[2][1] = m[0][1]*o[2][0] + m[1][1]*o[2][1] + m[2][1]*o[2][2]
+	r[2][2] = m[0][2]*o[2][0] + m[1][2]*o[2][1] + m[2][2]*o[2][2]
+}
+
+// `TimesVec3` returns the product of `m` with the column vector `v`.
+func (m *Mat3) TimesVec3(v Vec3) Vec3 {
+	return Vec3{
+		m[0][0]*v.X + m[1][0]*v.Y + m[2][0]*v.Z,
+		m[0][1]*v.X + m[1][1]*v.Y + m[2][1]*v.Z,
+		m[0][2]*v.X + m[1][2]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Equal` returns true if `m` and `o` are exactly equal (this is the same as
+// `m == o`).
+func (m Mat3) Equal(o Mat3) bool {
+	return m == o
+}
+
+// `RoughlyEqual` returns true if the absolute difference between each
+// element of `m` and `o` is less than `epsilon`.
+func (m Mat3) RoughlyEqual(o Mat3, epsilon float32) bool {
+	a, b := m.ColumnMajor(), o.ColumnMajor()
+	return roughlyEqual(a[:], b[:], epsilon)
+}
+
+// `NearlyEqual` returns true if the difference between each element of `m`
+// and `o` is less than `epsilon` times the largest absolute element of
+// either matrix.
+//
+// See `Mat4.NearlyEqual`.
+func (m Mat3) NearlyEqual(o Mat3, epsilon float32) bool {
+	a, b := m.ColumnMajor(), o.ColumnMajor()
+	return nearlyEqual(a[:], b[:], epsilon)
+}
+
+//------------------------------------------------------------------------------
+
+// `String` returns `m` printed with 3 digits after the decimal point, one row
+// per line. See `Mat4.String`.
+func (m Mat3) String() string {
+	return m.StringPrec(3)
+}
+
+// `StringPrec` returns `m` printed with `digits` digits after the decimal
+// point. See `Mat4.StringPrec`.
+func (m Mat3) StringPrec(digits int) string {
+	rows := make([][]float32, 3)
+	for r := range rows {
+		rows[r] = make([]float32, 3)
+		for c := range rows[r] {
+			rows[r][c] = m[c][r]
+		}
+	}
+	return formatMatrix(rows, digits)
+}
+
+//------------------------------------------------------------------------------
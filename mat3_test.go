@@ -0,0 +1,619 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func isRoughlyEqualMat3(a, b Mat3, epsilon float32) bool {
+	for c := 0; c < 3; c++ {
+		for r := 0; r < 3; r++ {
+			if !math.IsRoughlyEqual(a[c][r], b[c][r], epsilon) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isRoughlyEqualVec3(a, b Vec3, epsilon float32) bool {
+	return math.IsRoughlyEqual(a.X, b.X, epsilon) &&
+		math.IsRoughlyEqual(a.Y, b.Y, epsilon) &&
+		math.IsRoughlyEqual(a.Z, b.Z, epsilon)
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat3_literals(t *testing.T) {
+	a := MakeMat3(
+		1.1, 11.1, 111.1,
+		2.2, 22.2, 222.2,
+		3.3, 33.3, 333.3,
+	)
+	if a[0][0] != 1.1 || a[1][0] != 11.1 || a[2][0] != 111.1 ||
+		a[0][1] != 2.2 || a[1][1] != 22.2 || a[2][1] != 222.2 ||
+		a[0][2] != 3.3 || a[1][2] != 33.3 || a[2][2] != 333.3 {
+		t.Errorf("Not maked: %#v", a)
+	}
+	b := NewMat3(
+		1.1, 11.1, 111.1,
+		2.2, 22.2, 222.2,
+		3.3, 33.3, 333.3,
+	)
+	if *b != a {
+		t.Errorf("Not newed: %#v", b)
+	}
+	var c Mat3
+	c.SetTo(
+		1.1, 11.1, 111.1,
+		2.2, 22.2, 222.2,
+		3.3, 33.3, 333.3,
+	)
+	if c != a {
+		t.Errorf("Not set: %#v", c)
+	}
+	if a.At(0, 1) != 11.1 || a.At(1, 0) != 2.2 {
+		t.Errorf("Wrong At: %#v", a)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat3FromColumns(t *testing.T) {
+	a, b, c := Vec3{1, 2, 3}, Vec3{4, 5, 6}, Vec3{7, 8, 9}
+	m := Mat3FromColumns(a, b, c)
+	e := MakeMat3(
+		1, 4, 7,
+		2, 5, 8,
+		3, 6, 9,
+	)
+	if m != e {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	if r := m.TimesVec3(Vec3{0, 1, 0}); r != b {
+		t.Errorf("Column not preserved: %#v", r)
+	}
+	if m.Transposed() != Mat3FromRows(a, b, c) {
+		t.Errorf("Transpose differs from Mat3FromRows: %#v", Mat3FromRows(a, b, c))
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat3_ColumnMajor(t *testing.T) {
+	m := MakeMat3(
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	)
+	c := m.ColumnMajor()
+	if c != [9]float32{1, 4, 7, 2, 5, 8, 3, 6, 9} {
+		t.Errorf("Wrong column-major layout: %v", c)
+	}
+	r := m.RowMajor()
+	if r != [9]float32{1, 2, 3, 4, 5, 6, 7, 8, 9} {
+		t.Errorf("Wrong row-major layout: %v", r)
+	}
+	// Element (row 0, column 2)
+	if c[2*3+0] != 3 || r[0*3+2] != 3 || m.At(0, 2) != 3 {
+		t.Errorf("Wrong element position: %v, %v", c[6], r[2])
+	}
+	if n := Mat3FromColumnMajor(c); n != m {
+		t.Errorf("No round-trip through column-major: %#v", n)
+	}
+	if n := Mat3FromRowMajor(r); n != m {
+		t.Errorf("No round-trip through row-major: %#v", n)
+	}
+	if n := Mat3FromRowMajorSlice(r[:]); n != m {
+		t.Errorf("Wrong result from slice: %#v", n)
+	}
+	if n := Mat3FromColumnMajorSlice(r[:]); n != m.Transposed() {
+		t.Errorf("Mismatched layouts are not a transpose: %#v", n)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("No panic on short slice")
+		}
+	}()
+	Mat3FromRowMajorSlice(r[:8])
+}
+
+func TestMat3_TimesVec3(t *testing.T) {
+	m := Mat3RotationZ(math.Pi / 2)
+	v := m.TimesVec3(Vec3{1, 0, 0})
+	if !isRoughlyEqualVec3(v, Vec3{0, 1, 0}, 1e-6) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+	m = Mat3RotationX(math.Pi / 2)
+	v = m.TimesVec3(Vec3{0, 1, 0})
+	if !isRoughlyEqualVec3(v, Vec3{0, 0, 1}, 1e-6) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+	m = Mat3RotationY(math.Pi / 2)
+	v = m.TimesVec3(Vec3{0, 0, 1})
+	if !isRoughlyEqualVec3(v, Vec3{1, 0, 0}, 1e-6) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+}
+
+func TestMat3RotationAxis(t *testing.T) {
+	if m := Mat3RotationAxis(Vec3{0.6, 0, 0.8}, 0); m != Mat3Identity() {
+		t.Errorf("Not identity for zero angle: %#v", m)
+	}
+	for _, angle := range []float32{0.3, -1.2, math.Pi, 2.9} {
+		if m := Mat3RotationAxis(Vec3{1, 0, 0}, angle); !isRoughlyEqualMat3(m, Mat3RotationX(angle), 1e-6) {
+			t.Errorf("Wrong result around X: %#v", m)
+		}
+		if m := Mat3RotationAxis(Vec3{0, 1, 0}, angle); !isRoughlyEqualMat3(m, Mat3RotationY(angle), 1e-6) {
+			t.Errorf("Wrong result around Y: %#v", m)
+		}
+		if m := Mat3RotationAxis(Vec3{0, 0, 1}, angle); !isRoughlyEqualMat3(m, Mat3RotationZ(angle), 1e-6) {
+			t.Errorf("Wrong result around Z: %#v", m)
+		}
+
+		// Conjugating a rotation around X moves its axis
+		a := Mat3RotationZ(0.4)
+		ay := Mat3RotationY(-0.9)
+		a = a.Times(&ay)
+		axis := a.TimesVec3(Vec3{1, 0, 0})
+		rx := Mat3RotationX(angle)
+		at := a.Transposed()
+		e := a.Times(&rx)
+		e = e.Times(&at)
+		m := Mat3RotationAxis(axis, angle)
+		if !isRoughlyEqualMat3(m, e, 1e-5) {
+			t.Errorf("Wrong result around %#v: %#v instead of %#v", axis, m, e)
+		}
+		if r := Rotation(angle, axis).Mat3(); !isRoughlyEqualMat3(m, r, 1e-6) {
+			t.Errorf("Differs from Mat4 path: %#v", r)
+		}
+		for _, v := range []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, -2, 3}} {
+			if r := v.RotateAxis(axis, angle); !isRoughlyEqualVec3(m.TimesVec3(v), r, 1e-5) {
+				t.Errorf("Differs from Vec3.RotateAxis for %#v: %#v", v, r)
+			}
+		}
+	}
+}
+
+func TestLookRotation(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ups := []Vec3{{0, 1, 0}, {0, 0, 1}, {1, 1, 1}}
+	for i := 0; i < 100; i++ {
+		f := Vec3{r.Float32()*2 - 1, r.Float32()*2 - 1, r.Float32()*2 - 1}
+		if f.Length() < 0.1 {
+			continue
+		}
+		up := ups[i%len(ups)]
+		forwards := []Vec3{f, up, up.Times(-3)}
+		for _, forward := range forwards {
+			m, ok := LookRotation(forward, up)
+			if parallel := forward.Cross(up).Length() < 1e-3; ok == parallel {
+				t.Errorf("Wrong ok for %v, %v: %v", forward, up, ok)
+			}
+			if !m.IsRigid(1e-5) || !math.IsRoughlyEqual(m.Determinant(), 1, 1e-5) {
+				t.Errorf("Not a rotation for %v, %v: %#v", forward, up, m)
+			}
+			if v := m.TimesVec3(Vec3{0, 0, -1}); !isRoughlyEqualVec3(v, forward.Normalized(), 1e-5) {
+				t.Errorf("Wrong forward axis for %v, %v: %#v", forward, up, v)
+			}
+			// The up axis is on the side of up
+			if v := m.TimesVec3(Vec3{0, 1, 0}); forward.Cross(up).Length() > 1e-3 && v.Dot(up) <= 0 {
+				t.Errorf("Wrong up axis for %v, %v: %#v", forward, up, v)
+			}
+			q, qok := QuatLookRotation(forward, up)
+			if qok != ok {
+				t.Errorf("QuatLookRotation differs on ok for %v, %v", forward, up)
+			}
+			if v := q.Rotate(Vec3{0, 0, -1}); !isRoughlyEqualVec3(v, forward.Normalized(), 1e-5) {
+				t.Errorf("Wrong quaternion for %v, %v: %#v", forward, up, q)
+			}
+		}
+	}
+	// Same as the camera orientation
+	eye, center, up := Vec3{1, 2, 3}, Vec3{-2, 0, 5}, Vec3{0, 1, 0}
+	m, _ := LookRotation(center.Minus(eye), up)
+	if e := LookAt(eye, center, up).Mat3().Transposed(); !isRoughlyEqualMat3(m, e, 1e-6) {
+		t.Errorf("Differs from LookAt: %#v instead of %#v", m, e)
+	}
+	if m, ok := LookRotation(Vec3{0, 0, -2}, Vec3{0, 3, 0}); !ok || !isRoughlyEqualMat3(m, Mat3Identity(), 1e-6) {
+		t.Errorf("Wrong result for default orientation: %#v", m)
+	}
+	if m, ok := LookRotation(Vec3{}, Vec3{0, 1, 0}); ok || m != Mat3Identity() {
+		t.Errorf("Wrong result for zero forward: %#v", m)
+	}
+}
+
+func TestMat3_Times(t *testing.T) {
+	a := Mat3RotationX(0.7)
+	b := Mat3RotationY(-1.3)
+	c := a.Times(&b)
+
+	ma := Rotation(0.7, Vec3{1, 0, 0})
+	mb := Rotation(-1.3, Vec3{0, 1, 0})
+	mc := ma.Times(&mb)
+
+	if !isRoughlyEqualMat3(c, mc.Mat3(), 1e-6) {
+		t.Errorf("Wrong result: %#v instead of %#v", c, mc.Mat3())
+	}
+
+	v := Vec3{1.1, -2.2, 3.3}
+	w := c.TimesVec3(v)
+	x := mc.TimesVec4(v.Homogenized()).Dehomogenized()
+	if !isRoughlyEqualVec3(w, x, 1e-5) {
+		t.Errorf("Wrong result: %#v instead of %#v", w, x)
+	}
+	y := b.TimesVec3(v)
+	y = a.TimesVec3(y)
+	if !isRoughlyEqualVec3(w, y, 1e-5) {
+		t.Errorf("Wrong order: %#v instead of %#v", w, y)
+	}
+
+	var d Mat3
+	d.Multiply(&a, &b)
+	if d != c {
+		t.Errorf("Multiply differs from Times: %#v", d)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat3_Transposed(t *testing.T) {
+	a := MakeMat3(
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	)
+	b := a.Transposed()
+	if b != MakeMat3(
+		1, 4, 7,
+		2, 5, 8,
+		3, 6, 9,
+	) {
+		t.Errorf("Wrong result: %#v", b)
+	}
+	a.Transpose()
+	if a != b {
+		t.Errorf("Wrong result: %#v", a)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat3_Determinant(t *testing.T) {
+	if d := Mat3Identity().Determinant(); d != 1 {
+		t.Errorf("Wrong determinant for identity: %v", d)
+	}
+	r := Mat3RotationX(0.7)
+	ry := Mat3RotationY(-1.3)
+	r = r.Times(&ry)
+	if d := r.Determinant(); !math.IsRoughlyEqual(d, 1, 1e-6) {
+		t.Errorf("Wrong determinant for rotation: %v", d)
+	}
+	s := MakeMat3(
+		2, 0, 0,
+		0, 3, 0,
+		0, 0, 4,
+	)
+	if d := s.Determinant(); d != 24 {
+		t.Errorf("Wrong determinant for scaling: %v", d)
+	}
+	mirror := MakeMat3(
+		1, 0, 0,
+		0, -1, 0,
+		0, 0, 1,
+	)
+	if d := r.Times(&mirror).Determinant(); !math.IsRoughlyEqual(d, -1, 1e-6) {
+		t.Errorf("Wrong determinant for mirror: %v", d)
+	}
+	singular := MakeMat3(
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	)
+	if d := singular.Determinant(); !math.IsRoughlyEqual(d, 0, 1e-5) {
+		t.Errorf("Wrong determinant for singular matrix: %v", d)
+	}
+	if d := singular.Mat4().Determinant(); d != singular.Determinant() {
+		t.Errorf("Determinant differs from Mat4 path: %v", d)
+	}
+}
+
+func TestMat3_Inverse(t *testing.T) {
+	m := MakeMat3(
+		2, 0.5, -1,
+		0.3, 3, 0.2,
+		1, -0.7, 4,
+	)
+	inv, ok := m.Inverse()
+	if !ok {
+		t.Fatalf("Reported singular")
+	}
+	if p := m.Times(&inv); !isRoughlyEqualMat3(p, Mat3Identity(), 1e-6) {
+		t.Errorf("Wrong result: %#v", p)
+	}
+	if p := inv.Times(&m); !isRoughlyEqualMat3(p, Mat3Identity(), 1e-6) {
+		t.Errorf("Wrong result: %#v", p)
+	}
+	_, ok = MakeMat3(
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	).Inverse()
+	if ok {
+		t.Errorf("Singular matrix not detected")
+	}
+}
+
+func TestMat3_Inverse_special(t *testing.T) {
+	r := Mat3RotationAxis(Vec3{0.6, 0, 0.8}, 1.3)
+	if inv, ok := r.Inverse(); !ok || !isRoughlyEqualMat3(inv, r.Transposed(), 1e-6) {
+		t.Errorf("Rotation inverse is not the transpose: %#v", inv)
+	}
+	s := MakeMat3(
+		2, 0, 0,
+		0, 3, 0,
+		0, 0, 4,
+	)
+	e := MakeMat3(
+		1.0/2, 0, 0,
+		0, 1.0/3, 0,
+		0, 0, 1.0/4,
+	)
+	if inv, ok := s.Inverse(); !ok || !isRoughlyEqualMat3(inv, e, 1e-7) {
+		t.Errorf("Wrong inverse for scaling: %#v", inv)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		m := randomMat4(rnd).Mat3()
+		inv, ok := m.Inverse()
+		if !ok {
+			t.Errorf("Reported singular: %#v", m)
+			continue
+		}
+		if p := m.Times(&inv); !isRoughlyEqualMat3(p, Mat3Identity(), 1e-5) {
+			t.Errorf("Wrong result: %#v", p)
+		}
+	}
+
+	// Nearly parallel columns
+	_, ok := MakeMat3(
+		1, 2, 0,
+		1, 2.000001, 0,
+		0, 0, 1,
+	).Inverse()
+	if ok {
+		t.Errorf("Nearly singular matrix not detected")
+	}
+}
+
+func TestMat3_Trace(t *testing.T) {
+	m := MakeMat3(
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	)
+	if tr := m.Trace(); tr != 15 {
+		t.Errorf("Wrong result: %v", tr)
+	}
+}
+
+func TestMat3_IsOrthogonal(t *testing.T) {
+	r := Mat3RotationAxis(Vec3{0, 0.6, 0.8}, 2)
+	if !r.IsOrthogonal(1e-6) || !r.IsRigid(1e-6) {
+		t.Errorf("Rotation not rigid: %#v", r)
+	}
+	// Reflection through the XY plane
+	f := r
+	f[2] = [3]float32{-f[2][0], -f[2][1], -f[2][2]}
+	if !f.IsOrthogonal(1e-6) || f.IsRigid(1e-6) {
+		t.Errorf("Wrong result for reflection: %#v", f)
+	}
+	// The diagonal of mᵀ·m is (1+d)², i.e. about 1 + 2d
+	for _, c := range []struct {
+		d  float32
+		ok bool
+	}{{0.4e-3, true}, {0.6e-3, false}} {
+		s := r
+		for i := range s[0] {
+			s[0][i] *= 1 + c.d
+		}
+		if s.IsOrthogonal(1e-3) != c.ok || s.IsRigid(1e-3) != c.ok {
+			t.Errorf("Wrong result for scale %v", 1+c.d)
+		}
+	}
+	if Mat3Shear(0.01, 0, 0, 0, 0, 0).IsOrthogonal(1e-3) {
+		t.Errorf("Shear reported orthogonal")
+	}
+}
+
+func TestMat3_FrobeniusNorm(t *testing.T) {
+	m := MakeMat3(
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	)
+	if n := m.FrobeniusNorm(); n != 16.881943 {
+		t.Errorf("Wrong result: %v", n)
+	}
+	// Drift from orthonormal
+	r := Mat3RotationAxis(Vec3{0, 0.6, 0.8}, 1.2)
+	p := r.Transposed()
+	p = p.Times(&r)
+	for c := range p {
+		p[c][c]--
+	}
+	if n := p.FrobeniusNorm(); n > 1e-6 {
+		t.Errorf("Rotation not orthonormal: %v", n)
+	}
+	r[0][0] *= 1.01
+	p = r.Transposed()
+	p = p.Times(&r)
+	for c := range p {
+		p[c][c]--
+	}
+	if n := p.FrobeniusNorm(); n < 1e-3 {
+		t.Errorf("Drift not detected: %v", n)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestVec3_Outer(t *testing.T) {
+	m := Vec3{1, 2, 3}.Outer(Vec3{4, 5, 6})
+	e := MakeMat3(
+		4, 5, 6,
+		8, 10, 12,
+		12, 15, 18,
+	)
+	if m != e {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	v := Vec3{-1, 0.5, 2}
+	if r := m.TimesVec3(v); r != (Vec3{1, 2, 3}.Times(Vec3{4, 5, 6}.Dot(v))) {
+		t.Errorf("Wrong product: %#v", r)
+	}
+}
+
+func TestCovariance(t *testing.T) {
+	// Corners of a box centered on (10, 20, 30)
+	var points []Vec3
+	for _, x := range []float32{-1, 1} {
+		for _, y := range []float32{-2, 2} {
+			for _, z := range []float32{-3, 3} {
+				points = append(points, Vec3{10 + x, 20 + y, 30 + z})
+			}
+		}
+	}
+	if m := Covariance(points); m != MakeMat3(1, 0, 0, 0, 4, 0, 0, 0, 9) {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	// Points along a line
+	points = []Vec3{{-1, -2, 5}, {0, 0, 5}, {1, 2, 5}}
+	e := MakeMat3(
+		2.0/3, 4.0/3, 0,
+		4.0/3, 8.0/3, 0,
+		0, 0, 0,
+	)
+	if m := Covariance(points); !isRoughlyEqualMat3(m, e, 1e-6) {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	if m := Covariance(nil); m != (Mat3{}) {
+		t.Errorf("Wrong result for empty slice: %#v", m)
+	}
+}
+
+func TestMat3_SymmetricEigen(t *testing.T) {
+	check := func(m Mat3, values Vec3) {
+		l, v := m.SymmetricEigen()
+		if !isRoughlyEqualVec3(l, values, 1e-5) {
+			t.Errorf("Wrong eigenvalues for %#v: %#v instead of %#v", m, l, values)
+		}
+		if l.X < l.Y || l.Y < l.Z {
+			t.Errorf("Eigenvalues not sorted: %#v", l)
+		}
+		for i, li := range [3]float32{l.X, l.Y, l.Z} {
+			vi := Vec3{v[i][0], v[i][1], v[i][2]}
+			if r := m.TimesVec3(vi); !isRoughlyEqualVec3(r, vi.Times(li), 1e-5) {
+				t.Errorf("M·v != λ·v for %#v: %#v instead of %#v", m, r, vi.Times(li))
+			}
+		}
+		p := v.Transposed()
+		p = p.Times(&v)
+		if !isRoughlyEqualMat3(p, Mat3Identity(), 1e-6) || !math.IsRoughlyEqual(v.Determinant(), 1, 1e-6) {
+			t.Errorf("Eigenvectors are not a rotation: %#v", v)
+		}
+	}
+
+	check(MakeMat3(
+		2, 0, 0,
+		0, 5, 0,
+		0, 0, -1,
+	), Vec3{5, 2, -1})
+	check(Mat3Identity(), Vec3{1, 1, 1})
+	check(Mat3{}, Vec3{0, 0, 0})
+	check(MakeMat3(
+		2, -1, 0,
+		-1, 2, -1,
+		0, -1, 2,
+	), Vec3{2 + math.Sqrt2, 2, 2 - math.Sqrt2})
+	check(MakeMat3(
+		2, 1, 0,
+		1, 2, 0,
+		0, 0, 3,
+	), Vec3{3, 3, 1})
+
+	// Oriented point cloud
+	r := Mat3RotationAxis(Vec3{0.6, 0, 0.8}, 0.9)
+	var points []Vec3
+	for _, x := range []float32{-3, 3} {
+		for _, y := range []float32{-2, 2} {
+			for _, z := range []float32{-1, 1} {
+				points = append(points, r.TimesVec3(Vec3{x, y, z}).Plus(Vec3{5, 6, 7}))
+			}
+		}
+	}
+	c := Covariance(points)
+	check(c, Vec3{9, 4, 1})
+	_, v := c.SymmetricEigen()
+	for i := 0; i < 3; i++ {
+		if d := math.Abs(Vec3{v[i][0], v[i][1], v[i][2]}.Dot(Vec3{r[i][0], r[i][1], r[i][2]})); !math.IsRoughlyEqual(d, 1, 1e-5) {
+			t.Errorf("Wrong axis %d: %#v", i, v[i])
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat3_Mat4(t *testing.T) {
+	a := MakeMat3(
+		1.1, 2.2, 3.3,
+		4.4, 5.5, 6.6,
+		7.7, 8.8, 9.9,
+	)
+	b := a.Mat4()
+	if b[3] != [4]float32{0, 0, 0, 1} ||
+		b[0][3] != 0 || b[1][3] != 0 || b[2][3] != 0 {
+		t.Errorf("Wrong result: %#v", b)
+	}
+	if b.Mat3() != a {
+		t.Errorf("No round-trip: %#v", b.Mat3())
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func BenchmarkMat3_Times(b *testing.B) {
+	m := Mat3RotationX(0.7)
+	n := Mat3RotationY(-1.3)
+	var o Mat3
+	for i := 0; i < b.N; i++ {
+		o = m.Times(&n)
+	}
+	_ = o
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat3_String(t *testing.T) {
+	if s, e := Mat3Identity().String(), "[1.000 0.000 0.000]\n[0.000 1.000 0.000]\n[0.000 0.000 1.000]"; s != e {
+		t.Errorf("Wrong result for identity:\n%s\ninstead of:\n%s", s, e)
+	}
+	m := Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, -9}}
+	e := "" +
+		"[1.0 4.0  7.0]\n" +
+		"[2.0 5.0  8.0]\n" +
+		"[3.0 6.0 -9.0]"
+	if s := m.StringPrec(1); s != e {
+		t.Errorf("Wrong result:\n%s\ninstead of:\n%s", s, e)
+	}
+}
+
+//------------------------------------------------------------------------------
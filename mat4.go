@@ -3,7 +3,13 @@
 
 package glam
 
-import "github.com/drakmaniso/glam/math"
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/drakmaniso/glam/math"
+)
 
 //------------------------------------------------------------------------------
 
@@ -115,37 +121,376 @@ func Identity() Mat4 {
 
 //------------------------------------------------------------------------------
 
-// `At` returns the element at '(row, column)`.
+// `Mat4FromColumns` returns the matrix whose columns are `c0`, `c1`, `c2` and
+// `c3`. This is the storage order, so each vector is copied unchanged.
+//
+// See also `Mat4FromRows`.
+func Mat4FromColumns(c0, c1, c2, c3 Vec4) Mat4 {
+	return Mat4{
+		{c0.X, c0.Y, c0.Z, c0.W},
+		{c1.X, c1.Y, c1.Z, c1.W},
+		{c2.X, c2.Y, c2.Z, c2.W},
+		{c3.X, c3.Y, c3.Z, c3.W},
+	}
+}
+
+// `Mat4FromRows` returns the matrix whose rows are `r0`, `r1`, `r2` and `r3`.
+//
+// See also `Mat4FromColumns`.
+func Mat4FromRows(r0, r1, r2, r3 Vec4) Mat4 {
+	return Mat4{
+		{r0.X, r1.X, r2.X, r3.X},
+		{r0.Y, r1.Y, r2.Y, r3.Y},
+		{r0.Z, r1.Z, r2.Z, r3.Z},
+		{r0.W, r1.W, r2.W, r3.W},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `ColumnMajor` returns the elements of `m` in column-major order (the
+// storage order, and the default layout of OpenGL): element `(row, column)`
+// is at index `column*4 + row`.
+//
+// See also `RowMajor` and `Mat4FromColumnMajor`.
+func (m Mat4) ColumnMajor() [16]float32 {
+	return [16]float32{
+		m[0][0], m[0][1], m[0][2], m[0][3],
+		m[1][0], m[1][1], m[1][2], m[1][3],
+		m[2][0], m[2][1], m[2][2], m[2][3],
+		m[3][0], m[3][1], m[3][2], m[3][3],
+	}
+}
+
+// `Array` returns the elements of `m` in column-major order, as expected by
+// OpenGL (e.g. `glUniformMatrix4fv` with `transpose` set to false). It is the
+// same as `ColumnMajor`.
+//
+// See also `Pointer`.
+func (m Mat4) Array() [16]float32 {
+	return m.ColumnMajor()
+}
+
+// `Pointer` returns a pointer to the first element of `m`, for direct upload to
+// OpenGL. The 16 elements are contiguous, in column-major order (as with
+// `Array`): element `(row, column)` is at offset `column*4 + row`.
+//
+// The pointer aliases the memory of `m`: there is no copy, and changes to `m`
+// are visible through it (and vice versa).
+func (m *Mat4) Pointer() *float32 {
+	return &m[0][0]
+}
+
+// `RowMajor` returns the elements of `m` in row-major order (the layout used
+// e.g. by DirectX): element `(row, column)` is at index `row*4 + column`.
+//
+// See also `ColumnMajor` and `Mat4FromRowMajor`.
+func (m Mat4) RowMajor() [16]float32 {
+	return [16]float32{
+		m[0][0], m[1][0], m[2][0], m[3][0],
+		m[0][1], m[1][1], m[2][1], m[3][1],
+		m[0][2], m[1][2], m[2][2], m[3][2],
+		m[0][3], m[1][3], m[2][3], m[3][3],
+	}
+}
+
+// `Mat4FromColumnMajor` returns the matrix whose elements are given by `a` in
+// column-major order.
+//
+// See also `ColumnMajor` and `Mat4FromColumnMajorSlice`.
+func Mat4FromColumnMajor(a [16]float32) Mat4 {
+	return Mat4FromColumnMajorSlice(a[:])
+}
+
+// `Mat4FromRowMajor` returns the matrix whose elements are given by `a` in
+// row-major order.
+//
+// See also `RowMajor` and `Mat4FromRowMajorSlice`.
+func Mat4FromRowMajor(a [16]float32) Mat4 {
+	return Mat4FromRowMajorSlice(a[:])
+}
+
+// `Mat4FromColumnMajorSlice` returns the matrix whose elements are given by
+// the first 16 elements of `s`, in column-major order. It panics if `s` is
+// shorter.
+func Mat4FromColumnMajorSlice(s []float32) Mat4 {
+	if len(s) < 16 {
+		panic(fmt.Sprintf("glam.Mat4FromColumnMajorSlice: %d elements instead of 16", len(s)))
+	}
+	return Mat4{
+		{s[0], s[1], s[2], s[3]},
+		{s[4], s[5], s[6], s[7]},
+		{s[8], s[9], s[10], s[11]},
+		{s[12], s[13], s[14], s[15]},
+	}
+}
+
+// `Mat4FromRowMajorSlice` returns the matrix whose elements are given by the
+// first 16 elements of `s`, in row-major order. It panics if `s` is shorter.
+func Mat4FromRowMajorSlice(s []float32) Mat4 {
+	if len(s) < 16 {
+		panic(fmt.Sprintf("glam.Mat4FromRowMajorSlice: %d elements instead of 16", len(s)))
+	}
+	return Mat4{
+		{s[0], s[4], s[8], s[12]},
+		{s[1], s[5], s[9], s[13]},
+		{s[2], s[6], s[10], s[14]},
+		{s[3], s[7], s[11], s[15]},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `At` returns the element at `(row, column)`.
+//
+// It panics if `row` or `column` is not in [0, 3].
 func (m Mat4) At(row, column int) float32 {
+	checkMat4Index("At", "row", row)
+	checkMat4Index("At", "column", column)
 	return m[column][row]
 }
 
 // `Set` sets the element at `(row, column)` to `value`.
+//
+// It panics if `row` or `column` is not in [0, 3].
 func (m *Mat4) Set(row, column int, value float32) {
+	checkMat4Index("Set", "row", row)
+	checkMat4Index("Set", "column", column)
 	m[column][row] = value
 }
 
+// `Row` returns the row `i` of `m`. It panics if `i` is not in [0, 3].
+//
+// See also `Col` and `SetRow`.
+func (m Mat4) Row(i int) Vec4 {
+	checkMat4Index("Row", "index", i)
+	return Vec4{m[0][i], m[1][i], m[2][i], m[3][i]}
+}
+
+// `Col` returns the column `i` of `m` (e.g. `Col(3)` of a translation matrix
+// is the translation). It panics if `i` is not in [0, 3].
+//
+// See also `Row` and `SetCol`.
+func (m Mat4) Col(i int) Vec4 {
+	checkMat4Index("Col", "index", i)
+	return Vec4{m[i][0], m[i][1], m[i][2], m[i][3]}
+}
+
+// `SetRow` sets the row `i` of `m` to `v`. It panics if `i` is not in [0, 3].
+//
+// See also `Row` and `SetCol`.
+func (m *Mat4) SetRow(i int, v Vec4) {
+	checkMat4Index("SetRow", "index", i)
+	m[0][i], m[1][i], m[2][i], m[3][i] = v.X, v.Y, v.Z, v.W
+}
+
+// `SetCol` sets the column `i` of `m` to `v`. It panics if `i` is not in
+// [0, 3].
+//
+// See also `Col` and `SetRow`.
+func (m *Mat4) SetCol(i int, v Vec4) {
+	checkMat4Index("SetCol", "index", i)
+	m[i] = [4]float32{v.X, v.Y, v.Z, v.W}
+}
+
+func checkMat4Index(method, name string, i int) {
+	if i < 0 || i > 3 {
+		panic(fmt.Sprintf("glam.Mat4.%s: %s %d out of range [0, 3]", method, name, i))
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Equal` returns true if `m` and `o` are exactly equal (this is the same as
+// `m == o`).
+//
+// See also `NearlyEqual` and `RoughlyEqual`.
+func (m Mat4) Equal(o Mat4) bool {
+	return m == o
+}
+
+// `RoughlyEqual` returns true if the absolute difference between each
+// element of `m` and `o` is less than `epsilon`.
+//
+// See also `NearlyEqual` and `Equal`.
+func (m Mat4) RoughlyEqual(o Mat4, epsilon float32) bool {
+	a, b := m.ColumnMajor(), o.ColumnMajor()
+	return roughlyEqual(a[:], b[:], epsilon)
+}
+
+// `NearlyEqual` returns true if the difference between each element of `m`
+// and `o` is less than `epsilon` times the largest absolute element of
+// either matrix.
+//
+// Unlike `math.IsNearlyEqual`, the tolerance is relative to the whole matrix,
+// so that elements which should be zero do not need a special case.
+//
+// See also `RoughlyEqual` and `Equal`.
+func (m Mat4) NearlyEqual(o Mat4, epsilon float32) bool {
+	a, b := m.ColumnMajor(), o.ColumnMajor()
+	return nearlyEqual(a[:], b[:], epsilon)
+}
+
+//------------------------------------------------------------------------------
+
+// `String` returns `m` printed with 3 digits after the decimal point, one row
+// per line (rows are printed as rows, even though matrices are stored in
+// column-major order). It implements `fmt.Stringer`.
+//
+// See also `StringPrec`.
+func (m Mat4) String() string {
+	return m.StringPrec(3)
+}
+
+// `StringPrec` returns `m` printed with `digits` digits after the decimal
+// point, in the same layout as `String`.
+func (m Mat4) StringPrec(digits int) string {
+	rows := make([][]float32, 4)
+	for r := range rows {
+		rows[r] = make([]float32, 4)
+		for c := range rows[r] {
+			rows[r][c] = m[c][r]
+		}
+	}
+	return formatMatrix(rows, digits)
+}
+
 //------------------------------------------------------------------------------
 
-// `Perspective` returns a perspective projection matrix.
+// `Perspective` returns a symmetric perspective projection matrix.
+// `fieldOfView` is the vertical angle, and `aspectRatio` the width divided by
+// the height.
+//
+// This is the special case of `Frustum` where the frustum is centered on the
+// view axis, and follows the same conventions; in particular, it panics if the
+// resulting frustum is degenerate.
 //
-// See also `SetToPerspective`, `PerspectiveFrustum` and `SetToPerspectiveFrustum`.
+// See also `SetToPerspective`, `Frustum` and `SetToFrustum`.
 func Perspective(fieldOfView, aspectRatio, near, far float32) Mat4 {
-	f := float32(1.0) / math.Tan(fieldOfView/float32(2.0))
+	top := near * math.Tan(fieldOfView/2)
+	right := top * aspectRatio
+	checkFrustum("Perspective", -right, right, -top, top, near, far)
+	var m Mat4
+	m.setToFrustum(-right, right, -top, top, near, far)
+	return m
+}
+
+// `SetToPerspective` sets `m` to a symmetric perspective projection matrix.
+// `fieldOfView` is the vertical angle, and `aspectRatio` the width divided by
+// the height.
+//
+// See also `Perspective`, `Frustum` and `SetToFrustum`.
+func (m *Mat4) SetToPerspective(fieldOfView, aspectRatio, near, far float32) {
+	top := near * math.Tan(fieldOfView/2)
+	right := top * aspectRatio
+	checkFrustum("Mat4.SetToPerspective", -right, right, -top, top, near, far)
+	m.setToFrustum(-right, right, -top, top, near, far)
+}
+
+//------------------------------------------------------------------------------
+
+// `Frustum` returns a perspective projection matrix, for a frustum that may be
+// asymmetric (e.g. for VR or multi-screen setups). It is the same matrix as
+// `glFrustum`.
+//
+// The camera looks towards -Z, and the frustum is mapped to the cube from -1
+// to 1 in normalized device coordinates. `left`, `right`, `bottom` and `top`
+// are the bounds of the near plane; `near` and `far` are the (positive)
+// distances to the clipping planes.
+//
+// Like `glFrustum`, it rejects degenerate frustums: it panics if `near` or
+// `far` is not strictly positive, or if `left == right`, `bottom == top` or
+// `near == far`.
+//
+// See also `SetToFrustum`, `Perspective` and `SetToPerspective`.
+func Frustum(left, right, bottom, top, near, far float32) Mat4 {
+	checkFrustum("Frustum", left, right, bottom, top, near, far)
+	var m Mat4
+	m.setToFrustum(left, right, bottom, top, near, far)
+	return m
+}
+
+// `SetToFrustum` sets `m` to a perspective projection matrix.
+//
+// See also `Frustum`, `Perspective` and `SetToPerspective`.
+func (m *Mat4) SetToFrustum(left, right, bottom, top, near, far float32) {
+	checkFrustum("Mat4.SetToFrustum", left, right, bottom, top, near, far)
+	m.setToFrustum(left, right, bottom, top, near, far)
+}
+
+// `PerspectiveFrustum` is the same as `Frustum`.
+func PerspectiveFrustum(left, right, bottom, top, near, far float32) Mat4 {
+	checkFrustum("PerspectiveFrustum", left, right, bottom, top, near, far)
+	var m Mat4
+	m.setToFrustum(left, right, bottom, top, near, far)
+	return m
+}
+
+// `SetToPerspectiveFrustum` is the same as `SetToFrustum`.
+func (m *Mat4) SetToPerspectiveFrustum(left, right, bottom, top, near, far float32) {
+	checkFrustum("Mat4.SetToPerspectiveFrustum", left, right, bottom, top, near, far)
+	m.setToFrustum(left, right, bottom, top, near, far)
+}
+
+func checkFrustum(function string, left, right, bottom, top, near, far float32) {
+	if !(near > 0) || !(far > 0) || left == right || bottom == top || near == far {
+		panic(fmt.Sprintf(
+			"glam.%s: degenerate frustum (left %v, right %v, bottom %v, top %v, near %v, far %v)",
+			function, left, right, bottom, top, near, far,
+		))
+	}
+}
+
+func (m *Mat4) setToFrustum(left, right, bottom, top, near, far float32) {
+	m[0][0] = (2 * near) / (right - left)
+	m[0][1] = 0
+	m[0][2] = 0
+	m[0][3] = 0
+
+	m[1][0] = 0
+	m[1][1] = (2 * near) / (top - bottom)
+	m[1][2] = 0
+	m[1][3] = 0
+
+	m[2][0] = (right + left) / (right - left)
+	m[2][1] = (top + bottom) / (top - bottom)
+	m[2][2] = -(far + near) / (far - near)
+	m[2][3] = -1
+
+	m[3][0] = 0
+	m[3][1] = 0
+	m[3][2] = -(2 * far * near) / (far - near)
+	m[3][3] = 0
+}
 
+//------------------------------------------------------------------------------
+
+// `PerspectiveReversedZ` returns a symmetric perspective projection matrix
+// with reversed depth and no far plane: the near plane is mapped to a depth of
+// 1, and the depth tends towards 0 as the distance goes to infinity.
+//
+// Combined with a floating-point depth buffer, this gives a nearly uniform
+// depth precision over the whole view. With OpenGL, it expects
+// `glClipControl(GL_LOWER_LEFT, GL_ZERO_TO_ONE)`, `glDepthFunc(GL_GREATER)`
+// and the depth buffer cleared to 0.
+//
+// See also `SetToPerspectiveReversedZ` and `PerspectiveZeroToOne`.
+func PerspectiveReversedZ(fieldOfView, aspectRatio, near float32) Mat4 {
+	f := 1 / math.Tan(fieldOfView/2)
 	return Mat4{
 		{f / aspectRatio, 0, 0, 0},
 		{0, f, 0, 0},
-		{0, 0, (far + near) / (near - far), -1},
-		{0, 0, (2 * far * near) / (near - far), 0},
+		{0, 0, 0, -1},
+		{0, 0, near, 0},
 	}
 }
 
-// `SetToPerspective` sets `m` to a perspective projection matrix.
+// `SetToPerspectiveReversedZ` sets `m` to a symmetric perspective projection
+// matrix with reversed depth and no far plane.
 //
-// See also `Perspective`, `PerspectiveFrustum` and `SetToPerspectiveFrustum`.
-func (m *Mat4) SetToPerspective(fieldOfView, aspectRatio, near, far float32) {
-	f := float32(1.0) / math.Tan(fieldOfView/float32(2.0))
+// See also `PerspectiveReversedZ`.
+func (m *Mat4) SetToPerspectiveReversedZ(fieldOfView, aspectRatio, near float32) {
+	f := 1 / math.Tan(fieldOfView/2)
 
 	m[0][0] = f / aspectRatio
 	m[0][1] = 0
@@ -159,51 +504,59 @@ func (m *Mat4) SetToPerspective(fieldOfView, aspectRatio, near, far float32) {
 
 	m[2][0] = 0
 	m[2][1] = 0
-	m[2][2] = (far + near) / (near - far)
+	m[2][2] = 0
 	m[2][3] = -1
 
 	m[3][0] = 0
 	m[3][1] = 0
-	m[3][2] = (2 * far * near) / (near - far)
+	m[3][2] = near
 	m[3][3] = 0
 }
 
-//------------------------------------------------------------------------------
-
-// `PerspectiveFrustum` returns a perspective projection matrix.
+// `PerspectiveZeroToOne` returns a symmetric perspective projection matrix
+// mapping the depth to [0, 1] instead of [-1, 1]: the near plane is at 0 and
+// the far plane at 1, as expected by Direct3D and Vulkan.
 //
-// See also `SetToPerspectiveFrustum`, `Perspective` and `SetToPerspective`.
-func PerspectiveFrustum(left, right, bottom, top, near, far float32) Mat4 {
+// With OpenGL, it expects `glClipControl(GL_LOWER_LEFT, GL_ZERO_TO_ONE)` and
+// the default `glDepthFunc(GL_LESS)`.
+//
+// See also `SetToPerspectiveZeroToOne`, `Perspective` and
+// `PerspectiveReversedZ`.
+func PerspectiveZeroToOne(fieldOfView, aspectRatio, near, far float32) Mat4 {
+	f := 1 / math.Tan(fieldOfView/2)
 	return Mat4{
-		{(2 * near) / (right - left), 0, 0, 0},
-		{0, (2 * near) / (top - bottom), 0, 0},
-		{(right + left) / (right - left), (top + bottom) / (top - bottom), -(far + near) / (far - near), -1},
-		{0, 0, -(2 * far * near) / (far - near), 0},
+		{f / aspectRatio, 0, 0, 0},
+		{0, f, 0, 0},
+		{0, 0, -far / (far - near), -1},
+		{0, 0, -(far * near) / (far - near), 0},
 	}
 }
 
-// `SetToPerspectiveFrustum` sets `m` to a perspective projection matrix.
+// `SetToPerspectiveZeroToOne` sets `m` to a symmetric perspective projection
+// matrix mapping the depth to [0, 1].
 //
-// See also `PerspectiveFrustum`, `Perspective` and `SetToPerspective`.
-func (m *Mat4) SetToPerspectiveFrustum(left, right, bottom, top, near, far float32) {
-	m[0][0] = (2 * near) / (right - left)
+// See also `PerspectiveZeroToOne`.
+func (m *Mat4) SetToPerspectiveZeroToOne(fieldOfView, aspectRatio, near, far float32) {
+	f := 1 / math.Tan(fieldOfView/2)
+
+	m[0][0] = f / aspectRatio
 	m[0][1] = 0
 	m[0][2] = 0
 	m[0][3] = 0
 
 	m[1][0] = 0
-	m[1][1] = (2 * near) / (top - bottom)
+	m[1][1] = f
 	m[1][2] = 0
 	m[1][3] = 0
 
-	m[2][0] = (right + left) / (right - left)
-	m[2][1] = (top + bottom) / (top - bottom)
-	m[2][2] = -(far + near) / (far - near)
+	m[2][0] = 0
+	m[2][1] = 0
+	m[2][2] = -far / (far - near)
 	m[2][3] = -1
 
 	m[3][0] = 0
 	m[3][1] = 0
-	m[3][2] = -(2 * far * near) / (far - near)
+	m[3][2] = -(far * near) / (far - near)
 	m[3][3] = 0
 }
 
@@ -294,6 +647,198 @@ func (m *Mat4) SetToOrthographicFrustum(left, right, bottom, top, near, far floa
 
 //------------------------------------------------------------------------------
 
+// `Ortho2D` returns an orthographic projection matrix for pixel coordinates,
+// with the origin at the top-left corner of the screen and Y pointing down.
+// It is the same as `OrthographicFrustum(0, width, height, 0, -1, 1)`.
+//
+// Pixel coordinates are mapped to the edges of the pixels: the top-left
+// corner of the screen is at (0, 0), the bottom-right corner at
+// (`width`, `height`), and the center of pixel (i, j) at (i+0.5, j+0.5).
+//
+// See also `Ortho2DYUp` and `Ortho2DView`.
+func Ortho2D(width, height float32) Mat4 {
+	return Mat4{
+		{2 / width, 0, 0, 0},
+		{0, -2 / height, 0, 0},
+		{0, 0, -1, 0},
+		{-1, 1, 0, 1},
+	}
+}
+
+// `Ortho2DYUp` returns an orthographic projection matrix for pixel
+// coordinates, with the origin at the bottom-left corner of the screen and Y
+// pointing up (as in OpenGL window coordinates). It is otherwise the same as
+// `Ortho2D`.
+func Ortho2DYUp(width, height float32) Mat4 {
+	return Mat4{
+		{2 / width, 0, 0, 0},
+		{0, 2 / height, 0, 0},
+		{0, 0, -1, 0},
+		{-1, -1, 0, 1},
+	}
+}
+
+// `Ortho2DView` returns an orthographic projection matrix for a 2D camera:
+// the point `origin` is at the top-left corner of the screen, and each unit
+// spans `scale` pixels (Y pointing down, as with `Ortho2D`).
+//
+// `Ortho2DView(Vec2{0, 0}, 1, width, height)` is the same as
+// `Ortho2D(width, height)`.
+func Ortho2DView(origin Vec2, scale float32, width, height float32) Mat4 {
+	sx := 2 * scale / width
+	sy := -2 * scale / height
+	return Mat4{
+		{sx, 0, 0, 0},
+		{0, sy, 0, 0},
+		{0, 0, -1, 0},
+		{-1 - sx*origin.X, 1 - sy*origin.Y, 0, 1},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Viewport` returns the matrix mapping normalized device coordinates to
+// window coordinates, as done by `glViewport(x, y, width, height)` and
+// `glDepthRange(nearDepth, farDepth)`.
+//
+// The NDC cube from -1 to 1 is mapped to the rectangle from (`x`, `y`) to
+// (`x`+`width`, `y`+`height`), and the depth from `nearDepth` to `farDepth`.
+//
+// See also `NDCToWindow`.
+func Viewport(x, y, width, height, nearDepth, farDepth float32) Mat4 {
+	return Mat4{
+		{width / 2, 0, 0, 0},
+		{0, height / 2, 0, 0},
+		{0, 0, (farDepth - nearDepth) / 2, 0},
+		{x + width/2, y + height/2, (farDepth + nearDepth) / 2, 1},
+	}
+}
+
+// `NDCToWindow` returns the window coordinates of the point `ndc`, given in
+// normalized device coordinates. `viewport` holds the position and size of the
+// viewport (as the arguments of `glViewport`), and the depth is mapped to the
+// default depth range [0, 1].
+//
+// This is the same as transforming `ndc` by `Viewport(viewport.X, viewport.Y,
+// viewport.Z, viewport.W, 0, 1)`, but faster.
+func NDCToWindow(ndc Vec3, viewport Vec4) Vec3 {
+	return Vec3{
+		viewport.X + (ndc.X+1)*viewport.Z/2,
+		viewport.Y + (ndc.Y+1)*viewport.W/2,
+		(ndc.Z + 1) / 2,
+	}
+}
+
+// `Project` returns the window coordinates of the point `world`, transformed
+// by `modelview` and `projection` (as with `gluProject`). `viewport` is the
+// same as for `NDCToWindow`, and the Z coordinate of the result is the window
+// depth, in [0, 1] for points inside the frustum.
+//
+// The boolean is false if the point is on or behind the plane of the camera,
+// in which case the result is meaningless.
+func Project(world Vec3, modelview, projection Mat4, viewport Vec4) (Vec3, bool) {
+	p := modelview.TimesVec4(Vec4{world.X, world.Y, world.Z, 1})
+	p = projection.TimesVec4(p)
+	if p.W <= 0 {
+		return Vec3{}, false
+	}
+	return NDCToWindow(p.Dehomogenized(), viewport), true
+}
+
+// `Unproject` returns the world coordinates of the point `screen`, given in
+// window coordinates (with the depth in [0, 1]), i.e. it is the inverse of
+// `Project` (as with `gluUnProject`).
+//
+// The boolean is false if the product of `projection` and `modelview` is
+// singular, or if the point is at infinity.
+//
+// See also `PickRay`.
+func Unproject(screen Vec3, modelview, projection Mat4, viewport Vec4) (Vec3, bool) {
+	m := projection.Times(&modelview)
+	inv, ok := m.Inverse()
+	if !ok {
+		return Vec3{}, false
+	}
+	return unproject(&inv, screen, viewport)
+}
+
+func unproject(inv *Mat4, screen Vec3, viewport Vec4) (Vec3, bool) {
+	p := inv.TimesVec4(Vec4{
+		(screen.X-viewport.X)/viewport.Z*2 - 1,
+		(screen.Y-viewport.Y)/viewport.W*2 - 1,
+		screen.Z*2 - 1,
+		1,
+	})
+	if p.W == 0 {
+		return Vec3{}, false
+	}
+	return p.Dehomogenized(), true
+}
+
+// `PickRay` returns the ray going through the pixel at window coordinates
+// `screenXY` (e.g. the mouse position), for picking. The origin of the ray is
+// on the near plane, and its direction is normalized and points away from the
+// camera.
+//
+// The boolean is false if the product of `projection` and `modelview` is
+// singular.
+//
+// See also `Unproject`.
+func PickRay(screenXY Vec2, modelview, projection Mat4, viewport Vec4) (origin, dir Vec3, ok bool) {
+	m := projection.Times(&modelview)
+	inv, ok := m.Inverse()
+	if !ok {
+		return Vec3{}, Vec3{}, false
+	}
+	origin, ok = unproject(&inv, Vec3{screenXY.X, screenXY.Y, 0}, viewport)
+	if !ok {
+		return Vec3{}, Vec3{}, false
+	}
+	far, ok := unproject(&inv, Vec3{screenXY.X, screenXY.Y, 1}, viewport)
+	if !ok {
+		return Vec3{}, Vec3{}, false
+	}
+	return origin, far.Minus(origin).Normalized(), true
+}
+
+//------------------------------------------------------------------------------
+
+// `FrustumCorners` returns the eight corners of the view frustum, in world
+// space, given the inverse of the view-projection matrix. This is the same as
+// `FrustumSliceCorners(invViewProj, -1, 1)`.
+//
+// Corner `i` is the unprojection of the NDC point whose X is +1 if bit 0 of
+// `i` is set (and -1 otherwise), whose Y is +1 if bit 1 is set, and which is
+// on the far plane if bit 2 is set (and on the near plane otherwise). In
+// particular, the first four corners are those of the near plane.
+func FrustumCorners(invViewProj Mat4) [8]Vec3 {
+	return FrustumSliceCorners(invViewProj, -1, 1)
+}
+
+// `FrustumSliceCorners` returns the eight corners, in world space, of the part
+// of the view frustum between the NDC depths `near` and `far` (e.g. for the
+// cascades of a shadow map). The corners are in the same order as with
+// `FrustumCorners`.
+func FrustumSliceCorners(invViewProj Mat4, near, far float32) [8]Vec3 {
+	var c [8]Vec3
+	for i := range c {
+		p := Vec4{-1, -1, near, 1}
+		if i&1 != 0 {
+			p.X = 1
+		}
+		if i&2 != 0 {
+			p.Y = 1
+		}
+		if i&4 != 0 {
+			p.Z = far
+		}
+		c[i] = invViewProj.TimesVec4(p).Dehomogenized()
+	}
+	return c
+}
+
+//------------------------------------------------------------------------------
+
 // `Translation` returns a translation matrix.
 //
 // See also `SetToTranslation`.
@@ -333,40 +878,352 @@ func (m *Mat4) SetToTranslation(t Vec3) {
 
 //------------------------------------------------------------------------------
 
-// `Rotation` returns a rotation matrix.
+// `Scaling` returns a matrix scaling by `s.X` along the X axis, `s.Y` along
+// the Y axis and `s.Z` along the Z axis.
+//
+// See also `SetToScaling`.
+func Scaling(s Vec3) Mat4 {
+	return Mat4{
+		{s.X, 0, 0, 0},
+		{0, s.Y, 0, 0},
+		{0, 0, s.Z, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// `SetToScaling` sets `m` to a scaling matrix.
 //
-// See also `SetToRotation`.
+// See also `Scaling`.
+func (m *Mat4) SetToScaling(s Vec3) {
+	m[0][0] = s.X
+	m[0][1] = 0
+	m[0][2] = 0
+	m[0][3] = 0
+
+	m[1][0] = 0
+	m[1][1] = s.Y
+	m[1][2] = 0
+	m[1][3] = 0
+
+	m[2][0] = 0
+	m[2][1] = 0
+	m[2][2] = s.Z
+	m[2][3] = 0
+
+	m[3][0] = 0
+	m[3][1] = 0
+	m[3][2] = 0
+	m[3][3] = 1
+}
+
+//------------------------------------------------------------------------------
+
+// `Translated` returns `m` multiplied on the right by a translation matrix.
+//
+// As with `glTranslate`, the translation is applied in the local space of
+// `m`, i.e. before `m` itself: in `Identity().Translated(p).RotatedY(a)`,
+// points are first rotated, then translated.
+//
+// See also `Translate`.
+func (m Mat4) Translated(t Vec3) Mat4 {
+	m.Translate(t)
+	return m
+}
+
+// `Translate` multiplies `m` on the right by a translation matrix.
+//
+// More efficient than `Translated`.
+func (m *Mat4) Translate(t Vec3) {
+	for r := 0; r < 4; r++ {
+		m[3][r] += m[0][r]*t.X + m[1][r]*t.Y + m[2][r]*t.Z
+	}
+}
+
+// `Scaled` returns `m` multiplied on the right by a scaling matrix (i.e.
+// the scaling is applied before `m`).
+//
+// See also `Scale` and `Translated`.
+func (m Mat4) Scaled(s Vec3) Mat4 {
+	m.Scale(s)
+	return m
+}
+
+// `Scale` multiplies `m` on the right by a scaling matrix.
+//
+// More efficient than `Scaled`.
+func (m *Mat4) Scale(s Vec3) {
+	for r := 0; r < 4; r++ {
+		m[0][r] *= s.X
+		m[1][r] *= s.Y
+		m[2][r] *= s.Z
+	}
+}
+
+// `RotatedX` returns `m` multiplied on the right by a rotation of `angle`
+// around the X axis (i.e. the rotation is applied before `m`).
+//
+// See also `RotateX` and `Translated`.
+func (m Mat4) RotatedX(angle float32) Mat4 {
+	m.RotateX(angle)
+	return m
+}
+
+// `RotateX` multiplies `m` on the right by a rotation of `angle` around the X
+// axis.
+//
+// More efficient than `RotatedX`.
+func (m *Mat4) RotateX(angle float32) {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+	for r := 0; r < 4; r++ {
+		m[1][r], m[2][r] = c*m[1][r]+s*m[2][r], c*m[2][r]-s*m[1][r]
+	}
+}
+
+// `RotatedY` returns `m` multiplied on the right by a rotation of `angle`
+// around the Y axis (i.e. the rotation is applied before `m`).
+//
+// See also `RotateY` and `Translated`.
+func (m Mat4) RotatedY(angle float32) Mat4 {
+	m.RotateY(angle)
+	return m
+}
+
+// `RotateY` multiplies `m` on the right by a rotation of `angle` around the Y
+// axis.
+//
+// More efficient than `RotatedY`.
+func (m *Mat4) RotateY(angle float32) {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+	for r := 0; r < 4; r++ {
+		m[0][r], m[2][r] = c*m[0][r]-s*m[2][r], c*m[2][r]+s*m[0][r]
+	}
+}
+
+// `RotatedZ` returns `m` multiplied on the right by a rotation of `angle`
+// around the Z axis (i.e. the rotation is applied before `m`).
+//
+// See also `RotateZ` and `Translated`.
+func (m Mat4) RotatedZ(angle float32) Mat4 {
+	m.RotateZ(angle)
+	return m
+}
+
+// `RotateZ` multiplies `m` on the right by a rotation of `angle` around the Z
+// axis.
+//
+// More efficient than `RotatedZ`.
+func (m *Mat4) RotateZ(angle float32) {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+	for r := 0; r < 4; r++ {
+		m[0][r], m[1][r] = c*m[0][r]+s*m[1][r], c*m[1][r]-s*m[0][r]
+	}
+}
+
+// `RotatedAxis` returns `m` multiplied on the right by a rotation of `angle`
+// around `axis`, which must be normalized (i.e. the rotation is applied
+// before `m`).
+//
+// See also `RotateAxis` and `Translated`.
+func (m Mat4) RotatedAxis(axis Vec3, angle float32) Mat4 {
+	r := Rotation(angle, axis)
+	return m.Times(&r)
+}
+
+// `RotateAxis` multiplies `m` on the right by a rotation of `angle` around
+// `axis`, which must be normalized.
+//
+// See also `RotatedAxis`.
+func (m *Mat4) RotateAxis(axis Vec3, angle float32) {
+	r := Rotation(angle, axis)
+	*m = m.Times(&r)
+}
+
+//------------------------------------------------------------------------------
+
+// `Shear` returns a shearing matrix. Each amount is named after the
+// coordinate it displaces and the coordinate it is proportional to: `xy` is
+// added to X for each unit of Y, and so on:
+//
+//	x' = x + xy*y + xz*z
+//	y' = y + yx*x + yz*z
+//	z' = z + zx*x + zy*y
+//
+// The six basic shears are obtained by leaving a single amount non-zero; their
+// determinant is 1, so volumes are preserved.
+//
+// See also `SetToShear`, `Mat3Shear` and `Shear2D`.
+func Shear(xy, xz, yx, yz, zx, zy float32) Mat4 {
+	return Mat4{
+		{1, yx, zx, 0},
+		{xy, 1, zy, 0},
+		{xz, yz, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// `SetToShear` sets `m` to a shearing matrix.
+//
+// See also `Shear`.
+func (m *Mat4) SetToShear(xy, xz, yx, yz, zx, zy float32) {
+	m[0][0] = 1
+	m[0][1] = yx
+	m[0][2] = zx
+	m[0][3] = 0
+
+	m[1][0] = xy
+	m[1][1] = 1
+	m[1][2] = zy
+	m[1][3] = 0
+
+	m[2][0] = xz
+	m[2][1] = yz
+	m[2][2] = 1
+	m[2][3] = 0
+
+	m[3][0] = 0
+	m[3][1] = 0
+	m[3][2] = 0
+	m[3][3] = 1
+}
+
+//------------------------------------------------------------------------------
+
+// `Reflection` returns a matrix mirroring points across the plane of equation
+// `normal.Dot(p) + d = 0`. `normal` must be normalized.
+//
+// The result has a determinant of -1 (so triangle winding must be flipped
+// when rendering with it), and is its own inverse.
+//
+// See also `SetToReflection`.
+func Reflection(normal Vec3, d float32) Mat4 {
+	var m Mat4
+	m.SetToReflection(normal, d)
+	return m
+}
+
+// `SetToReflection` sets `m` to a matrix mirroring points across the plane of
+// equation `normal.Dot(p) + d = 0`. `normal` must be normalized.
+//
+// See also `Reflection`.
+func (m *Mat4) SetToReflection(normal Vec3, d float32) {
+	x, y, z := normal.X, normal.Y, normal.Z
+
+	m[0][0] = 1 - 2*x*x
+	m[0][1] = -2 * x * y
+	m[0][2] = -2 * x * z
+	m[0][3] = 0
+
+	m[1][0] = -2 * y * x
+	m[1][1] = 1 - 2*y*y
+	m[1][2] = -2 * y * z
+	m[1][3] = 0
+
+	m[2][0] = -2 * z * x
+	m[2][1] = -2 * z * y
+	m[2][2] = 1 - 2*z*z
+	m[2][3] = 0
+
+	m[3][0] = -2 * d * x
+	m[3][1] = -2 * d * y
+	m[3][2] = -2 * d * z
+	m[3][3] = 1
+}
+
+//------------------------------------------------------------------------------
+
+// `TRS` returns the matrix that scales by `scale`, then rotates by `rotation`
+// (which must be normalized), then translates by `translation`. This is
+// the same as the product T * R * S, but computed in one pass.
+//
+// See also `SetToTRS` and `DecomposeTRS`.
+func TRS(translation Vec3, rotation Quat, scale Vec3) Mat4 {
+	var m Mat4
+	m.SetToTRS(translation, rotation, scale)
+	return m
+}
+
+// `SetToTRS` sets `m` to the matrix that scales by `scale`, then rotates by
+// `rotation` (which must be normalized), then translates by `translation`.
+//
+// See also `TRS` and `DecomposeTRS`.
+func (m *Mat4) SetToTRS(translation Vec3, rotation Quat, scale Vec3) {
+	q := rotation
+	xx, yy, zz := q.X*q.X, q.Y*q.Y, q.Z*q.Z
+	xy, xz, yz := q.X*q.Y, q.X*q.Z, q.Y*q.Z
+	wx, wy, wz := q.W*q.X, q.W*q.Y, q.W*q.Z
+
+	m[0][0] = (1 - 2*(yy+zz)) * scale.X
+	m[0][1] = 2 * (xy + wz) * scale.X
+	m[0][2] = 2 * (xz - wy) * scale.X
+	m[0][3] = 0
+
+	m[1][0] = 2 * (xy - wz) * scale.Y
+	m[1][1] = (1 - 2*(xx+zz)) * scale.Y
+	m[1][2] = 2 * (yz + wx) * scale.Y
+	m[1][3] = 0
+
+	m[2][0] = 2 * (xz + wy) * scale.Z
+	m[2][1] = 2 * (yz - wx) * scale.Z
+	m[2][2] = (1 - 2*(xx+yy)) * scale.Z
+	m[2][3] = 0
+
+	m[3][0] = translation.X
+	m[3][1] = translation.Y
+	m[3][2] = translation.Z
+	m[3][3] = 1
+}
+
+//------------------------------------------------------------------------------
+
+// `Rotation` returns a matrix rotating by `angle` around `axis` (using
+// Rodrigues' formula). `axis` must be normalized. A zero `angle` gives exactly
+// the identity.
+//
+// Note: earlier versions rotated by `-angle`; see CHANGELOG.md for migration.
+//
+// See also `RotationAxis`, `SetToRotation` and `Mat3RotationAxis`.
 func Rotation(angle float32, axis Vec3) Mat4 {
 	c := math.Cos(angle)
 	s := math.Sin(angle)
 
 	return Mat4{
-		{c + axis.X*axis.X*(1-c), -axis.Z*s + axis.X*axis.Y*(1-c), axis.Y*s + axis.X*axis.Z*(1-c), 0},
-		{axis.Z*s + axis.Y*axis.X*(1-c), c + axis.Y*axis.Y*(1-c), -axis.X*s + axis.Y*axis.Z*(1-c), 0},
-		{-axis.Y*s + axis.Z*axis.X*(1-c), axis.X*s + axis.Z*axis.Y*(1-c), c + axis.Z*axis.Z*(1-c), 0},
+		{c + axis.X*axis.X*(1-c), axis.Z*s + axis.X*axis.Y*(1-c), -axis.Y*s + axis.X*axis.Z*(1-c), 0},
+		{-axis.Z*s + axis.Y*axis.X*(1-c), c + axis.Y*axis.Y*(1-c), axis.X*s + axis.Y*axis.Z*(1-c), 0},
+		{axis.Y*s + axis.Z*axis.X*(1-c), -axis.X*s + axis.Z*axis.Y*(1-c), c + axis.Z*axis.Z*(1-c), 0},
 		{0, 0, 0, 1},
 	}
 }
 
+// `RotationAxis` is the same as `Rotation`, but takes its arguments in the
+// same order as `Mat3RotationAxis` and `Vec3.RotateAxis`.
+func RotationAxis(axis Vec3, angle float32) Mat4 {
+	return Rotation(angle, axis)
+}
+
 // `SetToRotation` sets `m` to a rotation matrix.
 //
+// Note: earlier versions rotated by `-angle`; see CHANGELOG.md for migration.
+//
 // See also `Rotation`.
 func (m *Mat4) Rotation(angle float32, axis Vec3) {
 	c := math.Cos(angle)
 	s := math.Sin(angle)
 
 	m[0][0] = c + axis.X*axis.X*(1-c)
-	m[0][1] = -axis.Z*s + axis.X*axis.Y*(1-c)
-	m[0][2] = axis.Y*s + axis.X*axis.Z*(1-c)
+	m[0][1] = axis.Z*s + axis.X*axis.Y*(1-c)
+	m[0][2] = -axis.Y*s + axis.X*axis.Z*(1-c)
 	m[0][3] = 0
 
-	m[1][0] = axis.Z*s + axis.Y*axis.X*(1-c)
+	m[1][0] = -axis.Z*s + axis.Y*axis.X*(1-c)
 	m[1][1] = c + axis.Y*axis.Y*(1-c)
-	m[1][2] = -axis.X*s + axis.Y*axis.Z*(1-c)
+	m[1][2] = axis.X*s + axis.Y*axis.Z*(1-c)
 	m[1][3] = 0
 
-	m[2][0] = -axis.Y*s + axis.Z*axis.X*(1-c)
-	m[2][1] = axis.X*s + axis.Z*axis.Y*(1-c)
+	m[2][0] = axis.Y*s + axis.Z*axis.X*(1-c)
+	m[2][1] = -axis.X*s + axis.Z*axis.Y*(1-c)
 	m[2][2] = c + axis.Z*axis.Z*(1-c)
 	m[2][3] = 0
 
@@ -432,78 +1289,628 @@ build_rotmatrix(float m[4][4], float q[4])
 
 //------------------------------------------------------------------------------
 
+// `Transposed` returns the transpose of `m`.
+//
+// See also `Transpose`.
+func (m Mat4) Transposed() Mat4 {
+	return Mat4{
+		{m[0][0], m[1][0], m[2][0], m[3][0]},
+		{m[0][1], m[1][1], m[2][1], m[3][1]},
+		{m[0][2], m[1][2], m[2][2], m[3][2]},
+		{m[0][3], m[1][3], m[2][3], m[3][3]},
+	}
+}
+
+// `Transpose` sets `m` to its transpose.
+//
+// More efficient than `Transposed`.
+func (m *Mat4) Transpose() {
+	m[0][1], m[1][0] = m[1][0], m[0][1]
+	m[0][2], m[2][0] = m[2][0], m[0][2]
+	m[0][3], m[3][0] = m[3][0], m[0][3]
+	m[1][2], m[2][1] = m[2][1], m[1][2]
+	m[1][3], m[3][1] = m[3][1], m[1][3]
+	m[2][3], m[3][2] = m[3][2], m[2][3]
+}
+
+//------------------------------------------------------------------------------
+
 // `Times` returns the matrix product of `m` and `o`.
 //
-// See also `Multiply`, `TimesVec` and `MultiplyVec`.
+// The result transforms a vector by `o` first, then by `m`.
+//
+// Note: earlier versions returned `o*m`; see CHANGELOG.md for migration.
+//
+// See also `Multiply` and `TimesVec4`.
 func (m *Mat4) Times(o *Mat4) Mat4 {
 	return Mat4{
 		{
-			m[0][0]*o[0][0] + m[0][1]*o[1][0] + m[0][2]*o[2][0] + m[0][3]*o[3][0],
-			m[0][0]*o[0][1] + m[0][1]*o[1][1] + m[0][2]*o[2][1] + m[0][3]*o[3][1],
-			m[0][0]*o[0][2] + m[0][1]*o[1][2] + m[0][2]*o[2][2] + m[0][3]*o[3][2],
-			m[0][0]*o[0][3] + m[0][1]*o[1][3] + m[0][2]*o[2][3] + m[0][3]*o[3][3],
+			m[0][0]*o[0][0] + m[1][0]*o[0][1] + m[2][0]*o[0][2] + m[3][0]*o[0][3],
+			m[0][1]*o[0][0] + m[1][1]*o[0][1] + m[2][1]*o[0][2] + m[3][1]*o[0][3],
+			m[0][2]*o[0][0] + m[1][2]*o[0][1] + m[2][2]*o[0][2] + m[3][2]*o[0][3],
+			m[0][3]*o[0][0] + m[1][3]*o[0][1] + m[2][3]*o[0][2] + m[3][3]*o[0][3],
 		},
 		{
-			m[1][0]*o[0][0] + m[1][1]*o[1][0] + m[1][2]*o[2][0] + m[1][3]*o[3][0],
-			m[1][0]*o[0][1] + m[1][1]*o[1][1] + m[1][2]*o[2][1] + m[1][3]*o[3][1],
-			m[1][0]*o[0][2] + m[1][1]*o[1][2] + m[1][2]*o[2][2] + m[1][3]*o[3][2],
-			m[1][0]*o[0][3] + m[1][1]*o[1][3] + m[1][2]*o[2][3] + m[1][3]*o[3][3],
+			m[0][0]*o[1][0] + m[1][0]*o[1][1] + m[2][0]*o[1][2] + m[3][0]*o[1][3],
+			m[0][1]*o[1][0] + m[1][1]*o[1][1] + m[2][1]*o[1][2] + m[3][1]*o[1][3],
+			m[0][2]*o[1][0] + m[1][2]*o[1][1] + m[2][2]*o[1][2] + m[3][2]*o[1][3],
+			m[0][3]*o[1][0] + m[1][3]*o[1][1] + m[2][3]*o[1][2] + m[3][3]*o[1][3],
 		},
 		{
-			m[2][0]*o[0][0] + m[2][1]*o[1][0] + m[2][2]*o[2][0] + m[2][3]*o[3][0],
-			m[2][0]*o[0][1] + m[2][1]*o[1][1] + m[2][2]*o[2][1] + m[2][3]*o[3][1],
-			m[2][0]*o[0][2] + m[2][1]*o[1][2] + m[2][2]*o[2][2] + m[2][3]*o[3][2],
-			m[2][0]*o[0][3] + m[2][1]*o[1][3] + m[2][2]*o[2][3] + m[2][3]*o[3][3],
+			m[0][0]*o[2][0] + m[1][0]*o[2][1] + m[2][0]*o[2][2] + m[3][0]*o[2][3],
+			m[0][1]*o[2][0] + m[1][1]*o[2][1] + m[2][1]*o[2][2] + m[3][1]*o[2][3],
+			m[0][2]*o[2][0] + m[1][2]*o[2][1] + m[2][2]*o[2][2] + m[3][2]*o[2][3],
+			m[0][3]*o[2][0] + m[1][3]*o[2][1] + m[2][3]*o[2][2] + m[3][3]*o[2][3],
 		},
 		{
-			m[3][0]*o[0][0] + m[3][1]*o[1][0] + m[3][2]*o[2][0] + m[3][3]*o[3][0],
-			m[3][0]*o[0][1] + m[3][1]*o[1][1] + m[3][2]*o[2][1] + m[3][3]*o[3][1],
-			m[3][0]*o[0][2] + m[3][1]*o[1][2] + m[3][2]*o[2][2] + m[3][3]*o[3][2],
-			m[3][0]*o[0][3] + m[3][1]*o[1][3] + m[3][2]*o[2][3] + m[3][3]*o[3][3],
+			m[0][0]*o[3][0] + m[1][0]*o[3][1] + m[2][0]*o[3][2] + m[3][0]*o[3][3],
+			m[0][1]*o[3][0] + m[1][1]*o[3][1] + m[2][1]*o[3][2] + m[3][1]*o[3][3],
+			m[0][2]*o[3][0] + m[1][2]*o[3][1] + m[2][2]*o[3][2] + m[3][2]*o[3][3],
+			m[0][3]*o[3][0] + m[1][3]*o[3][1] + m[2][3]*o[3][2] + m[3][3]*o[3][3],
 		},
 	}
 }
 
-// `Multiply` sets `r` to the matrix product of `m` and `o`.
+// `Multiply` sets `r` to the matrix product of `m` and `o`, i.e. the same
+// result as `m.Times(o)`.
 //
 // `r` must not be `m` or `o`.
 //
-// See also `Multiply`, `TimesVec` and `MultiplyVec`.
+// Note: earlier versions computed `o*m`; see CHANGELOG.md for migration.
+//
+// See also `Times` and `TimesVec4`.
 func (r *Mat4) Multiply(m, o *Mat4) {
-	r[0][0] = m[0][0]*o[0][0] + m[0][1]*o[1][0] + m[0][2]*o[2][0] + m[0][3]*o[3][0]
-	r[0][1] = m[0][0]*o[0][1] + m[0][1]*o[1][1] + m[0][2]*o[2][1] + m[0][3]*o[3][1]
-	r[0][2] = m[0][0]*o[0][2] + m[0][1]*o[1][2] + m[0][2]*o[2][2] + m[0][3]*o[3][2]
-	r[0][3] = m[0][0]*o[0][3] + m[0][1]*o[1][3] + m[0][2]*o[2][3] + m[0][3]*o[3][3]
+	r[0][0] = m[0][0]*o[0][0] + m[1][0]*o[0][1] + m[2][0]*o[0][2] + m[3][0]*o[0][3]
+	r[0][1] = m[0][1]*o[0][0] + m[1][1]*o[0][1] + m[2][1]*o[0][2] + m[3][1]*o[0][3]
+	r[0][2] = m[0][2]*o[0][0] + m[1][2]*o[0][1] + m[2][2]*o[0][2] + m[3][2]*o[0][3]
+	r[0][3] = m[0][3]*o[0][0] + m[1][3]*o[0][1] + m[2][3]*o[0][2] + m[3][3]*o[0][3]
+
+	r[1][0] = m[0][0]*o[1][0] + m[1][0]*o[1][1] + m[2][0]*o[1][2] + m[3][0]*o[1][3]
+	r[1][1] = m[0][1]*o[1][0] + m[1][1]*o[1][1] + m[2][1]*o[1][2] + m[3][1]*o[1][3]
+	r[1][2] = m[0][2]*o[1][0] + m[1][2]*o[1][1] + m[2][2]*o[1][2] + m[3][2]*o[1][3]
+	r[1][3] = m[0][3]*o[1][0] + m[1][3]*o[1][1] + m[2][3]*o[1][2] + m[3][3]*o[1][3]
+
+	r[2][0] = m[0][0]*o[2][0] + m[1][0]*o[2][1] + m[2][0]*o[2][2] + m[3][0]*o[2][3]
+	r[2][1] = m[0][1]*o[2][0] + m[1][1]*o[2][1] + m[2][1]*o[2][2] + m[3][1]*o[2][3]
+	r[2][2] = m[0][2]*o[2][0] + m[1][2]*o[2][1] + m[2][2]*o[2][2] + m[3][2]*o[2][3]
+	r[2][3] = m[0][3]*o[2][0] + m[1][3]*o[2][1] + m[2][3]*o[2][2] + m[3][3]*o[2][3]
+
+	r[3][0] = m[0][0]*o[3][0] + m[1][0]*o[3][1] + m[2][0]*o[3][2] + m[3][0]*o[3][3]
+	r[3][1] = m[0][1]*o[3][0] + m[1][1]*o[3][1] + m[2][1]*o[3][2] + m[3][1]*o[3][3]
+	r[3][2] = m[0][2]*o[3][0] + m[1][2]*o[3][1] + m[2][2]*o[3][2] + m[3][2]*o[3][3]
+	r[3][3] = m[0][3]*o[3][0] + m[1][3]*o[3][1] + m[2][3]*o[3][2] + m[3][3]*o[3][3]
+}
 
-	r[1][0] = m[1][0]*o[0][0] + m[1][1]*o[1][0] + m[1][2]*o[2][0] + m[1][3]*o[3][0]
-	r[1][1] = m[1][0]*o[0][1] + m[1][1]*o[1][1] + m[1][2]*o[2][1] + m[1][3]*o[3][1]
-	r[1][2] = m[1][0]*o[0][2] + m[1][1]*o[1][2] + m[1][2]*o[2][2] + m[1][3]*o[3][2]
-	r[1][3] = m[1][0]*o[0][3] + m[1][1]*o[1][3] + m[1][2]*o[2][3] + m[1][3]*o[3][3]
+// `MulInto` sets `r` to the matrix product of `a` and `b`.
+//
+// Unlike `Multiply`, `r` may be `a` or `b` (or both), since the product is
+// entirely computed before being stored. It does not allocate.
+//
+// See also `Times`.
+func (r *Mat4) MulInto(a, b *Mat4) {
+	*r = a.Times(b)
+}
+
+// `TimesVec4` returns the product of `m` with the column vector `v`.
+func (m *Mat4) TimesVec4(v Vec4) Vec4 {
+	return Vec4{
+		m[0][0]*v.X + m[1][0]*v.Y + m[2][0]*v.Z + m[3][0]*v.W,
+		m[0][1]*v.X + m[1][1]*v.Y + m[2][1]*v.Z + m[3][1]*v.W,
+		m[0][2]*v.X + m[1][2]*v.Y + m[2][2]*v.Z + m[3][2]*v.W,
+		m[0][3]*v.X + m[1][3]*v.Y + m[2][3]*v.Z + m[3][3]*v.W,
+	}
+}
 
-	r[2][0] = m[2][0]*o[0][0] + m[2][1]*o[1][0] + m[2][2]*o[2][0] + m[2][3]*o[3][0]
-	r[2][1] = m[2][0]*o[0][1] + m[2][1]*o[1][1] + m[2][2]*o[2][1] + m[2][3]*o[3][1]
-	r[2][2] = m[2][0]*o[0][2] + m[2][1]*o[1][2] + m[2][2]*o[2][2] + m[2][3]*o[3][2]
-	r[2][3] = m[2][0]*o[0][3] + m[2][1]*o[1][3] + m[2][2]*o[2][3] + m[2][3]*o[3][3]
+// `TransformVec4Into` sets `dst` to the product of `m` with the column vector
+// `v`. `dst` may be `v`.
+//
+// See also `TimesVec4`.
+func (m *Mat4) TransformVec4Into(dst, v *Vec4) {
+	x, y, z, w := v.X, v.Y, v.Z, v.W
+	dst.X = m[0][0]*x + m[1][0]*y + m[2][0]*z + m[3][0]*w
+	dst.Y = m[0][1]*x + m[1][1]*y + m[2][1]*z + m[3][1]*w
+	dst.Z = m[0][2]*x + m[1][2]*y + m[2][2]*z + m[3][2]*w
+	dst.W = m[0][3]*x + m[1][3]*y + m[2][3]*z + m[3][3]*w
+}
 
-	r[3][0] = m[3][0]*o[0][0] + m[3][1]*o[1][0] + m[3][2]*o[2][0] + m[3][3]*o[3][0]
-	r[3][1] = m[3][0]*o[0][1] + m[3][1]*o[1][1] + m[3][2]*o[2][1] + m[3][3]*o[3][1]
-	r[3][2] = m[3][0]*o[0][2] + m[3][1]*o[1][2] + m[3][2]*o[2][2] + m[3][3]*o[3][2]
-	r[3][3] = m[3][0]*o[0][3] + m[3][1]*o[1][3] + m[3][2]*o[2][3] + m[3][3]*o[3][3]
+// `TransformPoint` returns the point `p` transformed by `m`: `p` is
+// homogenized with W=1, multiplied by `m`, and the result is dehomogenized,
+// i.e. divided by its W. The division is skipped when W is 1, which is the
+// case for affine matrices; for projective matrices, W must not be 0.
+//
+// This is the same as `m.TimesVec4(p.Homogenized()).Dehomogenized()`.
+//
+// See also `TransformDirection` and `TransformPoints`.
+func (m *Mat4) TransformPoint(p Vec3) Vec3 {
+	r := Vec3{
+		m[0][0]*p.X + m[1][0]*p.Y + m[2][0]*p.Z + m[3][0],
+		m[0][1]*p.X + m[1][1]*p.Y + m[2][1]*p.Z + m[3][1],
+		m[0][2]*p.X + m[1][2]*p.Y + m[2][2]*p.Z + m[3][2],
+	}
+	w := m[0][3]*p.X + m[1][3]*p.Y + m[2][3]*p.Z + m[3][3]
+	if w != 1 {
+		r = r.Slash(w)
+	}
+	return r
+}
+
+// `TransformDirection` returns the direction `d` transformed by `m`: `d` is
+// homogenized with W=0, so the translation does not apply, and there is no
+// division. The projection part of `m` (its bottom row) is ignored.
+//
+// See also `TransformPoint` and `TransformDirections`.
+func (m *Mat4) TransformDirection(d Vec3) Vec3 {
+	return Vec3{
+		m[0][0]*d.X + m[1][0]*d.Y + m[2][0]*d.Z,
+		m[0][1]*d.X + m[1][1]*d.Y + m[2][1]*d.Z,
+		m[0][2]*d.X + m[1][2]*d.Y + m[2][2]*d.Z,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `TransformPoints` sets each `dst[i]` to the point `src[i]` transformed by
+// `m` (i.e. with the translation applied). The bottom row of `m` is ignored,
+// so it must be an affine transformation; use `TransformVec4s` for
+// projections.
+//
+// `dst` and `src` must have the same length, otherwise nothing is written
+// and an error is returned. They may be the same slice, for in-place
+// transformation.
+//
+// See also `TransformDirections`.
+func (m *Mat4) TransformPoints(dst, src []Vec3) error {
+	if err := checkTransformLengths("TransformPoints", len(dst), len(src)); err != nil {
+		return err
+	}
+	m00, m01, m02 := m[0][0], m[0][1], m[0][2]
+	m10, m11, m12 := m[1][0], m[1][1], m[1][2]
+	m20, m21, m22 := m[2][0], m[2][1], m[2][2]
+	m30, m31, m32 := m[3][0], m[3][1], m[3][2]
+	for i, v := range src {
+		dst[i] = Vec3{
+			m00*v.X + m10*v.Y + m20*v.Z + m30,
+			m01*v.X + m11*v.Y + m21*v.Z + m31,
+			m02*v.X + m12*v.Y + m22*v.Z + m32,
+		}
+	}
+	return nil
+}
+
+// `parallelThreshold` is the number of points per worker below which
+// `TransformPointsParallel` does not start goroutines.
+const parallelThreshold = 16 * 1024
+
+// `TransformPointsParallel` is the same as `TransformPoints`, but splits the
+// work between `workers` goroutines (or `runtime.GOMAXPROCS` if `workers` is
+// less than or equal to 0). The results are identical.
+//
+// Fewer goroutines are used for small slices, so that each one has at least a
+// few thousand points to transform; in particular, small slices are
+// transformed in the calling goroutine.
+//
+// As with `TransformPoints`, an error is returned (and nothing is written) if
+// `dst` and `src` have different lengths.
+func (m *Mat4) TransformPointsParallel(dst, src []Vec3, workers int) error {
+	if err := checkTransformLengths("TransformPointsParallel", len(dst), len(src)); err != nil {
+		return err
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if n := len(src) / parallelThreshold; n < workers {
+		workers = n
+	}
+	if workers <= 1 {
+		return m.TransformPoints(dst, src)
+	}
+	var wg sync.WaitGroup
+	size := (len(src) + workers - 1) / workers
+	for start := 0; start < len(src); start += size {
+		end := start + size
+		if end > len(src) {
+			end = len(src)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			m.TransformPoints(dst[start:end], src[start:end])
+		}(start, end)
+	}
+	wg.Wait()
+	return nil
+}
+
+// `TransformDirections` sets each `dst[i]` to the direction `src[i]`
+// transformed by the upper-left 3x3 part of `m` (i.e. ignoring the
+// translation).
+//
+// `dst` and `src` must have the same length, otherwise nothing is written
+// and an error is returned. They may be the same slice, for in-place
+// transformation.
+//
+// See also `TransformPoints`.
+func (m *Mat4) TransformDirections(dst, src []Vec3) error {
+	if err := checkTransformLengths("TransformDirections", len(dst), len(src)); err != nil {
+		return err
+	}
+	m00, m01, m02 := m[0][0], m[0][1], m[0][2]
+	m10, m11, m12 := m[1][0], m[1][1], m[1][2]
+	m20, m21, m22 := m[2][0], m[2][1], m[2][2]
+	for i, v := range src {
+		dst[i] = Vec3{
+			m00*v.X + m10*v.Y + m20*v.Z,
+			m01*v.X + m11*v.Y + m21*v.Z,
+			m02*v.X + m12*v.Y + m22*v.Z,
+		}
+	}
+	return nil
+}
+
+// `TransformVec4s` sets each `dst[i]` to the product of `m` with the column
+// vector `src[i]`.
+//
+// `dst` and `src` must have the same length, otherwise nothing is written
+// and an error is returned. They may be the same slice, for in-place
+// transformation.
+//
+// See also `TimesVec4`.
+func (m *Mat4) TransformVec4s(dst, src []Vec4) error {
+	if err := checkTransformLengths("TransformVec4s", len(dst), len(src)); err != nil {
+		return err
+	}
+	m00, m01, m02, m03 := m[0][0], m[0][1], m[0][2], m[0][3]
+	m10, m11, m12, m13 := m[1][0], m[1][1], m[1][2], m[1][3]
+	m20, m21, m22, m23 := m[2][0], m[2][1], m[2][2], m[2][3]
+	m30, m31, m32, m33 := m[3][0], m[3][1], m[3][2], m[3][3]
+	for i, v := range src {
+		dst[i] = Vec4{
+			m00*v.X + m10*v.Y + m20*v.Z + m30*v.W,
+			m01*v.X + m11*v.Y + m21*v.Z + m31*v.W,
+			m02*v.X + m12*v.Y + m22*v.Z + m32*v.W,
+			m03*v.X + m13*v.Y + m23*v.Z + m33*v.W,
+		}
+	}
+	return nil
+}
+
+func checkTransformLengths(method string, dst, src int) error {
+	if dst != src {
+		return fmt.Errorf("glam.Mat4.%s: %d destinations for %d sources", method, dst, src)
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// `Determinant` returns the determinant of `m`.
+//
+// The expansion is accumulated in double precision, so that the sign of the
+// result is reliable even for nearly singular matrices (e.g. to detect
+// mirroring transforms).
+func (m Mat4) Determinant() float32 {
+	var a [4][4]float64
+	for c := range m {
+		for r := range m[c] {
+			a[c][r] = float64(m[c][r])
+		}
+	}
+
+	s0 := a[0][0]*a[1][1] - a[1][0]*a[0][1]
+	s1 := a[0][0]*a[1][2] - a[1][0]*a[0][2]
+	s2 := a[0][0]*a[1][3] - a[1][0]*a[0][3]
+	s3 := a[0][1]*a[1][2] - a[1][1]*a[0][2]
+	s4 := a[0][1]*a[1][3] - a[1][1]*a[0][3]
+	s5 := a[0][2]*a[1][3] - a[1][2]*a[0][3]
+
+	c5 := a[2][2]*a[3][3] - a[3][2]*a[2][3]
+	c4 := a[2][1]*a[3][3] - a[3][1]*a[2][3]
+	c3 := a[2][1]*a[3][2] - a[3][1]*a[2][2]
+	c2 := a[2][0]*a[3][3] - a[3][0]*a[2][3]
+	c1 := a[2][0]*a[3][2] - a[3][0]*a[2][2]
+	c0 := a[2][0]*a[3][1] - a[3][0]*a[2][1]
+
+	return float32(s0*c5 - s1*c4 + s2*c3 + s3*c2 - s4*c1 + s5*c0)
+}
+
+// `Trace` returns the sum of the elements on the main diagonal of `m`.
+func (m Mat4) Trace() float32 {
+	return m[0][0] + m[1][1] + m[2][2] + m[3][3]
+}
+
+// `IsIdentity` returns true if `m` is the identity, with an absolute tolerance
+// of `epsilon` on each element.
+func (m Mat4) IsIdentity(epsilon float32) bool {
+	return m.RoughlyEqual(Identity(), epsilon)
+}
+
+// `IsAffine` returns true if `m` has no projection, i.e. if its bottom row is
+// (0, 0, 0, 1), with an absolute tolerance of `epsilon` on each element.
+//
+// See also `Affine`.
+func (m Mat4) IsAffine(epsilon float32) bool {
+	return Vec4{m[0][3], m[1][3], m[2][3], m[3][3]}.RoughlyEqual(Vec4{0, 0, 0, 1}, epsilon)
+}
+
+// `IsRigid` returns true if `m` is made of a rotation and a translation only
+// (i.e. it is affine and its upper-left part is rigid), with a tolerance of
+// `epsilon`.
+//
+// See also `Mat3.IsRigid`.
+func (m Mat4) IsRigid(epsilon float32) bool {
+	return m.IsAffine(epsilon) && m.Mat3().IsRigid(epsilon)
+}
+
+// `FrobeniusNorm` returns the square root of the sum of the squares of all
+// elements of `m`.
+//
+// For example, the norm of `m.Transposed().Times(&m)` minus the identity
+// measures how far `m` has drifted from being orthonormal.
+func (m Mat4) FrobeniusNorm() float32 {
+	var s float32
+	for c := range m {
+		for r := range m[c] {
+			s += m[c][r] * m[c][r]
+		}
+	}
+	return math.Sqrt(s)
+}
+
+// `Inverse` returns the inverse of `m`, and true; or, if `m` is singular (or
+// too close to be inverted in single precision), the zero matrix and false.
+func (m Mat4) Inverse() (Mat4, bool) {
+	// Source: "The Laplace Expansion Theorem: Computing the Determinants and
+	// Inverses of Matrices" by David Eberly. Since the inverse of the
+	// transpose is the transpose of the inverse, the formulas do not depend
+	// on the storage order.
+
+	s0 := m[0][0]*m[1][1] - m[1][0]*m[0][1]
+	s1 := m[0][0]*m[1][2] - m[1][0]*m[0][2]
+	s2 := m[0][0]*m[1][3] - m[1][0]*m[0][3]
+	s3 := m[0][1]*m[1][2] - m[1][1]*m[0][2]
+	s4 := m[0][1]*m[1][3] - m[1][1]*m[0][3]
+	s5 := m[0][2]*m[1][3] - m[1][2]*m[0][3]
+
+	c5 := m[2][2]*m[3][3] - m[3][2]*m[2][3]
+	c4 := m[2][1]*m[3][3] - m[3][1]*m[2][3]
+	c3 := m[2][1]*m[3][2] - m[3][1]*m[2][2]
+	c2 := m[2][0]*m[3][3] - m[3][0]*m[2][3]
+	c1 := m[2][0]*m[3][2] - m[3][0]*m[2][2]
+	c0 := m[2][0]*m[3][1] - m[3][0]*m[2][1]
+
+	det := s0*c5 - s1*c4 + s2*c3 + s3*c2 - s4*c1 + s5*c0
+	if math.Abs(det) <= singularEpsilon*m.columnLengthsProduct() {
+		return Mat4{}, false
+	}
+
+	inv := 1 / det
+	return Mat4{
+		{
+			(m[1][1]*c5 - m[1][2]*c4 + m[1][3]*c3) * inv,
+			(-m[0][1]*c5 + m[0][2]*c4 - m[0][3]*c3) * inv,
+			(m[3][1]*s5 - m[3][2]*s4 + m[3][3]*s3) * inv,
+			(-m[2][1]*s5 + m[2][2]*s4 - m[2][3]*s3) * inv,
+		},
+		{
+			(-m[1][0]*c5 + m[1][2]*c2 - m[1][3]*c1) * inv,
+			(m[0][0]*c5 - m[0][2]*c2 + m[0][3]*c1) * inv,
+			(-m[3][0]*s5 + m[3][2]*s2 - m[3][3]*s1) * inv,
+			(m[2][0]*s5 - m[2][2]*s2 + m[2][3]*s1) * inv,
+		},
+		{
+			(m[1][0]*c4 - m[1][1]*c2 + m[1][3]*c0) * inv,
+			(-m[0][0]*c4 + m[0][1]*c2 - m[0][3]*c0) * inv,
+			(m[3][0]*s4 - m[3][1]*s2 + m[3][3]*s0) * inv,
+			(-m[2][0]*s4 + m[2][1]*s2 - m[2][3]*s0) * inv,
+		},
+		{
+			(-m[1][0]*c3 + m[1][1]*c1 - m[1][2]*c0) * inv,
+			(m[0][0]*c3 - m[0][1]*c1 + m[0][2]*c0) * inv,
+			(-m[3][0]*s3 + m[3][1]*s1 - m[3][2]*s0) * inv,
+			(m[2][0]*s3 - m[2][1]*s1 + m[2][2]*s0) * inv,
+		},
+	}, true
+}
+
+// `InverseAffine` returns the inverse of `m`, and true; or, if the upper-left
+// 3x3 part of `m` is singular (see `Mat3.Inverse`), the zero matrix and
+// false.
+//
+// `m` must be affine (its bottom row must be (0, 0, 0, 1), see `IsAffine`):
+// the bottom row is not read, and the bottom row of the result is always
+// (0, 0, 0, 1). Only the 3x3 part is inverted, so this is much faster than
+// `Inverse`.
+func (m Mat4) InverseAffine() (Mat4, bool) {
+	// Cofactors of the 3x3 part, stored in transposed position (the adjugate)
+	a00 := m[1][1]*m[2][2] - m[2][1]*m[1][2]
+	a01 := m[2][1]*m[0][2] - m[0][1]*m[2][2]
+	a02 := m[0][1]*m[1][2] - m[1][1]*m[0][2]
+	a10 := m[2][0]*m[1][2] - m[1][0]*m[2][2]
+	a11 := m[0][0]*m[2][2] - m[2][0]*m[0][2]
+	a12 := m[1][0]*m[0][2] - m[0][0]*m[1][2]
+	a20 := m[1][0]*m[2][1] - m[2][0]*m[1][1]
+	a21 := m[2][0]*m[0][1] - m[0][0]*m[2][1]
+	a22 := m[0][0]*m[1][1] - m[1][0]*m[0][1]
+
+	// Same criterion as Mat3.Inverse, squared to avoid Abs and Sqrt
+	det := m[0][0]*a00 + m[1][0]*a01 + m[2][0]*a02
+	p := (m[0][0]*m[0][0] + m[0][1]*m[0][1] + m[0][2]*m[0][2]) *
+		(m[1][0]*m[1][0] + m[1][1]*m[1][1] + m[1][2]*m[1][2]) *
+		(m[2][0]*m[2][0] + m[2][1]*m[2][1] + m[2][2]*m[2][2])
+	if det*det <= singularEpsilon*singularEpsilon*p {
+		return Mat4{}, false
+	}
+
+	d := 1 / det
+	a00, a01, a02 = a00*d, a01*d, a02*d
+	a10, a11, a12 = a10*d, a11*d, a12*d
+	a20, a21, a22 = a20*d, a21*d, a22*d
+	tx, ty, tz := m[3][0], m[3][1], m[3][2]
+
+	return Mat4{
+		{a00, a01, a02, 0},
+		{a10, a11, a12, 0},
+		{a20, a21, a22, 0},
+		{
+			-(a00*tx + a10*ty + a20*tz),
+			-(a01*tx + a11*ty + a21*tz),
+			-(a02*tx + a12*ty + a22*tz),
+			1,
+		},
+	}, true
+}
+
+// `InverseRigid` returns the inverse of `m`, which must be made of a rotation
+// and a translation only (see `IsRigid`).
+//
+// Neither assumption is checked: the upper-left 3x3 part is simply transposed,
+// and the bottom row is not read. If `m` contains a scale, a shear or a
+// projection, the result is wrong (use `InverseAffine` or `Inverse` instead).
+// This is the fastest of the three, and cannot fail.
+func (m Mat4) InverseRigid() Mat4 {
+	tx, ty, tz := m[3][0], m[3][1], m[3][2]
+	return Mat4{
+		{m[0][0], m[1][0], m[2][0], 0},
+		{m[0][1], m[1][1], m[2][1], 0},
+		{m[0][2], m[1][2], m[2][2], 0},
+		{
+			-(m[0][0]*tx + m[0][1]*ty + m[0][2]*tz),
+			-(m[1][0]*tx + m[1][1]*ty + m[1][2]*tz),
+			-(m[2][0]*tx + m[2][1]*ty + m[2][2]*tz),
+			1,
+		},
+	}
+}
+
+// `NormalMatrix` returns the matrix used to transform normals: the
+// inverse-transpose of the upper-left 3x3 part of `m`. Unlike the upper-left
+// part itself, it keeps normals perpendicular to the surface when `m`
+// contains a non-uniform scaling.
+//
+// The boolean is false if the upper-left part is singular, in which case the
+// zero matrix is returned.
+func (m Mat4) NormalMatrix() (Mat3, bool) {
+	inv, ok := m.Mat3().Inverse()
+	if !ok {
+		return Mat3{}, false
+	}
+	inv.Transpose()
+	return inv, true
+}
+
+// `Decompose` splits the affine transform `m` into a translation, a rotation
+// and a scaling, such that `m` is the product T * R * S.
+//
+// If `m` is mirrored (i.e. its determinant is negative), the X scale factor is
+// returned negative, so that `rotation` is always a proper rotation.
+//
+// If `m` cannot be represented this way (because it contains a shear or a
+// projection, or is singular), zero values and false are returned.
+//
+// See also `DecomposeTRS` and `TRS`.
+func (m Mat4) Decompose() (translation Vec3, rotation Mat3, scale Vec3, ok bool) {
+	if m[0][3] != 0 || m[1][3] != 0 || m[2][3] != 0 || m[3][3] != 1 {
+		return Vec3{}, Mat3{}, Vec3{}, false
+	}
+
+	translation, rotation, scale = m.decompose()
+
+	max := math.Abs(scale.X)
+	if scale.Y > max {
+		max = scale.Y
+	}
+	if scale.Z > max {
+		max = scale.Z
+	}
+	limit := singularEpsilon * max
+	if math.Abs(scale.X) <= limit || scale.Y <= limit || scale.Z <= limit {
+		return Vec3{}, Mat3{}, Vec3{}, false
+	}
+
+	const shearEpsilon = 1e-4
+	c0 := Vec3{rotation[0][0], rotation[0][1], rotation[0][2]}
+	c1 := Vec3{rotation[1][0], rotation[1][1], rotation[1][2]}
+	c2 := Vec3{rotation[2][0], rotation[2][1], rotation[2][2]}
+	if math.Abs(c0.Dot(c1)) > shearEpsilon ||
+		math.Abs(c0.Dot(c2)) > shearEpsilon ||
+		math.Abs(c1.Dot(c2)) > shearEpsilon {
+		return Vec3{}, Mat3{}, Vec3{}, false
+	}
+
+	return translation, rotation, scale, true
+}
+
+// `DecomposeTRS` splits the affine transform `m` into a translation, a
+// rotation and a scaling, such that `m` is the product T * R * S.
+//
+// `m` must not contain any shear or projection, and its scale factors must be
+// non-zero. If `m` is mirrored (i.e. its determinant is negative), the X
+// scale factor is returned negative.
+//
+// See also `Decompose` and `TRS`.
+func (m Mat4) DecomposeTRS() (translation Vec3, rotation Quat, scale Vec3) {
+	t, r, s := m.decompose()
+	return t, QuatFromMat3(r), s
+}
+
+// `decompose` extracts the translation, the rotation block and the scale of
+// `m`, without any validation.
+func (m *Mat4) decompose() (translation Vec3, rotation Mat3, scale Vec3) {
+	translation = Vec3{m[3][0], m[3][1], m[3][2]}
+
+	r := m.Mat3()
+	scale = Vec3{
+		Vec3{r[0][0], r[0][1], r[0][2]}.Length(),
+		Vec3{r[1][0], r[1][1], r[1][2]}.Length(),
+		Vec3{r[2][0], r[2][1], r[2][2]}.Length(),
+	}
+	if r.Determinant() < 0 {
+		scale.X = -scale.X
+	}
+
+	for i, s := range [3]float32{scale.X, scale.Y, scale.Z} {
+		r[i][0] /= s
+		r[i][1] /= s
+		r[i][2] /= s
+	}
+
+	return translation, r, scale
+}
+
+// `columnLengthsProduct` returns the product of the lengths of the columns of
+// `m`, which is an upper bound of the absolute value of its determinant.
+func (m *Mat4) columnLengthsProduct() float32 {
+	var p float32 = 1
+	for c := 0; c < 4; c++ {
+		p *= m[c][0]*m[c][0] + m[c][1]*m[c][1] + m[c][2]*m[c][2] + m[c][3]*m[c][3]
+	}
+	return math.Sqrt(p)
 }
 
 //------------------------------------------------------------------------------
 
 // `LookAt` returns a transform from world space into the specific eye space
 // that the projective matrix functions (Perspective, OrthographicFrustum, ...)
-// are designed to expect.
+// are designed to expect: a right-handed space where the camera is at the
+// origin, looks towards -Z, and where `up` is projected onto +Y.
+//
+// If the view direction is parallel (or nearly parallel) to `up`, a fallback
+// up vector is used instead: -Z when looking down along `up`, +Z when looking
+// up (or respectively -Y and +Y when `up` is itself close to the Z axis), as
+// if the camera had been tilted from a horizontal view. A zero `up` is
+// replaced by +Y.
+//
+// `eye` and `center` must be different; otherwise there is no view direction,
+// and the result is just a translation by `-eye`.
 //
 // See also `Perspective` and `OrthographicFrustum`.
 func LookAt(eye, center, up Vec3) Mat4 {
 	center.Subtract(eye)
+	if center.Dot(center) == 0 {
+		return Translation(eye.Inverse())
+	}
 	f := center.Normalized()
-	u := up.Normalized()
-	s := f.Cross(u).Normalized()
-	u = s.Cross(f)
+	s, u, _ := lookBasis(f, up)
 
 	res := MakeMat4(
 		s.X, s.Y, s.Z, -s.Dot(eye),
@@ -514,3 +1921,32 @@ func LookAt(eye, center, up Vec3) Mat4 {
 
 	return res
 }
+
+// `lookBasis` returns the right and up vectors of an orthonormal basis whose
+// forward vector is `f` (which must be normalized), with the up vector in the
+// plane of `f` and `up`, and true. If `up` is zero or parallel to `f`, the
+// fallback described in `LookAt` is used, and the boolean is false.
+func lookBasis(f, up Vec3) (s, u Vec3, ok bool) {
+	u = Vec3{0, 1, 0}
+	if l := up.Length(); l != 0 {
+		u = up.Slash(l)
+		ok = true
+	}
+	s = f.Cross(u)
+	if s.Length() < 1e-4 {
+		ok = false
+		sign := float32(1)
+		if f.Dot(u) < 0 {
+			sign = -1
+		}
+		if math.Abs(u.Z) < 0.9 {
+			u = Vec3{0, 0, sign}
+		} else {
+			u = Vec3{0, sign, 0}
+		}
+		s = f.Cross(u)
+	}
+	s.Normalize()
+	u = s.Cross(f)
+	return s, u, ok
+}
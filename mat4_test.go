@@ -4,8 +4,13 @@
 package glam
 
 import (
+	"fmt"
+	gomath "math"
+	"math/rand"
 	"testing"
 	"unsafe"
+
+	"github.com/drakmaniso/glam/math"
 )
 
 //------------------------------------------------------------------------------
@@ -199,3 +204,1753 @@ func BenchmarkMat4_SetTo(b *testing.B) {
 }
 
 //------------------------------------------------------------------------------
+
+func TestRotation(t *testing.T) {
+	m := Rotation(math.Pi/2, Vec3{0, 0, 1})
+	v := m.TimesVec4(Vec4{1, 0, 0, 1})
+	if !math.IsRoughlyEqual(v.X, 0, 1e-6) || !math.IsRoughlyEqual(v.Y, 1, 1e-6) ||
+		v.Z != 0 || v.W != 1 {
+		t.Errorf("Wrong result: %#v", v)
+	}
+	var n Mat4
+	n.Rotation(math.Pi/2, Vec3{0, 0, 1})
+	if n != m {
+		t.Errorf("SetToRotation differs from Rotation: %#v", n)
+	}
+}
+
+func TestRotationAxis(t *testing.T) {
+	if m := RotationAxis(Vec3{0.6, 0, 0.8}, 0); m != Identity() {
+		t.Errorf("Not identity for zero angle: %#v", m)
+	}
+	for _, axis := range []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, Vec3{1, -2, 0.5}.Normalized()} {
+		for _, angle := range []float32{0.3, -1.2, math.Pi, 2.9} {
+			m := RotationAxis(axis, angle)
+			if e := Mat3RotationAxis(axis, angle).Mat4(); !isRoughlyEqualMat4(m, e, 1e-6) {
+				t.Errorf("Differs from Mat3RotationAxis around %#v: %#v instead of %#v", axis, m, e)
+			}
+			if r := Rotation(angle, axis); r != m {
+				t.Errorf("Differs from Rotation: %#v", r)
+			}
+			for _, v := range []Vec3{{1, 0, 0}, {0, 1, 0}, {-1, 2, 3}} {
+				if p := m.TransformDirection(v); !isRoughlyEqualVec3(p, v.RotateAxis(axis, angle), 1e-5) {
+					t.Errorf("Differs from Vec3.RotateAxis for %#v: %#v", v, p)
+				}
+			}
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat4_Times(t *testing.T) {
+	tr := Translation(Vec3{1, 2, 3})
+	ro := Rotation(math.Pi/2, Vec3{0, 0, 1})
+	m := tr.Times(&ro)
+	v := m.TimesVec4(Vec4{1, 0, 0, 1})
+	if !math.IsRoughlyEqual(v.X, 1, 1e-6) || !math.IsRoughlyEqual(v.Y, 3, 1e-6) ||
+		v.Z != 3 || v.W != 1 {
+		t.Errorf("Wrong result: %#v", v)
+	}
+	var n Mat4
+	n.Multiply(&tr, &ro)
+	if n != m {
+		t.Errorf("Multiply differs from Times: %#v", n)
+	}
+}
+
+func TestMat4_MulInto(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a, b := randomMat4(r), randomMat4(r)
+	ab, ba, aa := a.Times(&b), b.Times(&a), a.Times(&a)
+
+	var m Mat4
+	m.MulInto(&a, &b)
+	if m != ab {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	m = a
+	m.MulInto(&m, &b)
+	if m != ab {
+		t.Errorf("Wrong result when aliasing the first operand: %#v", m)
+	}
+	m = a
+	m.MulInto(&b, &m)
+	if m != ba {
+		t.Errorf("Wrong result when aliasing the second operand: %#v", m)
+	}
+	m = a
+	m.MulInto(&m, &m)
+	if m != aa {
+		t.Errorf("Wrong result when aliasing both operands: %#v", m)
+	}
+
+	v := Vec4{1, -2, 3, 1}
+	e := a.TimesVec4(v)
+	var w Vec4
+	a.TransformVec4Into(&w, &v)
+	if w != e {
+		t.Errorf("Wrong result: %#v", w)
+	}
+	a.TransformVec4Into(&v, &v)
+	if v != e {
+		t.Errorf("Wrong result when aliasing: %#v", v)
+	}
+}
+
+func TestMat4_TransformPoint(t *testing.T) {
+	m := Translation(Vec3{1, -2, 3})
+	if p := m.TransformPoint(Vec3{1, 1, 1}); p != (Vec3{2, -1, 4}) {
+		t.Errorf("Wrong point: %#v", p)
+	}
+	if d := m.TransformDirection(Vec3{1, 1, 1}); d != (Vec3{1, 1, 1}) {
+		t.Errorf("Wrong direction: %#v", d)
+	}
+	p := Perspective(1.2, 1.5, 0.5, 50)
+	if q := p.TransformPoint(Vec3{0, 0, -0.5}); !isRoughlyEqualVec3(q, Vec3{0, 0, -1}, 1e-6) {
+		t.Errorf("Wrong projected point: %#v", q)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m := randomMat4(r)
+		v := Vec3{r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5}
+		if q, e := m.TransformPoint(v), m.TimesVec4(v.Homogenized()).Dehomogenized(); q != e {
+			t.Errorf("Wrong point: %#v instead of %#v", q, e)
+		}
+		e := m.TimesVec4(v.HomogenizedAsDirection())
+		if d := m.TransformDirection(v); d != (Vec3{e.X, e.Y, e.Z}) {
+			t.Errorf("Wrong direction: %#v instead of %#v", d, e)
+		}
+	}
+}
+
+func TestMat4_TransformPoints(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := randomAffineMat4(r)
+	src := make([]Vec3, 100)
+	for i := range src {
+		src[i] = Vec3{r.Float32()*10 - 5, r.Float32()*10 - 5, r.Float32()*10 - 5}
+	}
+	points, dirs := make([]Vec3, len(src)), make([]Vec3, len(src))
+	m.TransformPoints(points, src)
+	m.TransformDirections(dirs, src)
+	for i, v := range src {
+		e := m.TimesVec4(Vec4{v.X, v.Y, v.Z, 1})
+		if !isRoughlyEqualVec3(points[i], Vec3{e.X, e.Y, e.Z}, 1e-5) {
+			t.Errorf("Wrong point for %#v: %#v instead of %#v", v, points[i], e)
+		}
+		e = m.TimesVec4(Vec4{v.X, v.Y, v.Z, 0})
+		if !isRoughlyEqualVec3(dirs[i], Vec3{e.X, e.Y, e.Z}, 1e-5) {
+			t.Errorf("Wrong direction for %#v: %#v instead of %#v", v, dirs[i], e)
+		}
+	}
+	in := append([]Vec3(nil), src...)
+	m.TransformPoints(in, in)
+	for i := range in {
+		if in[i] != points[i] {
+			t.Errorf("Wrong in-place point: %#v instead of %#v", in[i], points[i])
+		}
+	}
+	in = append(in[:0], src...)
+	m.TransformDirections(in, in)
+	for i := range in {
+		if in[i] != dirs[i] {
+			t.Errorf("Wrong in-place direction: %#v instead of %#v", in[i], dirs[i])
+		}
+	}
+	short := make([]Vec3, 3)
+	if err := m.TransformPoints(short, src); err == nil ||
+		err.Error() != "glam.Mat4.TransformPoints: 3 destinations for 100 sources" {
+		t.Errorf("Wrong error on length mismatch: %v", err)
+	}
+	if err := m.TransformDirections(short, src); err == nil {
+		t.Errorf("No error on length mismatch")
+	}
+	for i := range short {
+		if short[i] != (Vec3{}) {
+			t.Errorf("Destination written despite length mismatch: %#v", short)
+			break
+		}
+	}
+}
+
+func TestMat4_TransformVec4s(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := randomMat4(r)
+	src := make([]Vec4, 100)
+	for i := range src {
+		src[i] = Vec4{r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5}
+	}
+	dst := make([]Vec4, len(src))
+	m.TransformVec4s(dst, src)
+	for i, v := range src {
+		if e := m.TimesVec4(v); !isRoughlyEqualVec4(dst[i], e, 1e-5) {
+			t.Errorf("Wrong result for %#v: %#v instead of %#v", v, dst[i], e)
+		}
+	}
+	m.TransformVec4s(src, src)
+	for i := range src {
+		if src[i] != dst[i] {
+			t.Errorf("Wrong in-place result: %#v instead of %#v", src[i], dst[i])
+		}
+	}
+	old := append([]Vec4(nil), dst...)
+	if err := m.TransformVec4s(dst, src[1:]); err == nil ||
+		err.Error() != "glam.Mat4.TransformVec4s: 100 destinations for 99 sources" {
+		t.Errorf("Wrong error on length mismatch: %v", err)
+	}
+	for i := range dst {
+		if dst[i] != old[i] {
+			t.Errorf("Destination written despite length mismatch")
+			break
+		}
+	}
+}
+
+func TestMat4_TransformPointsParallel(t *testing.T) {
+	m := randomAffineMat4(rand.New(rand.NewSource(1)))
+	for _, n := range []int{0, 10, parallelThreshold, 3*parallelThreshold + 17, 100000} {
+		src := benchmarkPoints(n)
+		e := make([]Vec3, n)
+		m.TransformPoints(e, src)
+		for _, w := range []int{-1, 0, 1, 2, 3, 7, 64} {
+			dst := make([]Vec3, n)
+			m.TransformPointsParallel(dst, src, w)
+			for i := range dst {
+				if dst[i] != e[i] {
+					t.Errorf("Wrong result for %d points and %d workers at %d: %#v instead of %#v", n, w, i, dst[i], e[i])
+					break
+				}
+			}
+		}
+		m.TransformPointsParallel(src, src, 4)
+		for i := range src {
+			if src[i] != e[i] {
+				t.Errorf("Wrong in-place result for %d points at %d", n, i)
+				break
+			}
+		}
+	}
+	src := benchmarkPoints(3 * parallelThreshold)
+	dst := make([]Vec3, len(src)-1)
+	for _, w := range []int{1, 4} {
+		if err := m.TransformPointsParallel(dst, src, w); err == nil ||
+			err.Error() != fmt.Sprintf("glam.Mat4.TransformPointsParallel: %d destinations for %d sources", len(dst), len(src)) {
+			t.Errorf("Wrong error on length mismatch with %d workers: %v", w, err)
+		}
+	}
+	for i := range dst {
+		if dst[i] != (Vec3{}) {
+			t.Errorf("Destination written despite length mismatch")
+			break
+		}
+	}
+}
+
+func benchmarkPoints(n int) []Vec3 {
+	r := rand.New(rand.NewSource(1))
+	p := make([]Vec3, n)
+	for i := range p {
+		p[i] = Vec3{r.Float32(), r.Float32(), r.Float32()}
+	}
+	return p
+}
+
+func BenchmarkMat4_TransformPoints(b *testing.B) {
+	m := randomAffineMat4(rand.New(rand.NewSource(1)))
+	src := benchmarkPoints(1024)
+	dst := make([]Vec3, len(src))
+	for i := 0; i < b.N; i++ {
+		m.TransformPoints(dst, src)
+	}
+}
+
+func BenchmarkMat4_TransformPoints_loop(b *testing.B) {
+	m := randomAffineMat4(rand.New(rand.NewSource(1)))
+	src := benchmarkPoints(1024)
+	dst := make([]Vec3, len(src))
+	for i := 0; i < b.N; i++ {
+		for j, v := range src {
+			p := m.TimesVec4(Vec4{v.X, v.Y, v.Z, 1})
+			dst[j] = Vec3{p.X, p.Y, p.Z}
+		}
+	}
+}
+
+func BenchmarkMat4_TransformPointsParallel(b *testing.B) {
+	m := randomAffineMat4(rand.New(rand.NewSource(1)))
+	src := benchmarkPoints(1 << 20)
+	dst := make([]Vec3, len(src))
+	for _, w := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", w), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m.TransformPointsParallel(dst, src, w)
+			}
+		})
+	}
+}
+
+func BenchmarkMat4_Times(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	m, n := randomMat4(r), randomMat4(r)
+	var o Mat4
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		o = m.Times(&n)
+	}
+	_ = o
+}
+
+func BenchmarkMat4_MulInto(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	m, n := randomMat4(r), randomMat4(r)
+	var o Mat4
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		o.MulInto(&m, &n)
+	}
+}
+
+func BenchmarkMat4_MulInto_aliased(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	m, n := randomMat4(r), randomMat4(r)
+	o := m
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		o = m
+		o.MulInto(&o, &n)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func isRoughlyEqualMat4(a, b Mat4, epsilon float32) bool {
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			if !math.IsRoughlyEqual(a[c][r], b[c][r], epsilon) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isRoughlyEqualVec4(a, b Vec4, epsilon float32) bool {
+	return math.IsRoughlyEqual(a.X, b.X, epsilon) &&
+		math.IsRoughlyEqual(a.Y, b.Y, epsilon) &&
+		math.IsRoughlyEqual(a.Z, b.Z, epsilon) &&
+		math.IsRoughlyEqual(a.W, b.W, epsilon)
+}
+
+// randomMat4 returns a well-conditioned matrix: a rotation and translation,
+// with scale factors in [0.5, 2] and a small perturbation on every element.
+func randomMat4(r *rand.Rand) Mat4 {
+	axis := Vec3{r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5}.Normalized()
+	m := Rotation(r.Float32()*2*math.Pi, axis)
+	for c := 0; c < 3; c++ {
+		s := 0.5 + 1.5*r.Float32()
+		for l := 0; l < 3; l++ {
+			m[c][l] *= s
+		}
+	}
+	for c := 0; c < 4; c++ {
+		for l := 0; l < 4; l++ {
+			m[c][l] += 0.2 * (r.Float32() - 0.5)
+		}
+	}
+	m[3][0] += 10 * (r.Float32() - 0.5)
+	m[3][1] += 10 * (r.Float32() - 0.5)
+	m[3][2] += 10 * (r.Float32() - 0.5)
+	return m
+}
+
+// inverse64 inverts m in double precision, using Gauss-Jordan elimination with
+// partial pivoting.
+func inverse64(m Mat4) [4][4]float64 {
+	var a, b [4][4]float64
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			a[r][c] = float64(m[c][r])
+		}
+		b[c][c] = 1
+	}
+	for c := 0; c < 4; c++ {
+		p := c
+		for r := c + 1; r < 4; r++ {
+			if gomath.Abs(a[r][c]) > gomath.Abs(a[p][c]) {
+				p = r
+			}
+		}
+		a[c], a[p] = a[p], a[c]
+		b[c], b[p] = b[p], b[c]
+		d := a[c][c]
+		for k := 0; k < 4; k++ {
+			a[c][k] /= d
+			b[c][k] /= d
+		}
+		for r := 0; r < 4; r++ {
+			if r != c {
+				f := a[r][c]
+				for k := 0; k < 4; k++ {
+					a[r][k] -= f * a[c][k]
+					b[r][k] -= f * b[c][k]
+				}
+			}
+		}
+	}
+	// Back to column-major
+	var res [4][4]float64
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			res[c][r] = b[r][c]
+		}
+	}
+	return res
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat4_Inverse(t *testing.T) {
+	id := Identity()
+	inv, ok := id.Inverse()
+	if !ok || inv != id {
+		t.Errorf("Wrong inverse of identity: %#v", inv)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		m := randomMat4(r)
+		inv, ok := m.Inverse()
+		if !ok {
+			t.Fatalf("Reported singular: %#v", m)
+		}
+		p := m.Times(&inv)
+		if !isRoughlyEqualMat4(p, id, 1e-5) {
+			t.Errorf("M * M⁻¹ is not identity: %#v", p)
+		}
+		p = inv.Times(&m)
+		if !isRoughlyEqualMat4(p, id, 1e-5) {
+			t.Errorf("M⁻¹ * M is not identity: %#v", p)
+		}
+		ref := inverse64(m)
+		for c := 0; c < 4; c++ {
+			for l := 0; l < 4; l++ {
+				if e := gomath.Abs(float64(inv[c][l]) - ref[c][l]); e > 1e-5*(1+gomath.Abs(ref[c][l])) {
+					t.Errorf("Error too large at [%d][%d]: %v instead of %v", c, l, inv[c][l], ref[c][l])
+				}
+			}
+		}
+	}
+}
+
+func TestMat4_Inverse_singular(t *testing.T) {
+	m := MakeMat4(
+		2, 0, 0, 1,
+		0, 0, 0, 2,
+		0, 0, 3, 3,
+		0, 0, 0, 1,
+	)
+	inv, ok := m.Inverse()
+	if ok {
+		t.Errorf("Singular matrix not detected: %#v", inv)
+	}
+	// Nearly singular: the first two columns are almost parallel
+	m = MakeMat4(
+		1, 1, 0, 0,
+		2, 2.000001, 0, 0,
+		3, 3, 1, 0,
+		0, 0, 0, 1,
+	)
+	if _, ok := m.Inverse(); ok {
+		t.Errorf("Nearly singular matrix not detected")
+	}
+	// A small but uniform scale must not be rejected
+	m = MakeMat4(
+		1e-3, 0, 0, 0,
+		0, 1e-3, 0, 0,
+		0, 0, 1e-3, 0,
+		0, 0, 0, 1,
+	)
+	if _, ok := m.Inverse(); !ok {
+		t.Errorf("Uniform scaling reported singular")
+	}
+}
+
+func BenchmarkMat4_Inverse(b *testing.B) {
+	m := randomMat4(rand.New(rand.NewSource(1)))
+	var o Mat4
+	for i := 0; i < b.N; i++ {
+		o, _ = m.Inverse()
+	}
+	_ = o
+}
+
+func TestMat4_InverseAffine(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m := randomAffineMat4(r)
+		inv, ok := m.InverseAffine()
+		e, eok := m.Inverse()
+		if !ok || !eok || !isRoughlyEqualMat4(inv, e, 1e-5) {
+			t.Errorf("Wrong result for %#v: %#v instead of %#v", m, inv, e)
+		}
+		if inv[0][3] != 0 || inv[1][3] != 0 || inv[2][3] != 0 || inv[3][3] != 1 {
+			t.Errorf("Result not affine: %#v", inv)
+		}
+	}
+	if _, ok := Scaling(Vec3{1, 0, 1}).InverseAffine(); ok {
+		t.Errorf("Singular matrix not detected")
+	}
+}
+
+func TestMat4_InverseRigid(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m := TRS(
+			Vec3{r.Float32()*10 - 5, r.Float32()*10 - 5, r.Float32()*10 - 5},
+			randomQuat(r),
+			Vec3{1, 1, 1},
+		)
+		inv := m.InverseRigid()
+		e, _ := m.Inverse()
+		if !isRoughlyEqualMat4(inv, e, 1e-5) {
+			t.Errorf("Wrong result for %#v: %#v instead of %#v", m, inv, e)
+		}
+		if p := m.Times(&inv); !p.IsIdentity(1e-5) {
+			t.Errorf("M * M⁻¹ is not identity: %#v", p)
+		}
+	}
+}
+
+func BenchmarkMat4_InverseAffine(b *testing.B) {
+	m := randomAffineMat4(rand.New(rand.NewSource(1)))
+	var o Mat4
+	for i := 0; i < b.N; i++ {
+		o, _ = m.InverseAffine()
+	}
+	_ = o
+}
+
+func BenchmarkMat4_InverseRigid(b *testing.B) {
+	m := TRS(Vec3{1, 2, 3}, randomQuat(rand.New(rand.NewSource(1))), Vec3{1, 1, 1})
+	var o Mat4
+	for i := 0; i < b.N; i++ {
+		o = m.InverseRigid()
+	}
+	_ = o
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat4_Determinant(t *testing.T) {
+	if d := Identity().Determinant(); d != 1 {
+		t.Errorf("Wrong determinant for identity: %v", d)
+	}
+	if d := Rotation(1.3, Vec3{2, -1, 3}.Normalized()).Determinant(); !math.IsRoughlyEqual(d, 1, 1e-6) {
+		t.Errorf("Wrong determinant for rotation: %v", d)
+	}
+	s := MakeMat4(
+		2, 0, 0, 5,
+		0, 3, 0, -1,
+		0, 0, 4, 7,
+		0, 0, 0, 1,
+	)
+	if d := s.Determinant(); d != 24 {
+		t.Errorf("Wrong determinant for scaling: %v", d)
+	}
+	mirror := MakeMat4(
+		-1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	)
+	r := Rotation(0.4, Vec3{0, 1, 0})
+	if d := r.Times(&mirror).Determinant(); !math.IsRoughlyEqual(d, -1, 1e-6) {
+		t.Errorf("Wrong determinant for mirror: %v", d)
+	}
+	singular := MakeMat4(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	)
+	if d := singular.Determinant(); !math.IsRoughlyEqual(d, 0, 1e-4) {
+		t.Errorf("Wrong determinant for singular matrix: %v", d)
+	}
+	// Nearly singular, but the sign must still be right
+	thin := MakeMat4(
+		1, 1, 0, 0,
+		1, 1.0001, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	)
+	if d := thin.Determinant(); d <= 0 {
+		t.Errorf("Wrong sign for nearly singular matrix: %v", d)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m := randomMat4(rnd)
+		inv, _ := m.Inverse()
+		d := m.Determinant() * inv.Determinant()
+		if !math.IsRoughlyEqual(d, 1, 1e-4) {
+			t.Errorf("Determinant of inverse is not the inverse: %v", d)
+		}
+	}
+}
+
+func TestMat4_Trace(t *testing.T) {
+	m := MakeMat4(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	)
+	if tr := m.Trace(); tr != 34 {
+		t.Errorf("Wrong result: %v", tr)
+	}
+}
+
+func TestMat4_IsIdentity(t *testing.T) {
+	if !Identity().IsIdentity(0) || (Mat4{}).IsIdentity(0.5) {
+		t.Errorf("Wrong result for identity")
+	}
+	r := Identity().RotatedX(1e-4)
+	for _, c := range []struct {
+		m        Mat4
+		epsilon  float32
+		identity bool
+	}{
+		{Translation(Vec3{0, 0.0009, 0}), 1e-3, true},
+		{Translation(Vec3{0, 0.0011, 0}), 1e-3, false},
+		{r, 1e-3, true},
+		{r, 1e-5, false},
+	} {
+		if c.m.IsIdentity(c.epsilon) != c.identity {
+			t.Errorf("Wrong result for %#v", c.m)
+		}
+	}
+}
+
+func TestMat4_IsAffine(t *testing.T) {
+	m := TRS(Vec3{1, 2, 3}, QuatIdentity(), Vec3{2, 2, 2})
+	if !m.IsAffine(0) {
+		t.Errorf("TRS not affine: %#v", m)
+	}
+	if p := Perspective(1, 1.5, 0.1, 100); p.IsAffine(1e-3) {
+		t.Errorf("Perspective reported affine: %#v", p)
+	}
+	m[1][3] = 0.0009
+	if !m.IsAffine(1e-3) || m.IsAffine(1e-4) {
+		t.Errorf("Wrong tolerance: %#v", m)
+	}
+
+	// Accumulated rotations stay rigid
+	a := Translation(Vec3{1, 2, 3})
+	for i := 0; i < 100; i++ {
+		a.RotateAxis(Vec3{0, 0.6, 0.8}, 0.1)
+		a.Translate(Vec3{0.1, 0, 0})
+	}
+	if !a.IsRigid(1e-5) {
+		t.Errorf("Accumulated rotations not rigid: %#v", a)
+	}
+	if m.IsRigid(1e-5) || Scaling(Vec3{1, 1, -1}).IsRigid(1e-5) {
+		t.Errorf("Wrong result for non-rigid matrix")
+	}
+}
+
+func TestMat4_FrobeniusNorm(t *testing.T) {
+	m := MakeMat4(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	)
+	if n := m.FrobeniusNorm(); n != 38.678159 {
+		t.Errorf("Wrong result: %v", n)
+	}
+	if n := Identity().FrobeniusNorm(); n != 2 {
+		t.Errorf("Wrong result for identity: %v", n)
+	}
+	m = MakeMat4(
+		-3, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 4,
+	)
+	if n := m.FrobeniusNorm(); n != 5 {
+		t.Errorf("Wrong result: %v", n)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat4_Transposed(t *testing.T) {
+	m := MakeMat4(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	)
+	e := MakeMat4(
+		1, 5, 9, 13,
+		2, 6, 10, 14,
+		3, 7, 11, 15,
+		4, 8, 12, 16,
+	)
+	if r := m.Transposed(); r != e {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	m.Transpose()
+	if m != e {
+		t.Errorf("Wrong result: %#v", m)
+	}
+}
+
+func TestMat4_NormalMatrix(t *testing.T) {
+	r := Rotation(0.6, Vec3{1, 2, -1}.Normalized())
+	s := MakeMat4(
+		3, 0, 0, 0,
+		0, 0.5, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	)
+	tr := Translation(Vec3{4, -2, 7})
+	m := tr.Times(&r)
+	m = m.Times(&s)
+
+	n, ok := m.NormalMatrix()
+	if !ok {
+		t.Fatalf("Reported singular")
+	}
+	u := m.Mat3()
+
+	normal := Vec3{1, 1, 0}.Normalized()
+	tangent := Vec3{1, -1, 0.5}
+	tn := n.TimesVec3(normal)
+	tt := u.TimesVec3(tangent)
+	if d := tn.Dot(tt); !math.IsRoughlyEqual(d, 0, 1e-5) {
+		t.Errorf("Transformed normal not perpendicular: %v", d)
+	}
+	// Using the upper-left part directly breaks under non-uniform scaling
+	if d := u.TimesVec3(normal).Dot(tt); math.IsRoughlyEqual(d, 0, 1e-2) {
+		t.Errorf("Naive transform unexpectedly perpendicular: %v", d)
+	}
+
+	// For a pure rotation, the normal matrix is the rotation itself
+	n, _ = r.NormalMatrix()
+	if !isRoughlyEqualMat3(n, r.Mat3(), 1e-6) {
+		t.Errorf("Wrong result for rotation: %#v", n)
+	}
+
+	s[1][1] = 0
+	if _, ok := s.NormalMatrix(); ok {
+		t.Errorf("Singular matrix not detected")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat4FromColumns(t *testing.T) {
+	a, b, c, d := Vec4{1, 2, 3, 4}, Vec4{5, 6, 7, 8}, Vec4{9, 10, 11, 12}, Vec4{13, 14, 15, 16}
+	m := Mat4FromColumns(a, b, c, d)
+	e := MakeMat4(
+		1, 5, 9, 13,
+		2, 6, 10, 14,
+		3, 7, 11, 15,
+		4, 8, 12, 16,
+	)
+	if m != e {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	if r := m.TimesVec4(Vec4{0, 0, 1, 0}); r != c {
+		t.Errorf("Column not preserved: %#v", r)
+	}
+	if m.Transposed() != Mat4FromRows(a, b, c, d) {
+		t.Errorf("Transpose differs from Mat4FromRows: %#v", Mat4FromRows(a, b, c, d))
+	}
+}
+
+func TestMat4_ColumnMajor(t *testing.T) {
+	m := MakeMat4(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	)
+	c := m.ColumnMajor()
+	if c != [16]float32{1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15, 4, 8, 12, 16} {
+		t.Errorf("Wrong column-major layout: %v", c)
+	}
+	r := m.RowMajor()
+	if r != [16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16} {
+		t.Errorf("Wrong row-major layout: %v", r)
+	}
+	// Element (row 1, column 3)
+	if c[3*4+1] != 8 || r[1*4+3] != 8 || m.At(1, 3) != 8 {
+		t.Errorf("Wrong element position: %v, %v", c[13], r[7])
+	}
+	tr := Translation(Vec3{7, 8, 9}).ColumnMajor()
+	if tr[12] != 7 || tr[13] != 8 || tr[14] != 9 {
+		t.Errorf("Wrong translation layout: %v", tr)
+	}
+	if *m.Flat() != c {
+		t.Errorf("Flat differs from ColumnMajor: %v", *m.Flat())
+	}
+	if a := m.Array(); a != c {
+		t.Errorf("Array differs from ColumnMajor: %v", a)
+	}
+	p := (*[16]float32)(unsafe.Pointer(m.Pointer()))
+	if *p != c {
+		t.Errorf("Wrong layout through Pointer: %v", *p)
+	}
+	if p[3*4+1] = 42; m.At(1, 3) != 42 {
+		t.Errorf("Pointer does not alias the matrix: %#v", m)
+	}
+	m.Set(1, 3, 8)
+
+	if n := Mat4FromColumnMajor(c); n != m {
+		t.Errorf("No round-trip through column-major: %#v", n)
+	}
+	if n := Mat4FromRowMajor(r); n != m {
+		t.Errorf("No round-trip through row-major: %#v", n)
+	}
+	if n := Mat4FromRowMajor(c); n != m.Transposed() {
+		t.Errorf("Mismatched layouts are not a transpose: %#v", n)
+	}
+	s := append([]float32{-1}, r[:]...)
+	if n := Mat4FromRowMajorSlice(s[1:]); n != m {
+		t.Errorf("Wrong result from slice: %#v", n)
+	}
+	if n := Mat4FromColumnMajorSlice(append(c[:], 99)); n != m {
+		t.Errorf("Wrong result from slice: %#v", n)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("No panic on short slice")
+		}
+	}()
+	Mat4FromColumnMajorSlice(c[:15])
+}
+
+func TestMat4_NearlyEqual(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a, b, c := randomMat4(r), randomMat4(r), randomMat4(r)
+	ab, bc := a.Times(&b), b.Times(&c)
+	m, n := ab.Times(&c), a.Times(&bc)
+	if m.Equal(n) {
+		t.Errorf("Products in different order are exactly equal: %#v", m)
+	}
+	if !m.NearlyEqual(n, 1e-5) || !m.RoughlyEqual(n, 1e-4) {
+		t.Errorf("Products in different order are not nearly equal: %#v, %#v", m, n)
+	}
+	if !m.Equal(m) || !m.NearlyEqual(m, 0) || !(Mat4{}).NearlyEqual(Mat4{}, 0) {
+		t.Errorf("Matrix not equal to itself")
+	}
+
+	// Rotation by 90°: the elements that should be zero are not
+	rz := Rotation(math.Pi/2, Vec3{0, 0, 1})
+	if rz[0][0] == 0 {
+		t.Errorf("Rotation is exact, test useless: %#v", rz)
+	}
+	e := MakeMat4(
+		0, -1, 0, 0,
+		1, 0, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	)
+	if !rz.NearlyEqual(e, 1e-6) {
+		t.Errorf("Wrong result: %#v", rz)
+	}
+
+	// A single element out of tolerance
+	n = m
+	n[2][1] += 1e-3 * m.FrobeniusNorm()
+	if m.NearlyEqual(n, 1e-5) || n.NearlyEqual(m, 1e-5) {
+		t.Errorf("Single element difference not detected")
+	}
+	n = m
+	n[3][3] += 2e-4
+	if m.RoughlyEqual(n, 1e-4) || !m.RoughlyEqual(n, 1e-3) {
+		t.Errorf("Wrong absolute tolerance")
+	}
+}
+
+func TestMat4_Row(t *testing.T) {
+	m := MakeMat4(
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	)
+	if m.At(1, 2) != 7 || m[2][1] != 7 {
+		t.Errorf("Wrong element: %v", m.At(1, 2))
+	}
+	if r := m.Row(1); r != (Vec4{5, 6, 7, 8}) {
+		t.Errorf("Wrong row: %#v", r)
+	}
+	if c := m.Col(2); c != (Vec4{3, 7, 11, 15}) {
+		t.Errorf("Wrong column: %#v", c)
+	}
+	for i, e := range []Vec4{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}} {
+		if c := m.TimesVec4(e); c != m.Col(i) {
+			t.Errorf("Column %d differs from TimesVec4: %#v", i, c)
+		}
+		if m.Row(i) != m.Transposed().Col(i) {
+			t.Errorf("Row %d differs from transposed column: %#v", i, m.Row(i))
+		}
+	}
+	if c := Translation(Vec3{1, 2, 3}).Col(3); c != (Vec4{1, 2, 3, 1}) {
+		t.Errorf("Wrong translation: %#v", c)
+	}
+
+	m.SetRow(0, Vec4{-1, -2, -3, -4})
+	m.SetCol(3, Vec4{20, 21, 22, 23})
+	m.Set(2, 1, 42)
+	e := MakeMat4(
+		-1, -2, -3, 20,
+		5, 6, 7, 21,
+		9, 42, 11, 22,
+		13, 14, 15, 23,
+	)
+	if m != e {
+		t.Errorf("Wrong result: %#v", m)
+	}
+}
+
+func TestMat4_Row_panics(t *testing.T) {
+	m := Identity()
+	for _, f := range []func(){
+		func() { m.Row(4) },
+		func() { m.Col(-1) },
+		func() { m.SetRow(7, Vec4{}) },
+		func() { m.SetCol(4, Vec4{}) },
+		func() { m.At(0, 4) },
+		func() { m.Set(-1, 0, 1) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("No panic on out-of-range index")
+				}
+			}()
+			f()
+		}()
+	}
+	defer func() {
+		r := recover()
+		if s, ok := r.(string); !ok || s != "glam.Mat4.Row: index 4 out of range [0, 3]" {
+			t.Errorf("Wrong panic message: %#v", r)
+		}
+	}()
+	m.Row(4)
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat4_DecomposeTRS(t *testing.T) {
+	compose := func(tr Vec3, r Mat3, s Vec3) Mat4 {
+		m := Translation(tr)
+		rm := r.Mat4()
+		sm := Mat4FromColumns(Vec4{s.X, 0, 0, 0}, Vec4{0, s.Y, 0, 0}, Vec4{0, 0, s.Z, 0}, Vec4{0, 0, 0, 1})
+		m = m.Times(&rm)
+		return m.Times(&sm)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		tr := Vec3{rnd.Float32()*20 - 10, rnd.Float32()*20 - 10, rnd.Float32()*20 - 10}
+		axis := Vec3{rnd.Float32() - 0.5, rnd.Float32() - 0.5, rnd.Float32() - 0.5}.Normalized()
+		r := Rotation(rnd.Float32()*2*math.Pi-math.Pi, axis).Mat3()
+		s := Vec3{0.1 + rnd.Float32()*4, 0.1 + rnd.Float32()*4, 0.1 + rnd.Float32()*4}
+		m := compose(tr, r, s)
+
+		dt, dr, ds := m.DecomposeTRS()
+		if !isRoughlyEqualVec3(dt, tr, 1e-6) {
+			t.Errorf("Wrong translation: %#v instead of %#v", dt, tr)
+		}
+		if !isRoughlyEqualVec3(ds, s, 1e-5) {
+			t.Errorf("Wrong scale: %#v instead of %#v", ds, s)
+		}
+		if !isRoughlyEqualMat3(dr.Mat3(), r, 1e-5) {
+			t.Errorf("Wrong rotation: %#v instead of %#v", dr.Mat3(), r)
+		}
+	}
+
+	// Mirrored transforms
+	r := Rotation(0.8, Vec3{0, 0.6, 0.8}).Mat3()
+	for _, s := range []Vec3{{-2, 1, 3}, {2, -1, 3}, {2, 1, -3}, {-2, -1, -3}} {
+		m := compose(Vec3{1, 2, 3}, r, s)
+		dt, dr, ds := m.DecomposeTRS()
+		if ds.X >= 0 || ds.Y <= 0 || ds.Z <= 0 {
+			t.Errorf("Mirror not on X for %#v: %#v", s, ds)
+		}
+		if d := dr.Mat3().Determinant(); !math.IsRoughlyEqual(d, 1, 1e-5) {
+			t.Errorf("Rotation is not proper for %#v: %v", s, d)
+		}
+		if n := compose(dt, dr.Mat3(), ds); !isRoughlyEqualMat4(n, m, 1e-5) {
+			t.Errorf("No recomposition for %#v: %#v", s, n)
+		}
+	}
+	_, dr, ds := compose(Vec3{}, r, Vec3{-2, 1, 3}).DecomposeTRS()
+	if !isRoughlyEqualVec3(ds, Vec3{-2, 1, 3}, 1e-5) || !isRoughlyEqualMat3(dr.Mat3(), r, 1e-5) {
+		t.Errorf("Wrong decomposition: %#v, %#v", dr, ds)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestFrustum(t *testing.T) {
+	l, r, b, tp, n, f := float32(-1), float32(3), float32(-0.5), float32(2), float32(1), float32(10)
+	m := Frustum(l, r, b, tp, n, f)
+	project := func(p Vec3) Vec3 {
+		return m.TimesVec4(Vec4{p.X, p.Y, p.Z, 1}).Dehomogenized()
+	}
+	for _, d := range []float32{n, 2.5, 7, f} {
+		s := d / n
+		cx, cy := (l+r)/2*s, (b+tp)/2*s
+		if p := project(Vec3{l * s, cy, -d}); !math.IsRoughlyEqual(p.X, -1, 1e-5) {
+			t.Errorf("Wrong left plane at depth %v: %#v", d, p)
+		}
+		if p := project(Vec3{r * s, cy, -d}); !math.IsRoughlyEqual(p.X, 1, 1e-5) {
+			t.Errorf("Wrong right plane at depth %v: %#v", d, p)
+		}
+		if p := project(Vec3{cx, b * s, -d}); !math.IsRoughlyEqual(p.Y, -1, 1e-5) {
+			t.Errorf("Wrong bottom plane at depth %v: %#v", d, p)
+		}
+		if p := project(Vec3{cx, tp * s, -d}); !math.IsRoughlyEqual(p.Y, 1, 1e-5) {
+			t.Errorf("Wrong top plane at depth %v: %#v", d, p)
+		}
+	}
+	if p := project(Vec3{0.3, 0.2, -n}); !math.IsRoughlyEqual(p.Z, -1, 1e-5) {
+		t.Errorf("Wrong near plane: %#v", p)
+	}
+	if p := project(Vec3{-4, 7, -f}); !math.IsRoughlyEqual(p.Z, 1, 1e-5) {
+		t.Errorf("Wrong far plane: %#v", p)
+	}
+	var o Mat4
+	o.SetToFrustum(l, r, b, tp, n, f)
+	if o != m {
+		t.Errorf("SetToFrustum differs: %#v", o)
+	}
+	if p := PerspectiveFrustum(l, r, b, tp, n, f); p != m {
+		t.Errorf("PerspectiveFrustum differs: %#v", p)
+	}
+	o.SetToPerspectiveFrustum(l, r, b, tp, n, f)
+	if o != m {
+		t.Errorf("SetToPerspectiveFrustum differs: %#v", o)
+	}
+}
+
+func TestFrustum_panics(t *testing.T) {
+	var m Mat4
+	for _, f := range []func(){
+		func() { Frustum(-1, 1, -1, 1, 0, 10) },
+		func() { Frustum(-1, 1, -1, 1, -0.1, 10) },
+		func() { Frustum(-1, 1, -1, 1, 1, 0) },
+		func() { Frustum(1, 1, -1, 1, 0.1, 10) },
+		func() { Frustum(-1, 1, 2, 2, 0.1, 10) },
+		func() { Frustum(-1, 1, -1, 1, 5, 5) },
+		func() { Frustum(-1, 1, -1, 1, math.NaN(), 10) },
+		func() { m.SetToFrustum(-1, 1, -1, 1, 0, 10) },
+		func() { PerspectiveFrustum(-1, 1, -1, 1, 0, 10) },
+		func() { Perspective(1, 1.5, 0, 10) },
+		func() { Perspective(0, 1.5, 0.1, 10) },
+		func() { m.SetToPerspective(1, 0, 0.1, 10) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("No panic on degenerate frustum")
+				}
+			}()
+			f()
+		}()
+	}
+	defer func() {
+		r := recover()
+		if s, ok := r.(string); !ok ||
+			s != "glam.Frustum: degenerate frustum (left 1, right 1, bottom -1, top 1, near 0.1, far 10)" {
+			t.Errorf("Wrong panic message: %#v", r)
+		}
+	}()
+	Frustum(1, 1, -1, 1, 0.1, 10)
+}
+
+func TestPerspective(t *testing.T) {
+	fov, aspect, n, f := float32(1.1), float32(16.0/9), float32(0.1), float32(100)
+	m := Perspective(fov, aspect, n, f)
+	top := n * math.Tan(fov/2)
+	right := top * aspect
+	if e := Frustum(-right, right, -top, top, n, f); m != e {
+		t.Errorf("Differs from symmetric frustum: %#v", m)
+	}
+	var o Mat4
+	o.SetToPerspective(fov, aspect, n, f)
+	if o != m {
+		t.Errorf("SetToPerspective differs: %#v", o)
+	}
+	if !math.IsRoughlyEqual(m[1][1], 1/math.Tan(fov/2), 1e-5) ||
+		!math.IsRoughlyEqual(m[0][0], m[1][1]/aspect, 1e-5) ||
+		m[2][0] != 0 || m[2][1] != 0 {
+		t.Errorf("Wrong result: %#v", m)
+	}
+}
+
+func TestPerspectiveReversedZ(t *testing.T) {
+	fov, aspect, n := float32(1.1), float32(16.0/9), float32(0.1)
+	m := PerspectiveReversedZ(fov, aspect, n)
+	depth := func(d float32) float32 {
+		return m.TimesVec4(Vec4{0.01, -0.02, -d, 1}).Dehomogenized().Z
+	}
+	if z := depth(n); !math.IsRoughlyEqual(z, 1, 1e-6) {
+		t.Errorf("Wrong depth at near plane: %v", z)
+	}
+	if z := depth(2 * n); !math.IsRoughlyEqual(z, 0.5, 1e-6) {
+		t.Errorf("Wrong depth at twice the near plane: %v", z)
+	}
+	prev := float32(2)
+	for d := n; d < 1e9; d *= 3 {
+		z := depth(d)
+		if z >= prev || z <= 0 {
+			t.Errorf("Depth not decreasing at distance %v: %v after %v", d, z, prev)
+		}
+		prev = z
+	}
+	if z := depth(1e9); !math.IsRoughlyEqual(z, 0, 1e-9) {
+		t.Errorf("Wrong asymptotic depth: %v", z)
+	}
+	e := Perspective(fov, aspect, n, 100)
+	if !math.IsRoughlyEqual(m[0][0], e[0][0], 1e-5) || !math.IsRoughlyEqual(m[1][1], e[1][1], 1e-5) {
+		t.Errorf("Wrong field of view: %#v", m)
+	}
+	var o Mat4
+	o.SetToPerspectiveReversedZ(fov, aspect, n)
+	if o != m {
+		t.Errorf("SetToPerspectiveReversedZ differs: %#v", o)
+	}
+}
+
+func TestPerspectiveZeroToOne(t *testing.T) {
+	fov, aspect, n, f := float32(1.1), float32(16.0/9), float32(0.1), float32(100)
+	m := PerspectiveZeroToOne(fov, aspect, n, f)
+	depth := func(d float32) float32 {
+		return m.TimesVec4(Vec4{0.01, -0.02, -d, 1}).Dehomogenized().Z
+	}
+	if z := depth(n); !math.IsRoughlyEqual(z, 0, 1e-6) {
+		t.Errorf("Wrong depth at near plane: %v", z)
+	}
+	if z, e := depth(2*n), f/(2*(f-n)); !math.IsRoughlyEqual(z, e, 1e-6) {
+		t.Errorf("Wrong depth at twice the near plane: %v instead of %v", z, e)
+	}
+	if z := depth(f); !math.IsRoughlyEqual(z, 1, 1e-6) {
+		t.Errorf("Wrong depth at far plane: %v", z)
+	}
+	// Far away the depth saturates, so it is only non-decreasing
+	prev := float32(-1)
+	for d := n; d < 1e9; d *= 3 {
+		z := depth(d)
+		if z < prev {
+			t.Errorf("Depth decreasing at distance %v: %v after %v", d, z, prev)
+		}
+		prev = z
+	}
+	if z, e := depth(1e9), f/(f-n); !math.IsRoughlyEqual(z, e, 1e-6) {
+		t.Errorf("Wrong asymptotic depth: %v instead of %v", z, e)
+	}
+	p := Perspective(fov, aspect, n, f)
+	q := p.TimesVec4(Vec4{0.3, 0.2, -5, 1}).Dehomogenized()
+	r := m.TimesVec4(Vec4{0.3, 0.2, -5, 1}).Dehomogenized()
+	if !math.IsRoughlyEqual(q.X, r.X, 1e-6) || !math.IsRoughlyEqual(q.Y, r.Y, 1e-6) ||
+		!math.IsRoughlyEqual((q.Z+1)/2, r.Z, 1e-5) {
+		t.Errorf("Differs from Perspective: %#v instead of %#v", r, q)
+	}
+	var o Mat4
+	o.SetToPerspectiveZeroToOne(fov, aspect, n, f)
+	if o != m {
+		t.Errorf("SetToPerspectiveZeroToOne differs: %#v", o)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestOrtho2D(t *testing.T) {
+	w, h := float32(640), float32(480)
+	project := func(m Mat4, p Vec2) Vec2 {
+		r := m.TimesVec4(Vec4{p.X, p.Y, 0, 1})
+		return Vec2{r.X, r.Y}
+	}
+	for _, c := range []struct {
+		p, down, up Vec2
+	}{
+		{Vec2{0, 0}, Vec2{-1, 1}, Vec2{-1, -1}},
+		{Vec2{w, 0}, Vec2{1, 1}, Vec2{1, -1}},
+		{Vec2{0, h}, Vec2{-1, -1}, Vec2{-1, 1}},
+		{Vec2{w, h}, Vec2{1, -1}, Vec2{1, 1}},
+		{Vec2{w / 2, h / 2}, Vec2{0, 0}, Vec2{0, 0}},
+		// Center of the first pixel
+		{Vec2{0.5, 0.5}, Vec2{-1 + 1/w, 1 - 1/h}, Vec2{-1 + 1/w, -1 + 1/h}},
+	} {
+		if p := project(Ortho2D(w, h), c.p); !isRoughlyEqualVec2(p, c.down, 1e-6) {
+			t.Errorf("Wrong result for %v: %#v instead of %#v", c.p, p, c.down)
+		}
+		if p := project(Ortho2DYUp(w, h), c.p); !isRoughlyEqualVec2(p, c.up, 1e-6) {
+			t.Errorf("Wrong Y-up result for %v: %#v instead of %#v", c.p, p, c.up)
+		}
+	}
+	if m := Ortho2D(w, h); !isRoughlyEqualMat4(m, OrthographicFrustum(0, w, h, 0, -1, 1), 1e-6) {
+		t.Errorf("Differs from OrthographicFrustum: %#v", m)
+	}
+	if m := Ortho2DYUp(w, h); !isRoughlyEqualMat4(m, OrthographicFrustum(0, w, 0, h, -1, 1), 1e-6) {
+		t.Errorf("Y-up differs from OrthographicFrustum: %#v", m)
+	}
+}
+
+func TestOrtho2DView(t *testing.T) {
+	w, h := float32(640), float32(480)
+	if m := Ortho2DView(Vec2{}, 1, w, h); m != Ortho2D(w, h) {
+		t.Errorf("Differs from Ortho2D: %#v", m)
+	}
+	o, s := Vec2{100, -50}, float32(4)
+	m, n := Ortho2DView(o, s, w, h), Ortho2D(w, h)
+	for _, p := range []Vec2{{0, 0}, {w, 0}, {0, h}, {w, h}, {w / 2, h / 2}, {0.5, 0.5}} {
+		// The world point shown at pixel p
+		q := o.Plus(p.Times(1 / s))
+		r := m.TimesVec4(Vec4{q.X, q.Y, 0, 1})
+		e := n.TimesVec4(Vec4{p.X, p.Y, 0, 1})
+		if !isRoughlyEqualVec4(r, e, 1e-5) {
+			t.Errorf("Wrong result for pixel %v: %#v instead of %#v", p, r, e)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestViewport(t *testing.T) {
+	x, y, w, h, n, f := float32(10), float32(20), float32(640), float32(480), float32(0.25), float32(0.75)
+	m := Viewport(x, y, w, h, n, f)
+	vp := Vec4{x, y, w, h}
+	for _, c := range []struct {
+		ndc, win Vec3
+	}{
+		{Vec3{-1, -1, -1}, Vec3{x, y, n}},
+		{Vec3{1, -1, -1}, Vec3{x + w, y, n}},
+		{Vec3{-1, 1, 1}, Vec3{x, y + h, f}},
+		{Vec3{1, 1, 1}, Vec3{x + w, y + h, f}},
+		{Vec3{0, 0, 0}, Vec3{x + w/2, y + h/2, (n + f) / 2}},
+	} {
+		p := m.TimesVec4(Vec4{c.ndc.X, c.ndc.Y, c.ndc.Z, 1})
+		if !isRoughlyEqualVec4(p, Vec4{c.win.X, c.win.Y, c.win.Z, 1}, 1e-6) {
+			t.Errorf("Wrong result for %v: %#v instead of %#v", c.ndc, p, c.win)
+		}
+		e := Vec3{c.win.X, c.win.Y, (c.ndc.Z + 1) / 2}
+		if q := NDCToWindow(c.ndc, vp); !isRoughlyEqualVec3(q, e, 1e-6) {
+			t.Errorf("Wrong NDCToWindow for %v: %#v instead of %#v", c.ndc, q, e)
+		}
+	}
+}
+
+func TestViewport_pipeline(t *testing.T) {
+	fov, aspect := float32(1.2), float32(640.0/480)
+	view := LookAt(Vec3{1, 2, 8}, Vec3{1, 2, 3}, Vec3{0, 1, 0})
+	proj := Perspective(fov, aspect, 0.1, 100)
+	vp := Viewport(0, 0, 640, 480, 0, 1)
+	m := proj.Times(&view)
+	m = vp.Times(&m)
+	// A point on the top edge of the field of view, 5 units in front of the eye
+	top := 5 * math.Tan(fov/2)
+	p := m.TimesVec4(Vec4{1, 2 + top, 3, 1}).Dehomogenized()
+	if !math.IsRoughlyEqual(p.X, 320, 1e-3) || !math.IsRoughlyEqual(p.Y, 480, 1e-3) {
+		t.Errorf("Wrong pixel: %#v", p)
+	}
+	if p.Z <= 0 || p.Z >= 1 {
+		t.Errorf("Wrong depth: %#v", p)
+	}
+	// The point looked at is at the center of the window
+	ndc := proj.Times(&view)
+	c := ndc.TimesVec4(Vec4{1, 2, 3, 1}).Dehomogenized()
+	if w := NDCToWindow(c, Vec4{0, 0, 640, 480}); !isRoughlyEqualVec3(w, Vec3{320, 240, p.Z}, 1e-3) {
+		t.Errorf("Wrong center: %#v", w)
+	}
+}
+
+func TestProject(t *testing.T) {
+	view := LookAt(Vec3{1, 2, 8}, Vec3{1, 2, 3}, Vec3{0, 1, 0})
+	proj := Perspective(1.2, 640.0/480, 0.1, 100)
+	vp := Vec4{10, 20, 640, 480}
+	p, ok := Project(Vec3{1, 2, 3}, view, proj, vp)
+	if !ok || !math.IsRoughlyEqual(p.X, 330, 1e-3) || !math.IsRoughlyEqual(p.Y, 260, 1e-3) {
+		t.Errorf("Wrong result for center of view: %#v, %v", p, ok)
+	}
+	if p.Z <= 0 || p.Z >= 1 {
+		t.Errorf("Wrong depth: %#v", p)
+	}
+	if _, ok := Project(Vec3{1, 2, 9}, view, proj, vp); ok {
+		t.Errorf("Point behind the camera not detected")
+	}
+	if _, ok := Project(Vec3{3, -1, 8}, view, proj, vp); ok {
+		t.Errorf("Point on the camera plane not detected")
+	}
+}
+
+func TestUnproject(t *testing.T) {
+	view := LookAt(Vec3{1, 2, 8}, Vec3{1, 2, 3}, Vec3{0, 1, 0})
+	proj := Perspective(1.2, 640.0/480, 0.1, 100)
+	vp := Vec4{10, 20, 640, 480}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		w := Vec3{r.Float32()*4 - 1, r.Float32() * 4, r.Float32()*4 - 2}
+		p, ok := Project(w, view, proj, vp)
+		if !ok {
+			t.Errorf("Point in front of the camera reported behind: %#v", w)
+			continue
+		}
+		q, ok := Unproject(p, view, proj, vp)
+		if !ok || !isRoughlyEqualVec3(q, w, 1e-3) {
+			t.Errorf("No round-trip for %#v: %#v, %v", w, q, ok)
+		}
+	}
+	if _, ok := Unproject(Vec3{0, 0, 0.5}, view, Zeros(), vp); ok {
+		t.Errorf("Singular matrix not detected")
+	}
+}
+
+func TestPickRay(t *testing.T) {
+	eye, center := Vec3{1, 2, 8}, Vec3{-1, 3, 3}
+	view := LookAt(eye, center, Vec3{0, 1, 0})
+	proj := Perspective(1.2, 640.0/480, 0.1, 100)
+	vp := Vec4{10, 20, 640, 480}
+	o, d, ok := PickRay(Vec2{330, 260}, view, proj, vp)
+	forward := center.Minus(eye).Normalized()
+	if !ok || !isRoughlyEqualVec3(d, forward, 1e-5) {
+		t.Errorf("Wrong direction through the center: %#v instead of %#v", d, forward)
+	}
+	if e := eye.Plus(forward.Times(0.1)); !isRoughlyEqualVec3(o, e, 1e-4) {
+		t.Errorf("Wrong origin: %#v instead of %#v", o, e)
+	}
+	// Any point on the ray projects to the same pixel
+	o, d, _ = PickRay(Vec2{100, 400}, view, proj, vp)
+	if p, _ := Project(o.Plus(d.Times(7)), view, proj, vp); !isRoughlyEqualVec2(Vec2{p.X, p.Y}, Vec2{100, 400}, 1e-2) {
+		t.Errorf("Wrong ray: %#v", p)
+	}
+	if _, _, ok := PickRay(Vec2{}, view, Zeros(), vp); ok {
+		t.Errorf("Singular matrix not detected")
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestFrustumCorners(t *testing.T) {
+	fov, aspect, n, f := float32(1.1), float32(1.5), float32(0.5), float32(50)
+	view := LookAt(Vec3{3, 1, -2}, Vec3{0, 2, 4}, Vec3{0, 1, 0})
+	proj := Perspective(fov, aspect, n, f)
+	vp := proj.Times(&view)
+	inv, _ := vp.Inverse()
+	tan := math.Tan(fov / 2)
+	// check verifies that, in view space, corner i is on the side planes at
+	// distance d
+	check := func(c [8]Vec3, i int, d float32) {
+		p := view.TimesVec4(Vec4{c[i].X, c[i].Y, c[i].Z, 1}).Dehomogenized()
+		sx, sy := float32(-1), float32(-1)
+		if i&1 != 0 {
+			sx = 1
+		}
+		if i&2 != 0 {
+			sy = 1
+		}
+		e := Vec3{sx * d * tan * aspect, sy * d * tan, -d}
+		if !isRoughlyEqualVec3(p, e, 1e-3*d) {
+			t.Errorf("Wrong corner %d at distance %v: %#v instead of %#v", i, d, p, e)
+		}
+	}
+	c := FrustumCorners(inv)
+	for i := range c {
+		d := n
+		if i&4 != 0 {
+			d = f
+		}
+		check(c, i, d)
+	}
+	if s := FrustumSliceCorners(inv, -1, 1); s != c {
+		t.Errorf("FrustumSliceCorners differs: %#v", s)
+	}
+	dn, df := float32(2), float32(10)
+	zn := proj.TimesVec4(Vec4{0, 0, -dn, 1}).Dehomogenized().Z
+	zf := proj.TimesVec4(Vec4{0, 0, -df, 1}).Dehomogenized().Z
+	s := FrustumSliceCorners(inv, zn, zf)
+	for i := range s {
+		d := dn
+		if i&4 != 0 {
+			d = df
+		}
+		check(s, i, d)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestLookAt(t *testing.T) {
+	isOrthonormal := func(m Mat4) bool {
+		r := m.Mat3()
+		p := r.Transposed()
+		p = p.Times(&r)
+		return isRoughlyEqualMat3(p, Mat3Identity(), 1e-5) && math.IsRoughlyEqual(r.Determinant(), 1, 1e-5)
+	}
+	transform := func(m Mat4, p Vec3) Vec3 {
+		return m.TimesVec4(p.Homogenized()).Dehomogenized()
+	}
+
+	eye, target := Vec3{1, 2, 3}, Vec3{-4, 0.5, 7}
+	m := LookAt(eye, target, Vec3{0, 1, 0})
+	if p := transform(m, eye); !isRoughlyEqualVec3(p, Vec3{}, 1e-5) {
+		t.Errorf("Eye not at origin: %#v", p)
+	}
+	d := target.Minus(eye).Length()
+	if p := transform(m, target); !isRoughlyEqualVec3(p, Vec3{0, 0, -d}, 1e-5) {
+		t.Errorf("Target not on -Z: %#v", p)
+	}
+	if p := transform(m, eye.Plus(Vec3{0, 1, 0})); p.Y <= 0 {
+		t.Errorf("Up not towards +Y: %#v", p)
+	}
+	if !isOrthonormal(m) {
+		t.Errorf("Not a rotation: %#v", m)
+	}
+
+	// Looking straight down, and straight up
+	for _, c := range []struct {
+		target, up, screenUp Vec3
+	}{
+		{Vec3{1, -5, 3}, Vec3{0, 1, 0}, Vec3{0, 0, -1}},
+		{Vec3{1, 9, 3}, Vec3{0, 1, 0}, Vec3{0, 0, 1}},
+		{Vec3{1 + 1e-5, -5, 3}, Vec3{0, 1, 0}, Vec3{0, 0, -1}},
+		{Vec3{1, 2, -3}, Vec3{0, 0, 1}, Vec3{0, -1, 0}},
+	} {
+		m := LookAt(eye, c.target, c.up)
+		for _, e := range m.Flat() {
+			if math.IsNaN(e) {
+				t.Fatalf("NaN for %#v: %#v", c, m)
+			}
+		}
+		if !isOrthonormal(m) {
+			t.Errorf("Not a rotation for %#v: %#v", c, m)
+		}
+		d := c.target.Minus(eye).Length()
+		if p := transform(m, c.target); !isRoughlyEqualVec3(p, Vec3{0, 0, -d}, 1e-4) {
+			t.Errorf("Target not on -Z for %#v: %#v", c, p)
+		}
+		if p := transform(m, eye.Plus(c.screenUp)); !isRoughlyEqualVec3(p, Vec3{0, 1, 0}, 1e-4) {
+			t.Errorf("Wrong fallback up for %#v: %#v", c, p)
+		}
+	}
+
+	// Degenerate: no view direction
+	if m := LookAt(eye, eye, Vec3{0, 1, 0}); m != Translation(eye.Inverse()) {
+		t.Errorf("Wrong result for eye == target: %#v", m)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestScaling(t *testing.T) {
+	m := Scaling(Vec3{2, 3, 4})
+	if v := m.TimesVec4(Vec4{1, 1, 1, 1}); v != (Vec4{2, 3, 4, 1}) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+	var o Mat4
+	o.SetToScaling(Vec3{2, 3, 4})
+	if o != m {
+		t.Errorf("SetToScaling differs: %#v", o)
+	}
+}
+
+func TestTRS(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		tr := Vec3{rnd.Float32()*20 - 10, rnd.Float32()*20 - 10, rnd.Float32()*20 - 10}
+		q := randomQuat(rnd)
+		s := Vec3{rnd.Float32()*4 - 2, rnd.Float32()*4 - 2, rnd.Float32()*4 - 2}
+		m := Translation(tr)
+		r := q.Mat3().Mat4()
+		sm := Scaling(s)
+		m = m.Times(&r)
+		m = m.Times(&sm)
+		if n := TRS(tr, q, s); !isRoughlyEqualMat4(n, m, 1e-5) {
+			t.Errorf("Differs from product: %#v instead of %#v", n, m)
+		}
+		var o Mat4
+		o.SetToTRS(tr, q, s)
+		if o != TRS(tr, q, s) {
+			t.Errorf("SetToTRS differs: %#v", o)
+		}
+	}
+}
+
+func BenchmarkTRS(b *testing.B) {
+	tr := Vec3{1, 2, 3}
+	q := QuatFromMat3(Rotation(0.7, Vec3{0, 0.6, 0.8}).Mat3())
+	s := Vec3{2, 0.5, 1}
+	var o Mat4
+	for i := 0; i < b.N; i++ {
+		o = TRS(tr, q, s)
+	}
+	_ = o
+}
+
+func BenchmarkTRS_product(b *testing.B) {
+	tr := Vec3{1, 2, 3}
+	q := QuatFromMat3(Rotation(0.7, Vec3{0, 0.6, 0.8}).Mat3())
+	s := Vec3{2, 0.5, 1}
+	var o Mat4
+	for i := 0; i < b.N; i++ {
+		o = Translation(tr)
+		r := q.Mat3().Mat4()
+		sm := Scaling(s)
+		o = o.Times(&r)
+		o = o.Times(&sm)
+	}
+	_ = o
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat4_Decompose(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		tr := Vec3{rnd.Float32()*20 - 10, rnd.Float32()*20 - 10, rnd.Float32()*20 - 10}
+		q := randomQuat(rnd)
+		s := Vec3{0.1 + rnd.Float32()*4, 0.1 + rnd.Float32()*4, 0.1 + rnd.Float32()*4}
+		if rnd.Intn(2) == 0 {
+			s.X = -s.X
+		}
+		if rnd.Intn(2) == 0 {
+			s.Y = -s.Y
+		}
+		if rnd.Intn(2) == 0 {
+			s.Z = -s.Z
+		}
+		m := TRS(tr, q, s)
+
+		dt, dr, ds, ok := m.Decompose()
+		if !ok {
+			t.Errorf("Failed for %#v", m)
+			continue
+		}
+		mirrored := s.X*s.Y*s.Z < 0
+		if (ds.X < 0) != mirrored || ds.Y <= 0 || ds.Z <= 0 {
+			t.Errorf("Mirror not folded into X: %#v for %#v", ds, s)
+		}
+		if d := dr.Determinant(); !math.IsRoughlyEqual(d, 1, 1e-5) {
+			t.Errorf("Rotation is not proper: %v", d)
+		}
+		n := Translation(dt)
+		r := dr.Mat4()
+		sm := Scaling(ds)
+		n = n.Times(&r)
+		n = n.Times(&sm)
+		if !isRoughlyEqualMat4(n, m, 1e-5) {
+			t.Errorf("No round-trip: %#v instead of %#v", n, m)
+		}
+		positive := s.X > 0 && s.Y > 0 && s.Z > 0
+		if positive && !isRoughlyEqualMat3(dr, q.Mat3(), 1e-5) {
+			t.Errorf("Wrong rotation: %#v", dr)
+		}
+	}
+
+	shear := Identity()
+	shear[1][0] = 0.5
+	if _, _, _, ok := shear.Decompose(); ok {
+		t.Errorf("Shear not detected")
+	}
+	if _, _, _, ok := Scaling(Vec3{1, 0, 2}).Decompose(); ok {
+		t.Errorf("Singular matrix not detected")
+	}
+	if _, _, _, ok := Perspective(1, 1, 0.1, 10).Decompose(); ok {
+		t.Errorf("Projection not detected")
+	}
+	tiny := TRS(Vec3{1, 2, 3}, QuatIdentity(), Vec3{1e-3, 1e-3, 1e-3})
+	if _, _, s, ok := tiny.Decompose(); !ok || !isRoughlyEqualVec3(s, Vec3{1e-3, 1e-3, 1e-3}, 1e-8) {
+		t.Errorf("Wrong result for small uniform scale: %#v", s)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat4_Translated(t *testing.T) {
+	p := Vec3{1, 2, 3}
+	s := Vec3{2, 0.5, -1}
+	axis := Vec3{1, -1, 2}.Normalized()
+
+	// Each method post-multiplies (local space)
+	m := Identity().Translated(p).RotatedY(0.7).Scaled(s)
+	tm, rm, sm := Translation(p), Rotation(0.7, Vec3{0, 1, 0}), Scaling(s)
+	e := tm.Times(&rm)
+	e = e.Times(&sm)
+	if !isRoughlyEqualMat4(m, e, 1e-6) {
+		t.Errorf("Wrong result: %#v instead of %#v", m, e)
+	}
+
+	base := TRS(Vec3{-4, 0, 1}, QuatFromMat3(Mat3RotationX(1.1)), Vec3{1, 2, 3})
+	for _, c := range []struct {
+		name string
+		m, o Mat4
+	}{
+		{"Translated", base.Translated(p), Translation(p)},
+		{"Scaled", base.Scaled(s), Scaling(s)},
+		{"RotatedX", base.RotatedX(0.3), Rotation(0.3, Vec3{1, 0, 0})},
+		{"RotatedY", base.RotatedY(-1.2), Rotation(-1.2, Vec3{0, 1, 0})},
+		{"RotatedZ", base.RotatedZ(2.5), Rotation(2.5, Vec3{0, 0, 1})},
+		{"RotatedAxis", base.RotatedAxis(axis, 0.9), Rotation(0.9, axis)},
+	} {
+		if e := base.Times(&c.o); !isRoughlyEqualMat4(c.m, e, 1e-5) {
+			t.Errorf("Wrong result for %s: %#v instead of %#v", c.name, c.m, e)
+		}
+	}
+
+	// The last call is applied first to points
+	m = Identity().Translated(Vec3{10, 0, 0}).RotatedZ(math.Pi / 2)
+	if v := m.TimesVec4(Vec4{1, 0, 0, 1}); !isRoughlyEqualVec4(v, Vec4{10, 1, 0, 1}, 1e-6) {
+		t.Errorf("Wrong order: %#v", v)
+	}
+	m = Identity().RotatedZ(math.Pi / 2).Translated(Vec3{10, 0, 0})
+	if v := m.TimesVec4(Vec4{1, 0, 0, 1}); !isRoughlyEqualVec4(v, Vec4{0, 11, 0, 1}, 1e-5) {
+		t.Errorf("Wrong order: %#v", v)
+	}
+}
+
+func TestMat4_Translate(t *testing.T) {
+	base := TRS(Vec3{-4, 0, 1}, QuatFromMat3(Mat3RotationX(1.1)), Vec3{1, 2, 3})
+	axis := Vec3{0, 0.6, 0.8}
+	m := base
+	m.Translate(Vec3{1, 2, 3})
+	m.RotateX(0.1)
+	m.RotateY(0.2)
+	m.RotateZ(0.3)
+	m.RotateAxis(axis, 0.4)
+	m.Scale(Vec3{2, 3, 4})
+	e := base.Translated(Vec3{1, 2, 3}).RotatedX(0.1).RotatedY(0.2).RotatedZ(0.3).
+		RotatedAxis(axis, 0.4).Scaled(Vec3{2, 3, 4})
+	if !isRoughlyEqualMat4(m, e, 1e-6) {
+		t.Errorf("In-place differs: %#v instead of %#v", m, e)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestShear(t *testing.T) {
+	var corners []Vec3
+	for i := 0; i < 8; i++ {
+		corners = append(corners, Vec3{float32(i & 1), float32(i >> 1 & 1), float32(i >> 2 & 1)})
+	}
+	k := float32(0.5)
+	for _, c := range []struct {
+		name string
+		m    Mat4
+		f    func(v Vec3) Vec3
+	}{
+		{"xy", Shear(k, 0, 0, 0, 0, 0), func(v Vec3) Vec3 { return Vec3{v.X + k*v.Y, v.Y, v.Z} }},
+		{"xz", Shear(0, k, 0, 0, 0, 0), func(v Vec3) Vec3 { return Vec3{v.X + k*v.Z, v.Y, v.Z} }},
+		{"yx", Shear(0, 0, k, 0, 0, 0), func(v Vec3) Vec3 { return Vec3{v.X, v.Y + k*v.X, v.Z} }},
+		{"yz", Shear(0, 0, 0, k, 0, 0), func(v Vec3) Vec3 { return Vec3{v.X, v.Y + k*v.Z, v.Z} }},
+		{"zx", Shear(0, 0, 0, 0, k, 0), func(v Vec3) Vec3 { return Vec3{v.X, v.Y, v.Z + k*v.X} }},
+		{"zy", Shear(0, 0, 0, 0, 0, k), func(v Vec3) Vec3 { return Vec3{v.X, v.Y, v.Z + k*v.Y} }},
+	} {
+		for _, p := range corners {
+			r := c.m.TimesVec4(p.Homogenized()).Dehomogenized()
+			if r != c.f(p) {
+				t.Errorf("Wrong result for %s shear of %#v: %#v", c.name, p, r)
+			}
+		}
+		if d := c.m.Determinant(); d != 1 {
+			t.Errorf("Wrong determinant for %s shear: %v", c.name, d)
+		}
+	}
+
+	m := Shear(1, 2, 3, 4, 5, 6)
+	var o Mat4
+	o.SetToShear(1, 2, 3, 4, 5, 6)
+	if o != m {
+		t.Errorf("SetToShear differs: %#v", o)
+	}
+	if n := Mat3Shear(1, 2, 3, 4, 5, 6); n != m.Mat3() {
+		t.Errorf("Mat3Shear differs: %#v", n)
+	}
+	if v := m.TimesVec4(Vec4{1, 1, 1, 1}); v != (Vec4{4, 8, 12, 1}) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestReflection(t *testing.T) {
+	// The plane y = 2
+	m := Reflection(Vec3{0, 1, 0}, -2)
+	if v := m.TimesVec4(Vec4{1, 5, 3, 1}); v != (Vec4{1, -1, 3, 1}) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := Vec3{rnd.Float32() - 0.5, rnd.Float32() - 0.5, rnd.Float32() - 0.5}.Normalized()
+		d := rnd.Float32()*10 - 5
+		m := Reflection(n, d)
+
+		if det := m.Determinant(); !math.IsRoughlyEqual(det, -1, 1e-5) {
+			t.Errorf("Wrong determinant: %v", det)
+		}
+		if mm := m.Times(&m); !isRoughlyEqualMat4(mm, Identity(), 1e-5) {
+			t.Errorf("Not its own inverse: %#v", mm)
+		}
+
+		// A point on the plane is unchanged
+		q := Vec3{rnd.Float32(), rnd.Float32(), rnd.Float32()}
+		q = q.Minus(n.Times(n.Dot(q) + d))
+		if r := m.TimesVec4(q.Homogenized()).Dehomogenized(); !isRoughlyEqualVec3(r, q, 1e-5) {
+			t.Errorf("Point on the plane moved: %#v instead of %#v", r, q)
+		}
+
+		// Other points go to the opposite side, at the same distance
+		p := Vec3{rnd.Float32()*10 - 5, rnd.Float32()*10 - 5, rnd.Float32()*10 - 5}
+		r := m.TimesVec4(p.Homogenized()).Dehomogenized()
+		if !math.IsRoughlyEqual(n.Dot(r)+d, -(n.Dot(p) + d), 1e-5) {
+			t.Errorf("Wrong reflection of %#v: %#v", p, r)
+		}
+		if rr := m.TimesVec4(r.Homogenized()).Dehomogenized(); !isRoughlyEqualVec3(rr, p, 1e-5) {
+			t.Errorf("No round-trip for %#v: %#v", p, rr)
+		}
+	}
+
+	var o Mat4
+	o.SetToReflection(Vec3{0.6, 0, 0.8}, 3)
+	if o != Reflection(Vec3{0.6, 0, 0.8}, 3) {
+		t.Errorf("SetToReflection differs: %#v", o)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestMat4_String(t *testing.T) {
+	if s, e := Identity().String(), "[1.000 0.000 0.000 0.000]\n[0.000 1.000 0.000 0.000]\n[0.000 0.000 1.000 0.000]\n[0.000 0.000 0.000 1.000]"; s != e {
+		t.Errorf("Wrong result for identity:\n%s\ninstead of:\n%s", s, e)
+	}
+	// Translation is in the last column
+	m := Translation(Vec3{12.5, -3, 0.25})
+	m[0][1] = -0.0625
+	e := "" +
+		"[ 1.00 0.00 0.00 12.50]\n" +
+		"[-0.06 1.00 0.00 -3.00]\n" +
+		"[ 0.00 0.00 1.00  0.25]\n" +
+		"[ 0.00 0.00 0.00  1.00]"
+	if s := m.StringPrec(2); s != e {
+		t.Errorf("Wrong result:\n%s\ninstead of:\n%s", s, e)
+	}
+	if s := fmt.Sprint(m); s != m.String() {
+		t.Errorf("Not a fmt.Stringer: %s", s)
+	}
+	var z Mat4
+	z[1][2] = float32(gomath.Copysign(0, -1))
+	if s, e := z.StringPrec(0), "[0 0 0 0]\n[0 0 0 0]\n[0 0 0 0]\n[0 0 0 0]"; s != e {
+		t.Errorf("Wrong result for negative zero:\n%s", s)
+	}
+}
+
+//------------------------------------------------------------------------------
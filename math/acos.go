@@ -0,0 +1,18 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import "math"
+
+//------------------------------------------------------------------------------
+
+// `Acos` returns the arccosine, in radians, of `x`.
+//
+// Special cases are the same as for the standard library `math.Acos` (in
+// particular, the result is NaN if `x` is outside [-1, 1]).
+func Acos(x float32) float32 {
+	return float32(math.Acos(float64(x)))
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,29 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestAcos(t *testing.T) {
+	for _, x := range []float32{-1, -0.75, -0.5, 0, 0.1, 0.5, 0.9999, 1} {
+		a := Acos(x)
+		b := float32(math.Acos(float64(x)))
+		if a != b {
+			t.Errorf("Wrong result for Acos(%v): %v instead of %v\n", x, a, b)
+		}
+	}
+	if Acos(1) != 0 || Acos(-1) != Pi {
+		t.Errorf("Wrong result at the bounds\n")
+	}
+	if !IsNaN(Acos(1.0001)) {
+		t.Errorf("Wrong result for Acos(1.0001)\n")
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,29 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestAsin(t *testing.T) {
+	for _, x := range []float32{-1, -0.75, -0.5, 0, 0.1, 0.5, 0.9999, 1} {
+		a := Asin(x)
+		b := float32(math.Asin(float64(x)))
+		if a != b {
+			t.Errorf("Wrong result for Asin(%v): %v instead of %v\n", x, a, b)
+		}
+	}
+	if Asin(0) != 0 || Asin(1) != Pi/2 || Asin(-1) != -Pi/2 {
+		t.Errorf("Wrong result at the bounds\n")
+	}
+	if !IsNaN(Asin(-1.0001)) {
+		t.Errorf("Wrong result for Asin(-1.0001)\n")
+	}
+}
+
+//------------------------------------------------------------------------------
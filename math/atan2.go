@@ -0,0 +1,18 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import "math"
+
+//------------------------------------------------------------------------------
+
+// `Atan2` returns the arctangent, in radians, of `y/x`, using the signs of the
+// two to determine the quadrant of the result (which is in [-Pi, Pi]).
+//
+// Special cases are the same as for the standard library `math.Atan2`.
+func Atan2(y, x float32) float32 {
+	return float32(math.Atan2(float64(y), float64(x)))
+}
+
+//------------------------------------------------------------------------------
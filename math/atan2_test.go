@@ -0,0 +1,28 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestAtan2(t *testing.T) {
+	for _, y := range []float32{-3, -1, -0.5, 0, 0.1, 1, 2.5} {
+		for _, x := range []float32{-2, -1, -0.1, 0, 0.5, 1, 4} {
+			a := Atan2(y, x)
+			b := float32(math.Atan2(float64(y), float64(x)))
+			if a != b {
+				t.Errorf("Wrong result for Atan2(%v, %v): %v instead of %v\n", y, x, a, b)
+			}
+		}
+	}
+	if Atan2(1, 0) != Pi/2 || Atan2(0, -1) != Pi || Atan2(-1, -1) != -3*Pi/4 {
+		t.Errorf("Wrong result for special angles\n")
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,20 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+//------------------------------------------------------------------------------
+
+// `Ceil` returns the nearest integer greater than or equal to `x`.
+//
+// Special cases are the same as for the standard library `math.Ceil`.
+func Ceil(x float32) float32 {
+	if !(Abs(x) < 1<<23) {
+		// Already an integer (or NaN, or an infinity); this also keeps the
+		// argument of Floor in the range of int32.
+		return x
+	}
+	return -Floor(-x)
+}
+
+//------------------------------------------------------------------------------
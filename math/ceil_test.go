@@ -0,0 +1,26 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestCeil(t *testing.T) {
+	for _, x := range []float32{-3.3, -3, -0.5, 0, 0.5, 3, 3.3, 1e10, -1e10, 8388607.5} {
+		a := Ceil(x)
+		b := float32(math.Ceil(float64(x)))
+		if a != b {
+			t.Errorf("Wrong result for Ceil(%v): %v instead of %v\n", x, a, b)
+		}
+	}
+	if !IsNaN(Ceil(NaN())) || !IsInf(Ceil(Inf(1)), 1) || !IsInf(Ceil(Inf(-1)), -1) {
+		t.Errorf("Wrong result for special values\n")
+	}
+}
+
+//------------------------------------------------------------------------------
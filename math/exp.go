@@ -0,0 +1,25 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import "math"
+
+//------------------------------------------------------------------------------
+
+// `Exp` returns `e**x`, the base-e exponential of `x`.
+//
+// Special cases are the same as for the standard library `math.Exp`.
+func Exp(x float32) float32 {
+	return float32(math.Exp(float64(x)))
+}
+
+// `Log` returns the natural logarithm of `x`.
+//
+// Special cases are the same as for the standard library `math.Log` (in
+// particular, the result is -Inf for 0, and NaN for negative values).
+func Log(x float32) float32 {
+	return float32(math.Log(float64(x)))
+}
+
+//------------------------------------------------------------------------------
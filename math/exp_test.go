@@ -0,0 +1,42 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestExp(t *testing.T) {
+	for _, x := range []float32{-10, -1, -0.5, 0, 0.1, 1, 2.5, 80} {
+		a := Exp(x)
+		b := float32(math.Exp(float64(x)))
+		if a != b {
+			t.Errorf("Wrong result for Exp(%v): %v instead of %v\n", x, a, b)
+		}
+	}
+	if Exp(0) != 1 || Exp(1) != E {
+		t.Errorf("Wrong result for special values\n")
+	}
+	if !IsInf(Exp(100), 1) {
+		t.Errorf("Wrong result for Exp(100)\n")
+	}
+}
+
+func TestLog(t *testing.T) {
+	for _, x := range []float32{1e-10, 0.1, 0.5, 1, 2, E, 10, 1e30} {
+		a := Log(x)
+		b := float32(math.Log(float64(x)))
+		if a != b {
+			t.Errorf("Wrong result for Log(%v): %v instead of %v\n", x, a, b)
+		}
+	}
+	if Log(1) != 0 || !IsInf(Log(0), -1) || !IsNaN(Log(-1)) {
+		t.Errorf("Wrong result for special values\n")
+	}
+}
+
+//------------------------------------------------------------------------------
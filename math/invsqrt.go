@@ -0,0 +1,22 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+//------------------------------------------------------------------------------
+
+// `InvSqrt` returns an approximation of `1/Sqrt(x)`, for a positive and
+// normal `x`.
+//
+// The relative error is less than 0.18%: this is intended for performance
+// critical code that can tolerate it (e.g. normalizing large batches of
+// vectors for shading). Use `1/Sqrt(x)` when accuracy matters.
+func InvSqrt(x float32) float32 {
+	// Initial estimate from the bit pattern, followed by one Newton-Raphson
+	// iteration. Magic constant from "Fast Inverse Square Root" by Chris
+	// Lomont.
+	y := Float32frombits(0x5f375a86 - Float32bits(x)>>1)
+	return y * (1.5 - 0.5*x*y*y)
+}
+
+//------------------------------------------------------------------------------
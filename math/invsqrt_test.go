@@ -0,0 +1,46 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestInvSqrt(t *testing.T) {
+	var worst float64
+	for x := float32(1e-30); x < 1e30; x *= 1.0137 {
+		a := float64(InvSqrt(x))
+		b := 1 / math.Sqrt(float64(x))
+		if e := math.Abs(a-b) / b; e > worst {
+			worst = e
+		}
+	}
+	if worst > 0.0018 {
+		t.Errorf("Relative error too large: %v\n", worst)
+	}
+	if InvSqrt(4) < 0.499 || InvSqrt(4) > 0.501 {
+		t.Errorf("Wrong result for InvSqrt(4): %v\n", InvSqrt(4))
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func BenchmarkInvSqrt(b *testing.B) {
+	a := float32(3.3)
+	for i := 0; i < b.N; i++ {
+		_ = InvSqrt(a)
+	}
+}
+
+func BenchmarkInvSqrt_sqrt(b *testing.B) {
+	a := float32(3.3)
+	for i := 0; i < b.N; i++ {
+		_ = 1 / Sqrt(a)
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,58 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+//------------------------------------------------------------------------------
+
+// `Min` returns the smaller of `x` and `y`.
+//
+// Unlike the standard library `math.Min`, there are no special cases: if
+// either argument is NaN, the result is unspecified.
+func Min(x, y float32) float32 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+// `Max` returns the larger of `x` and `y`.
+//
+// Unlike the standard library `math.Max`, there are no special cases: if
+// either argument is NaN, the result is unspecified.
+func Max(x, y float32) float32 {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+//------------------------------------------------------------------------------
+
+// `Clamp` returns `x` limited to the range [`min`, `max`] (as the GLSL
+// function of the same name).
+func Clamp(x, min, max float32) float32 {
+	if x < min {
+		return min
+	}
+	if x > max {
+		return max
+	}
+	return x
+}
+
+//------------------------------------------------------------------------------
+
+// `Saturate` returns `x` limited to the range [0, 1] (as the HLSL function
+// of the same name). This is the same as `Clamp(x, 0, 1)`.
+func Saturate(x float32) float32 {
+	if x < 0 {
+		return 0
+	}
+	if x > 1 {
+		return 1
+	}
+	return x
+}
+
+//------------------------------------------------------------------------------
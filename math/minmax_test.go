@@ -0,0 +1,48 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestMinMax(t *testing.T) {
+	for _, c := range []struct{ x, y float32 }{
+		{1, 2}, {2, 1}, {-1, 1}, {-3.5, -3.25}, {0, 0}, {1e30, -1e30},
+	} {
+		if a, b := Min(c.x, c.y), float32(math.Min(float64(c.x), float64(c.y))); a != b {
+			t.Errorf("Wrong result for Min(%v, %v): %v instead of %v\n", c.x, c.y, a, b)
+		}
+		if a, b := Max(c.x, c.y), float32(math.Max(float64(c.x), float64(c.y))); a != b {
+			t.Errorf("Wrong result for Max(%v, %v): %v instead of %v\n", c.x, c.y, a, b)
+		}
+	}
+}
+
+func TestClamp(t *testing.T) {
+	for _, c := range []struct{ x, r float32 }{
+		{-2, -1}, {-1, -1}, {0.5, 0.5}, {2, 2}, {2.5, 2},
+	} {
+		if r := Clamp(c.x, -1, 2); r != c.r {
+			t.Errorf("Wrong result for Clamp(%v, -1, 2): %v instead of %v\n", c.x, r, c.r)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestSaturate(t *testing.T) {
+	for _, c := range []struct{ x, r float32 }{
+		{-2, 0}, {0, 0}, {0.25, 0.25}, {1, 1}, {1.5, 1}, {Inf(1), 1}, {Inf(-1), 0},
+	} {
+		if r := Saturate(c.x); r != c.r || r != Clamp(c.x, 0, 1) {
+			t.Errorf("Wrong result for Saturate(%v): %v instead of %v\n", c.x, r, c.r)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
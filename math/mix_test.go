@@ -3,11 +3,38 @@
 
 package math
 
-import (
-//	"math"
-//	"testing"
-)
+import "testing"
 
-//TODO
+//------------------------------------------------------------------------------
+
+func TestMix(t *testing.T) {
+	for _, c := range [][4]float32{
+		{2, 6, 0, 2},
+		{2, 6, 1, 6},
+		{2, 6, 0.25, 3},
+		{2, 6, -0.5, 0},
+		{2, 6, 1.5, 8},
+		{-1, 1, 0.5, 0},
+	} {
+		if r := Mix(c[0], c[1], c[2]); r != c[3] {
+			t.Errorf("Wrong result for Mix(%v, %v, %v): %v instead of %v\n", c[0], c[1], c[2], r, c[3])
+		}
+	}
+}
+
+func TestStep(t *testing.T) {
+	for _, c := range [][3]float32{
+		{0.5, 0, 0},
+		{0.5, 0.4999, 0},
+		{0.5, 0.5, 1},
+		{0.5, 2, 1},
+		{-1, -2, 0},
+		{-1, -1, 1},
+	} {
+		if r := Step(c[0], c[1]); r != c[2] {
+			t.Errorf("Wrong result for Step(%v, %v): %v instead of %v\n", c[0], c[1], r, c[2])
+		}
+	}
+}
 
 //------------------------------------------------------------------------------
@@ -0,0 +1,19 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import "math"
+
+//------------------------------------------------------------------------------
+
+// `Mod` returns the floating-point remainder of `x/y`. The result has the
+// sign of `x`, and its magnitude is less than the magnitude of `y`.
+//
+// Special cases are the same as for the standard library `math.Mod`. See also
+// `Repeat`, whose result is always positive.
+func Mod(x, y float32) float32 {
+	return float32(math.Mod(float64(x), float64(y)))
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,28 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestMod(t *testing.T) {
+	for _, c := range []struct{ x, y float32 }{
+		{5.5, 2}, {-5.5, 2}, {5.5, -2}, {0.1, 0.03}, {3, 3}, {1e10, Pi},
+	} {
+		a := Mod(c.x, c.y)
+		b := float32(math.Mod(float64(c.x), float64(c.y)))
+		if a != b {
+			t.Errorf("Wrong result for Mod(%v, %v): %v instead of %v\n", c.x, c.y, a, b)
+		}
+	}
+	if Mod(-5.5, 2) != -1.5 || !IsNaN(Mod(1, 0)) {
+		t.Errorf("Wrong result for special values\n")
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,17 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import "math"
+
+//------------------------------------------------------------------------------
+
+// `Pow` returns `x**y`, the base-`x` exponential of `y`.
+//
+// Special cases are the same as for the standard library `math.Pow`.
+func Pow(x, y float32) float32 {
+	return float32(math.Pow(float64(x), float64(y)))
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,28 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import (
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestPow(t *testing.T) {
+	for _, c := range [][2]float32{
+		{2, 10}, {2, 0.5}, {0.5, 2.4}, {10, -3}, {0, 0}, {0, 2}, {1.5, 0}, {-2, 3},
+	} {
+		a := Pow(c[0], c[1])
+		b := float32(math.Pow(float64(c[0]), float64(c[1])))
+		if a != b {
+			t.Errorf("Wrong result for Pow(%v, %v): %v instead of %v\n", c[0], c[1], a, b)
+		}
+	}
+	if !IsNaN(Pow(-2, 0.5)) {
+		t.Errorf("Wrong result for Pow(-2, 0.5)\n")
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,26 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+//------------------------------------------------------------------------------
+
+// `Remap` returns `x` linearly mapped from the range [`inMin`, `inMax`] to the
+// range [`outMin`, `outMax`]: `inMin` gives `outMin`, and `inMax` gives
+// `outMax`. Either range may be inverted (i.e. with its min larger than its
+// max), but `inMin` and `inMax` must be different.
+//
+// The result is not clamped: values outside the input range are extrapolated.
+//
+// See also `RemapClamped`.
+func Remap(x, inMin, inMax, outMin, outMax float32) float32 {
+	return Mix(outMin, outMax, (x-inMin)/(inMax-inMin))
+}
+
+// `RemapClamped` is like `Remap`, but values outside the input range give the
+// nearest end of the output range.
+func RemapClamped(x, inMin, inMax, outMin, outMax float32) float32 {
+	return Mix(outMin, outMax, Saturate((x-inMin)/(inMax-inMin)))
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,43 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import "testing"
+
+//------------------------------------------------------------------------------
+
+func TestRemap(t *testing.T) {
+	for _, c := range []struct {
+		x, inMin, inMax, outMin, outMax float32
+		r, rc                           float32
+	}{
+		{5, 0, 10, 0, 1, 0.5, 0.5},
+		{0, 0, 10, 100, 200, 100, 100},
+		{10, 0, 10, 100, 200, 200, 200},
+		{-1, 0, 10, 0, 1, -0.1, 0},
+		{15, 0, 10, 0, 1, 1.5, 1},
+		// Inverted input range
+		{2, 10, 0, 0, 1, 0.8, 0.8},
+		{12, 10, 0, 0, 1, -0.2, 0},
+		{-5, 10, 0, 0, 1, 1.5, 1},
+		// Inverted output range
+		{2.5, 0, 10, 1, -1, 0.5, 0.5},
+		{20, 0, 10, 1, -1, -3, -1},
+		{-10, 0, 10, 1, -1, 3, 1},
+		// Both inverted
+		{7, 8, 4, 3, 1, 2.5, 2.5},
+		{9, 8, 4, 3, 1, 3.5, 3},
+	} {
+		if r := Remap(c.x, c.inMin, c.inMax, c.outMin, c.outMax); !IsRoughlyEqual(r, c.r, 1e-6) {
+			t.Errorf("Wrong result for Remap(%v, %v, %v, %v, %v): %v instead of %v\n",
+				c.x, c.inMin, c.inMax, c.outMin, c.outMax, r, c.r)
+		}
+		if r := RemapClamped(c.x, c.inMin, c.inMax, c.outMin, c.outMax); !IsRoughlyEqual(r, c.rc, 1e-6) {
+			t.Errorf("Wrong result for RemapClamped(%v, %v, %v, %v, %v): %v instead of %v\n",
+				c.x, c.inMin, c.inMax, c.outMin, c.outMax, r, c.rc)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
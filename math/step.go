@@ -0,0 +1,17 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+//------------------------------------------------------------------------------
+
+// `Step` returns 0 if `x` is less than `edge`, and 1 otherwise (as the GLSL
+// function of the same name).
+func Step(edge, x float32) float32 {
+	if x < edge {
+		return 0
+	}
+	return 1
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,56 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+//------------------------------------------------------------------------------
+
+// `Repeat` returns `x` modulo `length`, in [0, length) even for negative
+// values of `x` (this is the GLSL `mod` function, used for tiling).
+// `length` must be positive.
+//
+// See also `Wrap` and `PingPong`.
+func Repeat(x, length float32) float32 {
+	var r float32
+	if q := x / length; Abs(q) < 1<<23 {
+		r = x - length*Floor(q)
+	} else {
+		// Beyond the range of Floor; the remainder of such large values is
+		// exact in float32, and so is computed directly
+		r = Mod(x, length)
+		if r < 0 {
+			r += length
+		}
+	}
+	if r >= length {
+		// Rounding error for x slightly below a multiple of length
+		return 0
+	}
+	return r
+}
+
+// `Wrap` returns `x` wrapped into the range [min, max): the result differs
+// from `x` by a multiple of `max - min`. `max` must be greater than `min`.
+//
+// When `x` is slightly below `min` (or a multiple of the range below it), the
+// exact result may round to `max`; `min` is returned instead, as `Repeat` does.
+//
+// See also `Repeat`.
+func Wrap(x, min, max float32) float32 {
+	r := min + Repeat(x-min, max-min)
+	if r >= max {
+		return min
+	}
+	return r
+}
+
+// `PingPong` returns `x` folded back and forth into [0, length], i.e. a
+// triangle wave which is 0 at even multiples of `length` and `length` at odd
+// multiples. `length` must be positive.
+//
+// See also `Repeat`.
+func PingPong(x, length float32) float32 {
+	return length - Abs(Repeat(x, 2*length)-length)
+}
+
+//------------------------------------------------------------------------------
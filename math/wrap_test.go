@@ -0,0 +1,71 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package math
+
+import "testing"
+
+//------------------------------------------------------------------------------
+
+func TestRepeat(t *testing.T) {
+	for _, c := range [][3]float32{
+		{0, 1, 0},
+		{0.25, 1, 0.25},
+		{1, 1, 0},
+		{2.5, 1, 0.5},
+		{-0.25, 1, 0.75},
+		{-1, 1, 0},
+		{-3.5, 2, 0.5},
+		{7, 3, 1},
+		{-1e-9, 1, 0},
+		{1e10, 3, 1},
+		{-5e9, 7, 5},
+		{3e9, 1, 0},
+		{1 << 24, 0.75, 0.25},
+		{-(1 << 30), 10, 6},
+	} {
+		if r := Repeat(c[0], c[1]); r != c[2] {
+			t.Errorf("Wrong result for Repeat(%v, %v): %v instead of %v\n", c[0], c[1], r, c[2])
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	for _, c := range [][4]float32{
+		{0, -1, 1, 0},
+		{-1, -1, 1, -1},
+		{1, -1, 1, -1},
+		{1.5, -1, 1, -0.5},
+		{-1.5, -1, 1, 0.5},
+		{370, 0, 360, 10},
+		{-10, 0, 360, 350},
+		{5, 2, 4, 3},
+		{0.99999994, 1, 2, 1},
+		{1e10, 0, 3, 1},
+		{-5e9, 0, 7, 5},
+	} {
+		if r := Wrap(c[0], c[1], c[2]); r != c[3] {
+			t.Errorf("Wrong result for Wrap(%v, %v, %v): %v instead of %v\n", c[0], c[1], c[2], r, c[3])
+		}
+	}
+}
+
+func TestPingPong(t *testing.T) {
+	for _, c := range [][3]float32{
+		{0, 1, 0},
+		{0.25, 1, 0.25},
+		{1, 1, 1},
+		{1.25, 1, 0.75},
+		{2, 1, 0},
+		{3, 1, 1},
+		{-0.25, 1, 0.25},
+		{-1, 1, 1},
+		{5, 2, 1},
+	} {
+		if r := PingPong(c[0], c[1]); r != c[2] {
+			t.Errorf("Wrong result for PingPong(%v, %v): %v instead of %v\n", c[0], c[1], r, c[2])
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
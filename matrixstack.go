@@ -0,0 +1,85 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+//------------------------------------------------------------------------------
+
+// `MatrixStack` is a stack of matrices, in the style of the fixed-function
+// OpenGL pipeline. All operations apply to the top of the stack, and multiply
+// it on the right (i.e. they are applied to vertices before the ones already
+// on the stack).
+//
+// There is no depth limit, and popped storage is reused by later pushes. A
+// `MatrixStack` must be created with `NewMatrixStack`.
+type MatrixStack struct {
+	// The last matrix is the top of the stack
+	matrices []Mat4
+}
+
+// `NewMatrixStack` returns a stack containing only the identity matrix.
+func NewMatrixStack() *MatrixStack {
+	return &MatrixStack{matrices: []Mat4{Identity()}}
+}
+
+//------------------------------------------------------------------------------
+
+// `Push` duplicates the top of the stack.
+//
+// See also `Pop`.
+func (s *MatrixStack) Push() {
+	s.matrices = append(s.matrices, s.matrices[len(s.matrices)-1])
+}
+
+// `Pop` removes the top of the stack, restoring the matrix saved by the
+// matching `Push`. It panics if there is no such `Push` (i.e. it never removes
+// the last matrix).
+func (s *MatrixStack) Pop() {
+	if len(s.matrices) <= 1 {
+		panic("glam.MatrixStack.Pop: stack underflow (Pop without matching Push)")
+	}
+	s.matrices = s.matrices[:len(s.matrices)-1]
+}
+
+// `Depth` returns the number of `Push` not yet matched by a `Pop`.
+func (s *MatrixStack) Depth() int {
+	return len(s.matrices) - 1
+}
+
+//------------------------------------------------------------------------------
+
+// `Top` returns the matrix at the top of the stack.
+func (s *MatrixStack) Top() Mat4 {
+	return s.matrices[len(s.matrices)-1]
+}
+
+// `Load` replaces the top of the stack with `m`.
+func (s *MatrixStack) Load(m Mat4) {
+	s.matrices[len(s.matrices)-1] = m
+}
+
+// `Mult` multiplies the top of the stack on the right by `m`.
+func (s *MatrixStack) Mult(m *Mat4) {
+	t := &s.matrices[len(s.matrices)-1]
+	*t = t.Times(m)
+}
+
+// `Translate` multiplies the top of the stack on the right by a translation
+// matrix. See `Mat4.Translate`.
+func (s *MatrixStack) Translate(t Vec3) {
+	s.matrices[len(s.matrices)-1].Translate(t)
+}
+
+// `RotateAxis` multiplies the top of the stack on the right by a rotation of
+// `angle` around `axis`, which must be normalized. See `Mat4.RotateAxis`.
+func (s *MatrixStack) RotateAxis(axis Vec3, angle float32) {
+	s.matrices[len(s.matrices)-1].RotateAxis(axis, angle)
+}
+
+// `Scale` multiplies the top of the stack on the right by a scaling matrix.
+// See `Mat4.Scale`.
+func (s *MatrixStack) Scale(f Vec3) {
+	s.matrices[len(s.matrices)-1].Scale(f)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,92 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "testing"
+
+//------------------------------------------------------------------------------
+
+func TestMatrixStack(t *testing.T) {
+	s := NewMatrixStack()
+	if s.Top() != Identity() || s.Depth() != 0 {
+		t.Errorf("Wrong initial state: %#v", s.Top())
+	}
+
+	// Scene: a root, with two children, the first one having a child
+	axis := Vec3{0, 0.6, 0.8}
+	root := Translation(Vec3{10, 0, 0})
+	a := Translation(Vec3{0, 2, 0})
+	ar := Rotation(0.5, axis)
+	aa := Scaling(Vec3{2, 2, 2})
+	b := Translation(Vec3{-1, 0, 3})
+
+	s.Load(root)
+	s.Push()
+	{
+		s.Translate(Vec3{0, 2, 0})
+		s.RotateAxis(axis, 0.5)
+		p := a.Times(&ar)
+		e := root.Times(&p)
+		if !isRoughlyEqualMat4(s.Top(), e, 1e-6) {
+			t.Errorf("Wrong world matrix for A: %#v instead of %#v", s.Top(), e)
+		}
+		s.Push()
+		{
+			s.Scale(Vec3{2, 2, 2})
+			e = e.Times(&aa)
+			if !isRoughlyEqualMat4(s.Top(), e, 1e-6) {
+				t.Errorf("Wrong world matrix for child of A: %#v instead of %#v", s.Top(), e)
+			}
+			if s.Depth() != 2 {
+				t.Errorf("Wrong depth: %d", s.Depth())
+			}
+		}
+		s.Pop()
+	}
+	s.Pop()
+	s.Push()
+	{
+		s.Mult(&b)
+		if e := root.Times(&b); s.Top() != e {
+			t.Errorf("Wrong world matrix for B: %#v instead of %#v", s.Top(), e)
+		}
+	}
+	s.Pop()
+	if s.Top() != root || s.Depth() != 0 {
+		t.Errorf("Root not restored: %#v", s.Top())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("No panic on stack underflow")
+		}
+	}()
+	s.Pop()
+}
+
+func TestMatrixStack_storage(t *testing.T) {
+	s := NewMatrixStack()
+	for i := 0; i < 100; i++ {
+		s.Push()
+		s.Translate(Vec3{1, 0, 0})
+	}
+	if s.Top() != Translation(Vec3{100, 0, 0}) || s.Depth() != 100 {
+		t.Errorf("Wrong result: %#v", s.Top())
+	}
+	for i := 0; i < 100; i++ {
+		s.Pop()
+	}
+	if n := testing.AllocsPerRun(10, func() {
+		for i := 0; i < 100; i++ {
+			s.Push()
+		}
+		for i := 0; i < 100; i++ {
+			s.Pop()
+		}
+	}); n != 0 {
+		t.Errorf("Storage not reused: %v allocations", n)
+	}
+}
+
+//------------------------------------------------------------------------------
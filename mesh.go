@@ -0,0 +1,119 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "fmt"
+
+//------------------------------------------------------------------------------
+
+// `ComputeNormals` returns a smooth normal for each vertex of the triangle mesh
+// described by `positions` and `indices` (three indices per triangle, in
+// counter-clockwise order for front faces).
+//
+// Each vertex normal is the normalized sum of the face normals of the
+// triangles sharing it, weighted by their area. Degenerate triangles are
+// skipped, and vertices used by no (non-degenerate) triangle get the zero
+// vector.
+//
+// It panics if the length of `indices` is not a multiple of 3.
+//
+// See also `TriangleNormal`.
+func ComputeNormals(positions []Vec3, indices []uint32) []Vec3 {
+	if len(indices)%3 != 0 {
+		panic(fmt.Sprintf("glam.ComputeNormals: %d indices is not a multiple of 3", len(indices)))
+	}
+	normals := make([]Vec3, len(positions))
+	for t := 0; t < len(indices); t += 3 {
+		i0, i1, i2 := indices[t], indices[t+1], indices[t+2]
+		a := positions[i0]
+		// The length of the cross product is twice the area of the triangle
+		n := positions[i1].Minus(a).Cross(positions[i2].Minus(a))
+		if n == (Vec3{}) {
+			continue
+		}
+		normals[i0].Add(n)
+		normals[i1].Add(n)
+		normals[i2].Add(n)
+	}
+	for i := range normals {
+		if l := normals[i].LengthRobust(); l != 0 {
+			normals[i].Divide(l)
+		}
+	}
+	return normals
+}
+
+//------------------------------------------------------------------------------
+
+// `ComputeTangents` returns a tangent for each vertex of the triangle mesh
+// described by `positions`, `normals`, texture coordinates `uvs` and `indices`
+// (three indices per triangle), for use with tangent-space normal maps.
+//
+// The tangents are computed with Lengyel's method: the directions of
+// increasing U and V are accumulated over the triangles sharing each vertex,
+// then the U direction is made orthogonal to the (normalized) vertex normal
+// and normalized. The result is in the `XYZ` part of each tangent, and `W`
+// holds the handedness of the tangent space (1 or -1), so that the bitangent
+// is `W * normal × tangent`.
+//
+// Triangles whose texture coordinates are degenerate are skipped. Vertices
+// with no usable tangent get a zero `XYZ` part, with a `W` of 1.
+//
+// It panics if `normals` or `uvs` don't have the same length as `positions`,
+// or if the length of `indices` is not a multiple of 3.
+//
+// See also `ComputeNormals`.
+func ComputeTangents(positions []Vec3, normals []Vec3, uvs []Vec2, indices []uint32) (tangents []Vec4) {
+	if len(normals) != len(positions) || len(uvs) != len(positions) {
+		panic(fmt.Sprintf(
+			"glam.ComputeTangents: %d normals and %d uvs for %d positions",
+			len(normals), len(uvs), len(positions),
+		))
+	}
+	if len(indices)%3 != 0 {
+		panic(fmt.Sprintf("glam.ComputeTangents: %d indices is not a multiple of 3", len(indices)))
+	}
+
+	tan := make([]Vec3, len(positions))
+	bitan := make([]Vec3, len(positions))
+	for t := 0; t < len(indices); t += 3 {
+		i0, i1, i2 := indices[t], indices[t+1], indices[t+2]
+		e1 := positions[i1].Minus(positions[i0])
+		e2 := positions[i2].Minus(positions[i0])
+		d1 := uvs[i1].Minus(uvs[i0])
+		d2 := uvs[i2].Minus(uvs[i0])
+		det := d1.Cross(d2)
+		if det == 0 {
+			continue
+		}
+		// Solve e1 = d1.X*s + d1.Y*b and e2 = d2.X*s + d2.Y*b
+		s := e1.Times(d2.Y).Minus(e2.Times(d1.Y)).Slash(det)
+		b := e2.Times(d1.X).Minus(e1.Times(d2.X)).Slash(det)
+		for _, i := range [3]uint32{i0, i1, i2} {
+			tan[i].Add(s)
+			bitan[i].Add(b)
+		}
+	}
+
+	tangents = make([]Vec4, len(positions))
+	for i := range tangents {
+		n := normals[i]
+		if l := n.LengthRobust(); l != 0 {
+			n = n.Slash(l)
+		}
+		// Gram-Schmidt orthogonalization
+		t := tan[i].Minus(n.Times(n.Dot(tan[i])))
+		w := float32(1)
+		if n.Cross(t).Dot(bitan[i]) < 0 {
+			w = -1
+		}
+		if l := t.LengthRobust(); l != 0 {
+			t = t.Slash(l)
+		}
+		tangents[i] = Vec4{t.X, t.Y, t.Z, w}
+	}
+	return tangents
+}
+
+//------------------------------------------------------------------------------
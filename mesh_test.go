@@ -0,0 +1,156 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+// `cubeMesh` returns an indexed unit cube centered on the origin, with shared
+// vertices and counter-clockwise faces seen from outside.
+func cubeMesh() ([]Vec3, []uint32) {
+	positions := make([]Vec3, 8)
+	for i := range positions {
+		positions[i] = Vec3{
+			float32(i&1) - 0.5,
+			float32(i>>1&1) - 0.5,
+			float32(i>>2&1) - 0.5,
+		}
+	}
+	indices := []uint32{
+		0, 2, 3, 0, 3, 1, // -Z
+		4, 5, 7, 4, 7, 6, // +Z
+		0, 4, 6, 0, 6, 2, // -X
+		1, 3, 7, 1, 7, 5, // +X
+		0, 1, 5, 0, 5, 4, // -Y
+		2, 6, 7, 2, 7, 3, // +Y
+	}
+	return positions, indices
+}
+
+func TestComputeNormals(t *testing.T) {
+	positions, indices := cubeMesh()
+	normals := ComputeNormals(positions, indices)
+	if len(normals) != len(positions) {
+		t.Fatalf("Wrong number of normals: %d", len(normals))
+	}
+	for i, n := range normals {
+		if !math.IsRoughlyEqual(n.Length(), 1, 1e-6) {
+			t.Errorf("Normal %d not unit length: %#v", i, n)
+		}
+		if n.Dot(positions[i]) <= 0 {
+			t.Errorf("Normal %d not pointing outward: %#v", i, n)
+		}
+	}
+	// These two corners are shared by two triangles of each of their faces
+	for _, i := range []int{0, 7} {
+		if e := positions[i].Normalized(); !isRoughlyEqualVec3(normals[i], e, 1e-6) {
+			t.Errorf("Wrong normal for %v: %#v instead of %#v", positions[i], normals[i], e)
+		}
+	}
+
+	// Flat quad, with a degenerate triangle and an unused vertex
+	positions = []Vec3{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0}, {5, 5, 5}}
+	indices = []uint32{0, 1, 2, 0, 2, 3, 0, 1, 1}
+	normals = ComputeNormals(positions, indices)
+	for i := 0; i < 4; i++ {
+		if normals[i] != (Vec3{0, 0, 1}) {
+			t.Errorf("Wrong normal for quad vertex %d: %#v", i, normals[i])
+		}
+	}
+	if normals[4] != (Vec3{}) {
+		t.Errorf("Wrong normal for unused vertex: %#v", normals[4])
+	}
+}
+
+func TestComputeNormals_areaWeighted(t *testing.T) {
+	// Two triangles sharing vertex 0: a large one facing +Z, a small one
+	// facing +X.
+	positions := []Vec3{{0, 0, 0}, {4, 0, 0}, {0, 4, 0}, {0, 1, 0}, {0, 0, 1}}
+	indices := []uint32{0, 1, 2, 0, 3, 4}
+	n := ComputeNormals(positions, indices)[0]
+	if e := (Vec3{1, 0, 16}).Normalized(); !isRoughlyEqualVec3(n, e, 1e-6) {
+		t.Errorf("Wrong result: %#v instead of %#v", n, e)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestComputeTangents(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	// Quads on four faces of a cube, each textured with U and V along its
+	// edges; the second one has mirrored texture coordinates.
+	var positions, normals []Vec3
+	var uvs []Vec2
+	var indices []uint32
+	var expected []Vec4
+	faces := [][3]Vec3{
+		// normal, U direction, V direction
+		{{0, 0, 1}, {1, 0, 0}, {0, 1, 0}},
+		{{0, 0, -1}, {1, 0, 0}, {0, 1, 0}},
+		{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+		{{-1, 0, 0}, {0, 0, 1}, {0, 1, 0}},
+	}
+	for _, f := range faces {
+		n, u, v := f[0], f[1], f[2]
+		w := n.Cross(u).Dot(v)
+		base := uint32(len(positions))
+		for c := 0; c < 4; c++ {
+			cu, cv := float32(c&1), float32(c>>1)
+			p := n.Times(0.5).Plus(u.Times(cu - 0.5)).Plus(v.Times(cv - 0.5))
+			positions = append(positions, p)
+			// Slightly perturbed normals, as for a smoothed mesh
+			normals = append(normals, n.Plus(Vec3{r.Float32(), r.Float32(), r.Float32()}.Times(0.2)))
+			uvs = append(uvs, Vec2{cu, cv})
+			expected = append(expected, Vec4{u.X, u.Y, u.Z, w})
+		}
+		if w > 0 {
+			indices = append(indices, base, base+1, base+3, base, base+3, base+2)
+		} else {
+			indices = append(indices, base, base+3, base+1, base, base+2, base+3)
+		}
+	}
+
+	tangents := ComputeTangents(positions, normals, uvs, indices)
+	if len(tangents) != len(positions) {
+		t.Fatalf("Wrong number of tangents: %d", len(tangents))
+	}
+	for i, tg := range tangents {
+		v := Vec3{tg.X, tg.Y, tg.Z}
+		n := normals[i].Normalized()
+		if d := v.Dot(n); math.Abs(d) > 1e-6 {
+			t.Errorf("Tangent %d not orthogonal to normal: %#v (dot %v)", i, tg, d)
+		}
+		if !math.IsRoughlyEqual(v.Length(), 1, 1e-6) {
+			t.Errorf("Tangent %d not unit length: %#v", i, tg)
+		}
+		if tg.W != expected[i].W {
+			t.Errorf("Wrong handedness for tangent %d: %#v instead of %#v", i, tg, expected[i])
+		}
+		if e := (Vec3{expected[i].X, expected[i].Y, expected[i].Z}); v.Dot(e) < 0.9 {
+			t.Errorf("Wrong direction for tangent %d: %#v instead of %#v", i, tg, expected[i])
+		}
+	}
+
+	// Degenerate texture coordinates
+	tangents = ComputeTangents(
+		[]Vec3{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+		[]Vec3{{0, 0, 1}, {0, 0, 1}, {0, 0, 1}},
+		[]Vec2{{0, 0}, {1, 1}, {2, 2}},
+		[]uint32{0, 1, 2},
+	)
+	for i, tg := range tangents {
+		if tg != (Vec4{0, 0, 0, 1}) {
+			t.Errorf("Wrong tangent %d for degenerate uvs: %#v", i, tg)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -4,6 +4,8 @@
 package noise
 
 import (
+	"math/rand"
+
 	"github.com/drakmaniso/glam"
 	"github.com/drakmaniso/glam/math"
 )
@@ -62,8 +64,15 @@ func perlinFade(x float32) float32 {
 
 //------------------------------------------------------------------------------
 
-// `Perlin3DAt` returns the value of a 3D Perlin noise function at position `p`.
+// `Perlin3DAt` returns the value of a 3D Perlin noise function at position
+// `p`, in [-1, 1].
+//
+// See also `Perlin3D` for a seeded noise function.
 func Perlin3DAt(p glam.Vec3) float32 {
+	return perlin3D(&perlinPermutation, p)
+}
+
+func perlin3D(perm *[512]int32, p glam.Vec3) float32 {
 	// Source: "Simplex Noise Demystified" by Stefan Gustavson
 	// http://www.itn.liu.se/~stegu/simplexnoise/simplexnoise.pdf
 
@@ -83,14 +92,14 @@ func Perlin3DAt(p glam.Vec3) float32 {
 	iz &= 0xFF
 
 	// Set of gradient indices
-	g000 := perlinPermutation[ix+perlinPermutation[iy+perlinPermutation[iz]]] % 12
-	g001 := perlinPermutation[ix+perlinPermutation[iy+perlinPermutation[iz+1]]] % 12
-	g010 := perlinPermutation[ix+perlinPermutation[iy+1+perlinPermutation[iz]]] % 12
-	g011 := perlinPermutation[ix+perlinPermutation[iy+1+perlinPermutation[iz+1]]] % 12
-	g100 := perlinPermutation[ix+1+perlinPermutation[iy+perlinPermutation[iz]]] % 12
-	g101 := perlinPermutation[ix+1+perlinPermutation[iy+perlinPermutation[iz+1]]] % 12
-	g110 := perlinPermutation[ix+1+perlinPermutation[iy+1+perlinPermutation[iz]]] % 12
-	g111 := perlinPermutation[ix+1+perlinPermutation[iy+1+perlinPermutation[iz+1]]] % 12
+	g000 := perm[ix+perm[iy+perm[iz]]] % 12
+	g001 := perm[ix+perm[iy+perm[iz+1]]] % 12
+	g010 := perm[ix+perm[iy+1+perm[iz]]] % 12
+	g011 := perm[ix+perm[iy+1+perm[iz+1]]] % 12
+	g100 := perm[ix+1+perm[iy+perm[iz]]] % 12
+	g101 := perm[ix+1+perm[iy+perm[iz+1]]] % 12
+	g110 := perm[ix+1+perm[iy+1+perm[iz]]] % 12
+	g111 := perm[ix+1+perm[iy+1+perm[iz+1]]] % 12
 
 	// Noise contribution for each corner
 	n000 := perlinGradient[g000].Dot(glam.Vec3{rx, ry, rz})
@@ -124,3 +133,29 @@ func Perlin3DAt(p glam.Vec3) float32 {
 }
 
 //------------------------------------------------------------------------------
+
+// `Perlin3D` is a 3D Perlin noise function whose permutation table is
+// generated from a seed.
+type Perlin3D struct {
+	permutation [512]int32
+}
+
+// `NewPerlin3D` returns a Perlin noise function for `seed`. The same seed
+// always gives the same noise function.
+func NewPerlin3D(seed int64) *Perlin3D {
+	var n Perlin3D
+	for i, v := range rand.New(rand.NewSource(seed)).Perm(256) {
+		n.permutation[i] = int32(v)
+		n.permutation[i+256] = int32(v)
+	}
+	return &n
+}
+
+// `At` returns the value of the noise function at position `p`, in [-1, 1].
+//
+// See also `Perlin3DAt`.
+func (n *Perlin3D) At(p glam.Vec3) float32 {
+	return perlin3D(&n.permutation, p)
+}
+
+//------------------------------------------------------------------------------
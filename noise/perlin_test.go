@@ -5,11 +5,13 @@ package noise
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 )
 
 import (
 	"github.com/drakmaniso/glam"
+	"github.com/drakmaniso/glam/math"
 )
 
 //------------------------------------------------------------------------------
@@ -214,3 +216,36 @@ func TestPerlin3DAt(t *testing.T) {
 }
 
 //------------------------------------------------------------------------------
+
+func TestPerlin3D(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a, b, c := NewPerlin3D(42), NewPerlin3D(42), NewPerlin3D(43)
+	same := true
+	for i := 0; i < 10000; i++ {
+		p := glam.Vec3{r.Float32()*200 - 100, r.Float32()*200 - 100, r.Float32()*200 - 100}
+		v := a.At(p)
+		if v < -1 || v > 1 {
+			t.Errorf("Out of range at %v: %v", p, v)
+		}
+		if w := b.At(p); w != v {
+			t.Errorf("Not reproducible at %v: %v and %v", p, v, w)
+		}
+		if c.At(p) != v {
+			same = false
+		}
+		// The gradient is bounded, so small steps make small changes
+		d := glam.Vec3{r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5}.Times(1e-3)
+		if w := a.At(p.Plus(d)); math.Abs(w-v) > 1e-2 {
+			t.Errorf("Not continuous at %v: %v then %v", p, v, w)
+		}
+	}
+	if same {
+		t.Errorf("Different seeds give the same noise")
+	}
+	// Zero on the lattice, like Perlin3DAt
+	if v := a.At(glam.Vec3{3, -7, 12}); v != 0 {
+		t.Errorf("Wrong result on lattice point: %v", v)
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,79 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "fmt"
+
+//------------------------------------------------------------------------------
+
+// `NormalTransformer` transforms surface normals by a matrix. It precomputes
+// the normal matrix once, for use on many normals.
+//
+// Normals cannot be transformed like directions: under a non-uniform scale,
+// they would no longer be perpendicular to the surface. They must be
+// transformed by the inverse-transpose of the linear part of the matrix.
+type NormalTransformer struct {
+	normal Mat3
+}
+
+// `NewNormalTransformer` returns a transformer for the normals of a surface
+// transformed by `m`. The translation and projection parts of `m` are
+// ignored.
+//
+// The normal matrix is computed as the cofactor matrix of the upper-left 3x3
+// part of `m` (i.e. its inverse-transpose multiplied by the determinant, whose
+// sign is kept). Since the normals are renormalized, this gives the same
+// result, but also works when the matrix flattens the surface.
+func NewNormalTransformer(m Mat4) NormalTransformer {
+	c0 := Vec3{m[0][0], m[0][1], m[0][2]}
+	c1 := Vec3{m[1][0], m[1][1], m[1][2]}
+	c2 := Vec3{m[2][0], m[2][1], m[2][2]}
+	n0, n1, n2 := c1.Cross(c2), c2.Cross(c0), c0.Cross(c1)
+	if c0.Dot(n0) < 0 {
+		// Negative determinant: flip, to match the inverse-transpose
+		n0, n1, n2 = n0.Inverse(), n1.Inverse(), n2.Inverse()
+	}
+	return NormalTransformer{
+		normal: Mat3{
+			{n0.X, n0.Y, n0.Z},
+			{n1.X, n1.Y, n1.Z},
+			{n2.X, n2.Y, n2.Z},
+		},
+	}
+}
+
+// `Transform` returns the normal `n` transformed and renormalized.
+func (t *NormalTransformer) Transform(n Vec3) Vec3 {
+	return t.normal.TimesVec3(n).Normalized()
+}
+
+// `TransformNormals` sets each `dst[i]` to the normal `src[i]` transformed and
+// renormalized.
+//
+// `dst` and `src` must have the same length, otherwise nothing is written
+// and an error is returned. They may be the same slice, for in-place
+// transformation.
+func (t *NormalTransformer) TransformNormals(dst, src []Vec3) error {
+	if len(dst) != len(src) {
+		return fmt.Errorf("glam.NormalTransformer.TransformNormals: %d destinations for %d sources", len(dst), len(src))
+	}
+	for i, n := range src {
+		dst[i] = t.normal.TimesVec3(n).Normalized()
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// `TransformNormal` returns the surface normal `n` transformed by `m` (using
+// the inverse-transpose of its linear part) and renormalized.
+//
+// This computes the normal matrix every time: to transform many normals, use
+// a `NormalTransformer`.
+func (m *Mat4) TransformNormal(n Vec3) Vec3 {
+	t := NewNormalTransformer(*m)
+	return t.Transform(n)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,94 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func TestMat4_TransformNormal(t *testing.T) {
+	m := Scaling(Vec3{1, 2, 1})
+	tangent := Vec3{1, 1, 0}.Normalized()
+	normal := Vec3{1, -1, 0}.Normalized()
+	tt := m.TimesVec4(Vec4{tangent.X, tangent.Y, tangent.Z, 0})
+	tm := Vec3{tt.X, tt.Y, tt.Z}
+	if naive := m.TimesVec4(Vec4{normal.X, normal.Y, normal.Z, 0}); math.Abs(tm.Dot(Vec3{naive.X, naive.Y, naive.Z})) < 0.1 {
+		t.Errorf("Naive transformation unexpectedly works")
+	}
+	n := m.TransformNormal(normal)
+	if math.Abs(tm.Dot(n)) > 1e-6 || !math.IsRoughlyEqual(n.Length(), 1, 1e-6) {
+		t.Errorf("Wrong normal: %#v", n)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m := randomAffineMat4(r)
+		a := Vec3{r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5}
+		b := Vec3{r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5}
+		n := a.Cross(b).Normalized()
+		ta, tb := m.TimesVec4(Vec4{a.X, a.Y, a.Z, 0}), m.TimesVec4(Vec4{b.X, b.Y, b.Z, 0})
+		// The normal of the transformed surface, on the same side
+		e := Vec3{ta.X, ta.Y, ta.Z}.Cross(Vec3{tb.X, tb.Y, tb.Z}).Normalized()
+		if m.Mat3().Determinant() < 0 {
+			e = e.Inverse()
+		}
+		if tn := m.TransformNormal(n); !isRoughlyEqualVec3(tn, e, 1e-3) {
+			t.Errorf("Wrong normal for %#v: %#v instead of %#v", m, tn, e)
+		}
+	}
+}
+
+func TestNormalTransformer(t *testing.T) {
+	// Mirroring keeps the normals on the same side of the surface
+	m := Scaling(Vec3{-1, 1, 1})
+	nt := NewNormalTransformer(m)
+	if n := nt.Transform(Vec3{1, 0, 0}); n != (Vec3{-1, 0, 0}) {
+		t.Errorf("Wrong normal for mirror: %#v", n)
+	}
+	// Flattening still gives a usable normal
+	m = Scaling(Vec3{1, 0, 1})
+	nt = NewNormalTransformer(m)
+	if n := nt.Transform(Vec3{0.6, 0.8, 0}); n != (Vec3{0, 1, 0}) {
+		t.Errorf("Wrong normal for flattening: %#v", n)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	m = randomAffineMat4(r)
+	nt = NewNormalTransformer(m)
+	src := make([]Vec3, 50)
+	for i := range src {
+		src[i] = Vec3{r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5}.Normalized()
+	}
+	dst := make([]Vec3, len(src))
+	nt.TransformNormals(dst, src)
+	for i := range src {
+		if e := m.TransformNormal(src[i]); dst[i] != e {
+			t.Errorf("Wrong result: %#v instead of %#v", dst[i], e)
+		}
+	}
+	nt.TransformNormals(src, src)
+	for i := range src {
+		if src[i] != dst[i] {
+			t.Errorf("Wrong in-place result: %#v instead of %#v", src[i], dst[i])
+		}
+	}
+	short := make([]Vec3, 3)
+	if err := nt.TransformNormals(short, src); err == nil ||
+		err.Error() != "glam.NormalTransformer.TransformNormals: 3 destinations for 50 sources" {
+		t.Errorf("Wrong error on length mismatch: %v", err)
+	}
+	for i := range short {
+		if short[i] != (Vec3{}) {
+			t.Errorf("Destination written despite length mismatch: %#v", short)
+			break
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,114 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//------------------------------------------------------------------------------
+
+// `ParseVec3` returns the vector whose components are written in `s`,
+// separated by whitespace or by commas (e.g. "1.0 2.0 3.0" or "1, 2, 3"). The
+// components may be enclosed in braces, brackets or parentheses, so the
+// default formatting of a `Vec3` (e.g. "{1 2 3}") is accepted.
+//
+// See also `Vec3.Scan`.
+func ParseVec3(s string) (Vec3, error) {
+	var c [3]float32
+	if err := parseComponents("ParseVec3", s, c[:]); err != nil {
+		return Vec3{}, err
+	}
+	return Vec3{c[0], c[1], c[2]}, nil
+}
+
+// `parseComponents` parses the components in `s` into `c`, whose length is the
+// exact number of components expected.
+func parseComponents(function, s string, c []float32) error {
+	s = strings.TrimSpace(s)
+	for _, p := range []string{"{}", "[]", "()"} {
+		if len(s) >= 2 && s[0] == p[0] && s[len(s)-1] == p[1] {
+			s = s[1 : len(s)-1]
+			break
+		}
+	}
+	fields := strings.Fields(strings.Replace(s, ",", " , ", -1))
+	n := 0
+	// A comma is only allowed between two components
+	comma := false
+	for i, f := range fields {
+		if f == "," {
+			if !comma || i == len(fields)-1 {
+				return fmt.Errorf("glam.%s: unexpected comma in %q", function, s)
+			}
+			comma = false
+			continue
+		}
+		if n == len(c) {
+			return fmt.Errorf("glam.%s: too many components in %q", function, s)
+		}
+		x, err := strconv.ParseFloat(f, 32)
+		if err != nil {
+			return fmt.Errorf("glam.%s: invalid component %q", function, f)
+		}
+		c[n] = float32(x)
+		n++
+		comma = true
+	}
+	if n < len(c) {
+		return fmt.Errorf("glam.%s: %d components instead of %d in %q", function, n, len(c), s)
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// `Scan` implements `fmt.Scanner`, so that `fmt.Sscan` and similar functions
+// can read a `Vec3`. It reads three components separated by whitespace or by
+// commas, as `ParseVec3` (but without enclosing braces). The verbs 'v', 'e',
+// 'f' and 'g' (and their upper case variants) are accepted.
+func (a *Vec3) Scan(state fmt.ScanState, verb rune) error {
+	switch verb {
+	case 'v', 'e', 'E', 'f', 'F', 'g', 'G':
+	default:
+		return fmt.Errorf("glam.Vec3.Scan: invalid verb %%%c", verb)
+	}
+	var c [3]float32
+	for i := range c {
+		state.SkipSpace()
+		if i > 0 {
+			// Optional comma
+			r, _, err := state.ReadRune()
+			if err != nil {
+				return fmt.Errorf("glam.Vec3.Scan: missing component %d: %v", i, err)
+			}
+			if r == ',' {
+				state.SkipSpace()
+			} else {
+				state.UnreadRune()
+			}
+		}
+		tok, err := state.Token(false, func(r rune) bool {
+			return r != ',' && !unicode.IsSpace(r)
+		})
+		if err != nil {
+			return fmt.Errorf("glam.Vec3.Scan: %v", err)
+		}
+		if len(tok) == 0 {
+			return fmt.Errorf("glam.Vec3.Scan: missing component %d", i)
+		}
+		x, err := strconv.ParseFloat(string(tok), 32)
+		if err != nil {
+			return fmt.Errorf("glam.Vec3.Scan: invalid component %q", tok)
+		}
+		c[i] = float32(x)
+	}
+	*a = Vec3{c[0], c[1], c[2]}
+	return nil
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,84 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestParseVec3(t *testing.T) {
+	for _, s := range []string{
+		"1 -2.5 3e2",
+		"1.0 -2.5 300.0",
+		"  1\t-2.5 \n 300  ",
+		"1,-2.5,300",
+		"1, -2.5 ,300",
+		"1 -2.5, 300",
+		"{1 -2.5 300}",
+		"(1, -2.5, 300)",
+		" [1 -2.5 300] ",
+		fmt.Sprint(Vec3{1, -2.5, 300}),
+	} {
+		v, err := ParseVec3(s)
+		if err != nil || v != (Vec3{1, -2.5, 300}) {
+			t.Errorf("Wrong result for %q: %#v, %v", s, v, err)
+		}
+	}
+	for _, c := range []struct {
+		s, token string
+	}{
+		{"", "0 components"},
+		{"1 2", "2 components"},
+		{"1 2 3 4", "too many"},
+		{"1 x 3", `"x"`},
+		{"1 2 3.0.1", `"3.0.1"`},
+		{"1,,2,3", "comma"},
+		{",1 2 3", "comma"},
+		{"1 2 3,", "comma"},
+		{"{1 2 3", `"{1"`},
+		{"1 2 1e99", `"1e99"`},
+	} {
+		v, err := ParseVec3(c.s)
+		if err == nil {
+			t.Errorf("No error for %q: %#v", c.s, v)
+			continue
+		}
+		if !strings.Contains(err.Error(), c.token) {
+			t.Errorf("Unclear error for %q: %v", c.s, err)
+		}
+	}
+}
+
+func TestVec3_Scan(t *testing.T) {
+	var v, w Vec3
+	var n int
+	if _, err := fmt.Sscan("1 2 3 4, 5,6 7", &v, &w, &n); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if v != (Vec3{1, 2, 3}) || w != (Vec3{4, 5, 6}) || n != 7 {
+		t.Errorf("Wrong result: %#v, %#v, %d", v, w, n)
+	}
+	if _, err := fmt.Sscanf("v=0.5,-1,2e3", "v=%g", &v); err != nil || v != (Vec3{0.5, -1, 2000}) {
+		t.Errorf("Wrong result: %#v, %v", v, err)
+	}
+	// Round-trip through the default formatting
+	e := Vec3{0.1, -2, 3.25}
+	if _, err := fmt.Sscan(strings.Trim(fmt.Sprint(e), "{}"), &v); err != nil || v != e {
+		t.Errorf("No round-trip for %#v: %#v, %v", e, v, err)
+	}
+	for _, s := range []string{"1 2", "1 x 3", "1,,2 3"} {
+		if _, err := fmt.Sscan(s, &v); err == nil {
+			t.Errorf("No error for %q: %#v", s, v)
+		}
+	}
+	if _, err := fmt.Sscanf("1 2 3", "%d", &v); err == nil {
+		t.Errorf("No error for invalid verb")
+	}
+}
+
+//------------------------------------------------------------------------------
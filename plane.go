@@ -0,0 +1,64 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+//------------------------------------------------------------------------------
+
+// `Plane` is the set of points `p` such that `Normal.Dot(p) + D == 0`.
+// `Normal` must be normalized, so that `-D` is the signed distance from the
+// origin to the plane.
+type Plane struct {
+	Normal Vec3
+	D      float32
+}
+
+// `PlaneFromPointNormal` returns the plane going through `point` and
+// perpendicular to `normal`, which must be normalized.
+func PlaneFromPointNormal(point, normal Vec3) Plane {
+	return Plane{Normal: normal, D: -normal.Dot(point)}
+}
+
+// `PlaneFromPoints` returns the plane going through `a`, `b` and `c`. The
+// normal points towards the side from which the three points are in
+// counter-clockwise order.
+//
+// The points must not be aligned.
+func PlaneFromPoints(a, b, c Vec3) Plane {
+	n := b.Minus(a).Cross(c.Minus(a)).Normalized()
+	return PlaneFromPointNormal(a, n)
+}
+
+//------------------------------------------------------------------------------
+
+// `SignedDistance` returns the distance from `p` to the plane, positive on the
+// side the normal points to, and negative on the other side.
+func (pl Plane) SignedDistance(p Vec3) float32 {
+	return pl.Normal.Dot(p) + pl.D
+}
+
+//------------------------------------------------------------------------------
+
+// `ReflectPoint` returns the mirror image of the point `p` across the plane.
+//
+// See also `ReflectVector` and `ReflectionMatrix`.
+func (pl Plane) ReflectPoint(p Vec3) Vec3 {
+	return p.Minus(pl.Normal.Times(2 * pl.SignedDistance(p)))
+}
+
+// `ReflectVector` returns the mirror image of the vector `v` across the plane,
+// i.e. ignoring the position of the plane (e.g. for directions or normals).
+//
+// See also `ReflectPoint`.
+func (pl Plane) ReflectVector(v Vec3) Vec3 {
+	return v.Minus(pl.Normal.Times(2 * pl.Normal.Dot(v)))
+}
+
+// `ReflectionMatrix` returns the matrix mirroring points across the plane,
+// e.g. to render planar reflections. It is the same as `Reflection(pl.Normal,
+// pl.D)`.
+func (pl Plane) ReflectionMatrix() Mat4 {
+	return Reflection(pl.Normal, pl.D)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,61 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func TestPlaneFromPoints(t *testing.T) {
+	pl := PlaneFromPoints(Vec3{0, 0, 2}, Vec3{1, 0, 2}, Vec3{0, 1, 2})
+	if pl.Normal != (Vec3{0, 0, 1}) || pl.D != -2 {
+		t.Errorf("Wrong result: %#v", pl)
+	}
+	if d := pl.SignedDistance(Vec3{5, -3, 7}); d != 5 {
+		t.Errorf("Wrong distance: %v", d)
+	}
+	if d := pl.SignedDistance(Vec3{5, -3, -1}); d != -3 {
+		t.Errorf("Wrong distance: %v", d)
+	}
+	if pl2 := PlaneFromPointNormal(Vec3{7, 8, 2}, Vec3{0, 0, 1}); pl2 != pl {
+		t.Errorf("Wrong result: %#v", pl2)
+	}
+}
+
+func TestPlane_ReflectPoint(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := Vec3{r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5}.Normalized()
+		pl := Plane{n, r.Float32()*4 - 2}
+		p := Vec3{r.Float32()*10 - 5, r.Float32()*10 - 5, r.Float32()*10 - 5}
+		q := pl.ReflectPoint(p)
+		if !math.IsRoughlyEqual(pl.SignedDistance(q), -pl.SignedDistance(p), 1e-5) {
+			t.Errorf("Not equidistant: %v and %v", pl.SignedDistance(p), pl.SignedDistance(q))
+		}
+		if m := p.Plus(q).Times(0.5); !math.IsRoughlyEqual(pl.SignedDistance(m), 0, 1e-5) {
+			t.Errorf("Middle not on the plane: %#v", m)
+		}
+		if back := pl.ReflectPoint(q); !isRoughlyEqualVec3(back, p, 1e-5) {
+			t.Errorf("Reflecting twice gives %#v instead of %#v", back, p)
+		}
+		m := pl.ReflectionMatrix()
+		if e := m.TimesVec4(Vec4{p.X, p.Y, p.Z, 1}); !isRoughlyEqualVec3(Vec3{e.X, e.Y, e.Z}, q, 1e-5) {
+			t.Errorf("Wrong matrix: %#v instead of %#v", e, q)
+		}
+		v := pl.ReflectVector(p)
+		if e := m.TimesVec4(Vec4{p.X, p.Y, p.Z, 0}); !isRoughlyEqualVec3(Vec3{e.X, e.Y, e.Z}, v, 1e-5) {
+			t.Errorf("Wrong vector: %#v instead of %#v", v, e)
+		}
+		if back := pl.ReflectVector(v); !isRoughlyEqualVec3(back, p, 1e-5) {
+			t.Errorf("Reflecting twice gives %#v instead of %#v", back, p)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,69 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+//------------------------------------------------------------------------------
+
+// `SignedArea2D` returns the area of `polygon`, positive if its vertices are
+// in counter-clockwise order, and negative if they are clockwise. The polygon
+// is implicitly closed (the last vertex is joined to the first one).
+//
+// The polygon must not self-intersect; otherwise, the parts wound in opposite
+// directions cancel out.
+func SignedArea2D(polygon []Vec2) float32 {
+	if len(polygon) < 3 {
+		return 0
+	}
+	// Shoelace formula, relative to the first vertex for better precision
+	var a float32
+	o := polygon[0]
+	for i := 2; i < len(polygon); i++ {
+		a += polygon[i-1].Minus(o).Cross(polygon[i].Minus(o))
+	}
+	return a / 2
+}
+
+// `IsConvex2D` returns true if `polygon` is convex, in either winding order.
+// The polygon is implicitly closed.
+//
+// Collinear consecutive edges are allowed, but polygons with fewer than 3
+// vertices, or with all their vertices aligned, are not convex. Neither are
+// self-intersecting polygons (e.g. a star).
+func IsConvex2D(polygon []Vec2) bool {
+	n := len(polygon)
+	if n < 3 {
+		return false
+	}
+	var sign, firstX, lastX float32
+	xChanges := 0
+	prev := polygon[0].Minus(polygon[n-1])
+	for i := range polygon {
+		next := polygon[(i+1)%n].Minus(polygon[i])
+		c := prev.Cross(next)
+		switch {
+		case c > 0 && sign < 0, c < 0 && sign > 0:
+			return false
+		case c != 0:
+			sign = c
+		}
+		// A convex polygon turns only once, so the X direction of its edges
+		// changes at most twice
+		if next.X != 0 {
+			if lastX*next.X < 0 {
+				xChanges++
+			}
+			if firstX == 0 {
+				firstX = next.X
+			}
+			lastX = next.X
+		}
+		prev = next
+	}
+	if lastX*firstX < 0 {
+		xChanges++
+	}
+	return sign != 0 && xChanges <= 2
+}
+
+//------------------------------------------------------------------------------
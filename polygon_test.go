@@ -0,0 +1,54 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "testing"
+
+//------------------------------------------------------------------------------
+
+func TestSignedArea2D(t *testing.T) {
+	ccw := []Vec2{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	cw := []Vec2{{0, 0}, {0, 2}, {2, 2}, {2, 0}}
+	// An L shape, counter-clockwise
+	l := []Vec2{{0, 0}, {3, 0}, {3, 1}, {1, 1}, {1, 2}, {0, 2}}
+	for _, c := range []struct {
+		p []Vec2
+		a float32
+	}{
+		{ccw, 4},
+		{cw, -4},
+		{l, 4},
+		{[]Vec2{{10, 10}, {11, 10}, {10, 11}}, 0.5},
+		{[]Vec2{{0, 0}, {1, 1}, {2, 2}}, 0},
+		{[]Vec2{{0, 0}, {1, 1}}, 0},
+		{nil, 0},
+	} {
+		if a := SignedArea2D(c.p); a != c.a {
+			t.Errorf("Wrong area for %v: %v instead of %v", c.p, a, c.a)
+		}
+	}
+}
+
+func TestIsConvex2D(t *testing.T) {
+	for _, c := range []struct {
+		p  []Vec2
+		ok bool
+	}{
+		{[]Vec2{{0, 0}, {2, 0}, {2, 2}, {0, 2}}, true},                  // CCW square
+		{[]Vec2{{0, 0}, {0, 2}, {2, 2}, {2, 0}}, true},                  // CW square
+		{[]Vec2{{0, 0}, {1, 0}, {2, 0}, {2, 2}, {0, 2}}, true},          // collinear edges
+		{[]Vec2{{0, 0}, {3, 0}, {3, 1}, {1, 1}, {1, 2}, {0, 2}}, false}, // L shape
+		{[]Vec2{{0, 0}, {2, 1}, {4, 0}, {2, 4}}, false},                 // arrow head
+		{[]Vec2{{0, 0}, {1, 1}, {2, 2}}, false},                         // aligned
+		{[]Vec2{{0, 0}, {1, 1}}, false},                                 // too few
+		// Pentagram: every turn is in the same direction
+		{[]Vec2{{0, 10}, {6, -8}, {-9.5, 3}, {9.5, 3}, {-6, -8}}, false},
+	} {
+		if ok := IsConvex2D(c.p); ok != c.ok {
+			t.Errorf("Wrong result for %v: %v", c.p, ok)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,51 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+//------------------------------------------------------------------------------
+
+// `PolylineLength` returns the total length of the polyline through `points`,
+// i.e. the sum of the lengths of its segments.
+//
+// See also `PointAtArcLength`.
+func PolylineLength(points []Vec3) float32 {
+	var l float32
+	for i := 1; i < len(points); i++ {
+		l += points[i].Minus(points[i-1]).Length()
+	}
+	return l
+}
+
+// `PointAtArcLength` returns the point at `distance` from the start of the
+// polyline through `points`, measured along the polyline, and true; or, if
+// `distance` is negative or greater than the length of the polyline, the zero
+// value and false.
+//
+// Calling it with regularly spaced distances gives points moving at constant
+// speed along the polyline. Each call walks the segments from the start, so
+// for long polylines it is better to accumulate the segment lengths once.
+//
+// See also `PolylineLength`.
+func PointAtArcLength(points []Vec3, distance float32) (Vec3, bool) {
+	if len(points) == 0 || distance < 0 {
+		return Vec3{}, false
+	}
+	for i := 1; i < len(points); i++ {
+		l := points[i].Minus(points[i-1]).Length()
+		if distance <= l {
+			if l == 0 {
+				return points[i], true
+			}
+			return points[i-1].Mix(points[i], distance/l), true
+		}
+		distance -= l
+	}
+	// Tolerate the rounding errors of the subtractions
+	if distance <= 1e-6*PolylineLength(points) {
+		return points[len(points)-1], true
+	}
+	return Vec3{}, false
+}
+
+//------------------------------------------------------------------------------
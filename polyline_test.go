@@ -0,0 +1,81 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func TestPolylineLength(t *testing.T) {
+	points := []Vec3{{0, 0, 0}, {3, 4, 0}, {3, 4, 2}, {3, 4, 2}, {0, 0, 2}}
+	if l := PolylineLength(points); l != 12 {
+		t.Errorf("Wrong length: %v", l)
+	}
+	if l := PolylineLength(points[:1]); l != 0 {
+		t.Errorf("Wrong length for a single point: %v", l)
+	}
+	if l := PolylineLength(nil); l != 0 {
+		t.Errorf("Wrong length for no point: %v", l)
+	}
+}
+
+func TestPointAtArcLength(t *testing.T) {
+	// Straight polyline with uneven segments
+	line := []Vec3{{1, 2, 3}, {1.5, 2, 3}, {4, 2, 3}, {11, 2, 3}}
+	if p, ok := PointAtArcLength(line, PolylineLength(line)/2); !ok || p != (Vec3{6, 2, 3}) {
+		t.Errorf("Wrong midpoint: %#v, %v", p, ok)
+	}
+	for i := 0; i <= 20; i++ {
+		d := float32(i) / 2
+		p, ok := PointAtArcLength(line, d)
+		if !ok || !isRoughlyEqualVec3(p, Vec3{1 + d, 2, 3}, 1e-6) {
+			t.Errorf("Wrong result at %v: %#v, %v", d, p, ok)
+		}
+	}
+
+	points := []Vec3{{0, 0, 0}, {3, 4, 0}, {3, 4, 2}, {3, 4, 2}, {0, 0, 2}}
+	for _, c := range []struct {
+		d float32
+		p Vec3
+	}{
+		{0, Vec3{0, 0, 0}},
+		{2.5, Vec3{1.5, 2, 0}},
+		{5, Vec3{3, 4, 0}},
+		{6, Vec3{3, 4, 1}},
+		{7, Vec3{3, 4, 2}},
+		{9.5, Vec3{1.5, 2, 2}},
+		{12, Vec3{0, 0, 2}},
+	} {
+		if p, ok := PointAtArcLength(points, c.d); !ok || !isRoughlyEqualVec3(p, c.p, 1e-6) {
+			t.Errorf("Wrong result at %v: %#v, %v", c.d, p, ok)
+		}
+	}
+	for _, d := range []float32{-0.1, 12.1, math.Inf(1)} {
+		if p, ok := PointAtArcLength(points, d); ok || p != (Vec3{}) {
+			t.Errorf("Wrong result at %v: %#v, %v", d, p, ok)
+		}
+	}
+	if p, ok := PointAtArcLength(points[:1], 0); !ok || p != points[0] {
+		t.Errorf("Wrong result for a single point: %#v, %v", p, ok)
+	}
+	if _, ok := PointAtArcLength(nil, 0); ok {
+		t.Errorf("Wrong result for no point")
+	}
+
+	// The end is reached despite rounding errors
+	var curve []Vec3
+	for i := 0; i <= 100; i++ {
+		a := float32(i) / 100 * math.Pi
+		curve = append(curve, Vec3{math.Cos(a), math.Sin(a), 0.1 * float32(i)})
+	}
+	if p, ok := PointAtArcLength(curve, PolylineLength(curve)); !ok || !isRoughlyEqualVec3(p, curve[100], 1e-5) {
+		t.Errorf("End not reached: %#v, %v", p, ok)
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,489 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"fmt"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+// `Quat` is a single-precision quaternion. `W` is the scalar part, and `X`,
+// `Y`, `Z` the vector part.
+//
+// Quaternions used to represent rotations must be normalized.
+type Quat struct {
+	X float32
+	Y float32
+	Z float32
+	W float32
+}
+
+//------------------------------------------------------------------------------
+
+// `QuatIdentity` returns the identity quaternion (i.e. no rotation).
+func QuatIdentity() Quat {
+	return Quat{0, 0, 0, 1}
+}
+
+// `QuatFromAxisAngle` returns the quaternion rotating counter-clockwise by
+// `angle` (in radians) around `axis`. The axis is normalized, so it only needs
+// to be non-zero.
+//
+// See also `Quat.AxisAngle`.
+func QuatFromAxisAngle(axis Vec3, angle float32) Quat {
+	a := axis.Normalized()
+	s := math.Sin(angle / 2)
+	return Quat{a.X * s, a.Y * s, a.Z * s, math.Cos(angle / 2)}
+}
+
+// `AxisAngle` returns the normalized axis and the angle (in radians, in [0,
+// 2*Pi]) of the rotation `q`, such that `QuatFromAxisAngle` rebuilds it. `q`
+// does not need to be normalized, but must be non-zero.
+//
+// If the rotation is the identity (angle 0 or 2*Pi), the axis is arbitrary:
+// the X axis is returned.
+func (q Quat) AxisAngle() (axis Vec3, angle float32) {
+	v := Vec3{q.X, q.Y, q.Z}
+	l := v.LengthRobust()
+	// More accurate than math.Acos(q.W) near the identity
+	angle = 2 * math.Atan2(l, q.W)
+	if l == 0 {
+		return Vec3{1, 0, 0}, angle
+	}
+	return v.Slash(l), angle
+}
+
+//------------------------------------------------------------------------------
+
+// `Times` returns the Hamilton product of `a` and `b`. For rotations, the
+// result rotates by `b` first, then by `a`.
+//
+// See also `Multiply`.
+func (a Quat) Times(b Quat) Quat {
+	return Quat{
+		a.W*b.X + a.X*b.W + a.Y*b.Z - a.Z*b.Y,
+		a.W*b.Y - a.X*b.Z + a.Y*b.W + a.Z*b.X,
+		a.W*b.Z + a.X*b.Y - a.Y*b.X + a.Z*b.W,
+		a.W*b.W - a.X*b.X - a.Y*b.Y - a.Z*b.Z,
+	}
+}
+
+// `Multiply` sets `a` to the Hamilton product of `a` and `b`.
+//
+// More efficient than `Times`.
+func (a *Quat) Multiply(b Quat) {
+	a.X, a.Y, a.Z, a.W =
+		a.W*b.X+a.X*b.W+a.Y*b.Z-a.Z*b.Y,
+		a.W*b.Y-a.X*b.Z+a.Y*b.W+a.Z*b.X,
+		a.W*b.Z+a.X*b.Y-a.Y*b.X+a.Z*b.W,
+		a.W*b.W-a.X*b.X-a.Y*b.Y-a.Z*b.Z
+}
+
+//------------------------------------------------------------------------------
+
+// `Conjugate` returns the conjugate of `a`, which for a normalized quaternion
+// is the opposite rotation.
+//
+// See also `Inverse`.
+func (a Quat) Conjugate() Quat {
+	return Quat{-a.X, -a.Y, -a.Z, a.W}
+}
+
+// `Inverse` returns the multiplicative inverse of `a`, i.e. its conjugate
+// divided by its squared norm. `a` must be non-zero.
+//
+// For a normalized quaternion, `Conjugate` gives the same result and is
+// cheaper.
+//
+// See also `Invert`.
+func (a Quat) Inverse() Quat {
+	n := a.X*a.X + a.Y*a.Y + a.Z*a.Z + a.W*a.W
+	return Quat{-a.X / n, -a.Y / n, -a.Z / n, a.W / n}
+}
+
+// `Invert` sets `a` to its multiplicative inverse. `a` must be non-zero.
+//
+// More efficient than `Inverse`.
+func (a *Quat) Invert() {
+	n := a.X*a.X + a.Y*a.Y + a.Z*a.Z + a.W*a.W
+	a.X /= -n
+	a.Y /= -n
+	a.Z /= -n
+	a.W /= n
+}
+
+// `Rotate` returns the vector `v` rotated by `q`, which must be normalized.
+func (q Quat) Rotate(v Vec3) Vec3 {
+	u := Vec3{q.X, q.Y, q.Z}
+	t := u.Cross(v)
+	t = Vec3{2 * t.X, 2 * t.Y, 2 * t.Z}
+	c := u.Cross(t)
+	return Vec3{
+		v.X + q.W*t.X + c.X,
+		v.Y + q.W*t.Y + c.Y,
+		v.Z + q.W*t.Z + c.Z,
+	}
+}
+
+// `RotateSlice` sets each `dst[i]` to `src[i]` rotated by `q`, which must be
+// normalized. `dst` and `src` must have the same length, otherwise nothing is
+// written and an error is returned; they may be the same slice.
+//
+// For more than a few vectors, this is faster than calling `Rotate` on each of
+// them, as the rotation is converted to a matrix once.
+func (q Quat) RotateSlice(dst, src []Vec3) error {
+	if len(dst) != len(src) {
+		return fmt.Errorf("glam.Quat.RotateSlice: %d destinations for %d sources", len(dst), len(src))
+	}
+	m := q.Mat3()
+	for i, v := range src {
+		dst[i] = Vec3{
+			m[0][0]*v.X + m[1][0]*v.Y + m[2][0]*v.Z,
+			m[0][1]*v.X + m[1][1]*v.Y + m[2][1]*v.Z,
+			m[0][2]*v.X + m[1][2]*v.Y + m[2][2]*v.Z,
+		}
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// `Integrated` returns the orientation `q` (which must be normalized) after
+// rotating for `dt` seconds at the constant `angularVelocity` (in radians per
+// second, expressed in world space: its direction is the axis of rotation,
+// and its length the angular speed).
+//
+// The rotation is computed exactly with the exponential map, rather than with
+// the usual first-order approximation, and the result is renormalized, so
+// that it stays a unit quaternion over many steps.
+//
+// See also `Integrate`.
+func (q Quat) Integrated(angularVelocity Vec3, dt float32) Quat {
+	h := angularVelocity.Times(dt / 2)
+	a := h.Length()
+	// sin(a)/a, with its Taylor series for small angles
+	s := 1 - a*a/6
+	if a > 1e-3 {
+		s = math.Sin(a) / a
+	}
+	d := Quat{h.X * s, h.Y * s, h.Z * s, math.Cos(a)}
+	r := d.Times(q)
+	r.Normalize()
+	return r
+}
+
+// `Integrate` sets `q` to its orientation after rotating for `dt` seconds at
+// the constant `angularVelocity`. See `Integrated`.
+func (q *Quat) Integrate(angularVelocity Vec3, dt float32) {
+	*q = q.Integrated(angularVelocity, dt)
+}
+
+//------------------------------------------------------------------------------
+
+// `SwingTwist` decomposes the rotation `q` (which must be normalized) into a
+// twist around `twistAxis` (which must be non-zero), followed by a swing
+// around an axis orthogonal to it, so that `swing.Times(twist)` is `q`. This
+// is typically used to limit the rotation of joints.
+//
+// The twist is chosen with a non-negative `W`, i.e. with an angle in [-Pi,
+// Pi]. When `q` is a half turn around an axis orthogonal to `twistAxis`, the
+// twist is undefined and the identity is returned.
+func (q Quat) SwingTwist(twistAxis Vec3) (swing, twist Quat) {
+	a := twistAxis.Normalized()
+	// Projection of the rotation axis onto the twist axis
+	p := a.Times(a.Dot(Vec3{q.X, q.Y, q.Z}))
+	twist = Quat{p.X, p.Y, p.Z, q.W}
+	if twist.W < 0 {
+		twist = Quat{-p.X, -p.Y, -p.Z, -q.W}
+	}
+	if n := twist.Norm(); n > 1e-6 {
+		twist = Quat{twist.X / n, twist.Y / n, twist.Z / n, twist.W / n}
+	} else {
+		twist = QuatIdentity()
+	}
+	return q.Times(twist.Conjugate()), twist
+}
+
+//------------------------------------------------------------------------------
+
+// `Dot` returns the dot product of `a` and `b` (i.e. the cosine of half the
+// angle between the two rotations, if both are normalized).
+func (a Quat) Dot(b Quat) float32 {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z + a.W*b.W
+}
+
+// `Norm` returns the norm `|a|` of `a` (normalized quaternions have a norm of
+// 1).
+func (a Quat) Norm() float32 {
+	return math.Sqrt(a.X*a.X + a.Y*a.Y + a.Z*a.Z + a.W*a.W)
+}
+
+// `Normalized` return `a/|a|` (i.e. the normalization of `a`).
+// `a` must be non-zero.
+//
+// See also `Normalize`.
+func (a Quat) Normalized() Quat {
+	length := math.Sqrt(a.X*a.X + a.Y*a.Y + a.Z*a.Z + a.W*a.W)
+	return Quat{a.X / length, a.Y / length, a.Z / length, a.W / length}
+}
+
+// `Normalize` sets `a` to `a/|a|` (i.e. normalizes `a`).
+// `a` must be non-zero.
+//
+// More efficient than `Normalized`.
+func (a *Quat) Normalize() {
+	length := math.Sqrt(a.X*a.X + a.Y*a.Y + a.Z*a.Z + a.W*a.W)
+	a.X /= length
+	a.Y /= length
+	a.Z /= length
+	a.W /= length
+}
+
+//------------------------------------------------------------------------------
+
+// `Nlerp` returns the normalized linear interpolation between the rotations
+// `a` and `b`, which must be normalized. The shortest path is always taken.
+//
+// This is much cheaper than a spherical interpolation, and for small angles
+// the difference is negligible. For larger angles the path is the same but
+// the angular velocity is not constant: the interpolation is faster near the
+// middle (e.g. for a 90 degree difference, the error at `t = 0.25` is about
+// 0.9 degree).
+func (a Quat) Nlerp(b Quat, t float32) Quat {
+	if a.Dot(b) < 0 {
+		b = Quat{-b.X, -b.Y, -b.Z, -b.W}
+	}
+	r := Quat{
+		a.X + t*(b.X-a.X),
+		a.Y + t*(b.Y-a.Y),
+		a.Z + t*(b.Z-a.Z),
+		a.W + t*(b.W-a.W),
+	}
+	r.Normalize()
+	return r
+}
+
+// `Slerp` returns the spherical linear interpolation between the rotations `a`
+// and `b`, which must be normalized. The shortest path is always taken, and
+// the angular velocity is constant.
+//
+// The result is exactly `a` for `t = 0`, and `b` or `-b` (the same rotation)
+// for `t = 1`. When the rotations are very close, `Nlerp` is used instead, as
+// it is then as accurate and avoids dividing by a vanishing sine.
+//
+// See also `Nlerp`, which is cheaper.
+func (a Quat) Slerp(b Quat, t float32) Quat {
+	d := a.Dot(b)
+	if d < 0 {
+		b = Quat{-b.X, -b.Y, -b.Z, -b.W}
+		d = -d
+	}
+	if d > 0.9995 {
+		return a.Nlerp(b, t)
+	}
+	theta := math.Acos(d)
+	s := math.Sin(theta)
+	wa := math.Sin((1-t)*theta) / s
+	wb := math.Sin(t*theta) / s
+	return Quat{
+		wa*a.X + wb*b.X,
+		wa*a.Y + wb*b.Y,
+		wa*a.Z + wb*b.Z,
+		wa*a.W + wb*b.W,
+	}
+}
+
+// `Squad` returns the spherical quadrangle interpolation between the rotations
+// `a` and `b`, using the control quaternions `outA` (leaving `a`) and `inB`
+// (arriving at `b`). All four must be normalized.
+//
+// With the control quaternions given by `SquadControlPoints`, consecutive
+// segments join smoothly: the interpolation passes through each key with a
+// continuous angular velocity.
+func Squad(a, outA, inB, b Quat, t float32) Quat {
+	return a.Slerp(b, t).Slerp(outA.Slerp(inB, t), 2*t*(1-t))
+}
+
+// `SquadControlPoints` returns the inner control quaternion of each key in
+// `keys`, for use with `Squad`: the segment from `keys[i]` to `keys[i+1]` uses
+// `s[i]` as `outA` and `s[i+1]` as `inB`. The first and last keys are their
+// own control quaternions.
+//
+// The keys must be normalized, and each one should be on the same side as its
+// predecessor (i.e. with a non-negative dot product; negate it otherwise) so
+// that the interpolation doesn't take the long way.
+func SquadControlPoints(keys []Quat) []Quat {
+	s := make([]Quat, len(keys))
+	for i := range keys {
+		if i == 0 || i == len(keys)-1 {
+			s[i] = keys[i]
+			continue
+		}
+		inv := keys[i].Conjugate()
+		l1 := inv.Times(keys[i+1]).log()
+		l0 := inv.Times(keys[i-1]).log()
+		e := Quat{-(l1.X + l0.X) / 4, -(l1.Y + l0.Y) / 4, -(l1.Z + l0.Z) / 4, 0}
+		s[i] = keys[i].Times(e.exp())
+	}
+	return s
+}
+
+// `log` returns the logarithm of the normalized quaternion `q`, i.e. the pure
+// quaternion of its rotation axis times half its angle.
+func (q Quat) log() Quat {
+	v := Vec3{q.X, q.Y, q.Z}
+	l := v.LengthRobust()
+	if l == 0 {
+		return Quat{}
+	}
+	v = v.Times(math.Atan2(l, q.W) / l)
+	return Quat{v.X, v.Y, v.Z, 0}
+}
+
+// `exp` returns the exponential of the pure quaternion `q` (its `W` is
+// ignored). It is the inverse of `log`.
+func (q Quat) exp() Quat {
+	v := Vec3{q.X, q.Y, q.Z}
+	a := v.LengthRobust()
+	if a == 0 {
+		return QuatIdentity()
+	}
+	v = v.Times(math.Sin(a) / a)
+	return Quat{v.X, v.Y, v.Z, math.Cos(a)}
+}
+
+//------------------------------------------------------------------------------
+
+// `QuatFromMat3` returns the quaternion corresponding to the rotation matrix
+// `m`, which must be orthonormal with a determinant of 1.
+//
+// The computation branches on the largest diagonal element (Shepperd's
+// method), so it stays accurate for rotations close to 180 degrees.
+//
+// See also `Quat.Mat3`.
+func QuatFromMat3(m Mat3) Quat {
+	// Note: m[column][row]
+	switch tr := m[0][0] + m[1][1] + m[2][2]; {
+	case tr > 0:
+		s := 2 * math.Sqrt(tr+1)
+		return Quat{
+			(m[1][2] - m[2][1]) / s,
+			(m[2][0] - m[0][2]) / s,
+			(m[0][1] - m[1][0]) / s,
+			s / 4,
+		}
+	case m[0][0] > m[1][1] && m[0][0] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[0][0]-m[1][1]-m[2][2])
+		return Quat{
+			s / 4,
+			(m[1][0] + m[0][1]) / s,
+			(m[2][0] + m[0][2]) / s,
+			(m[1][2] - m[2][1]) / s,
+		}
+	case m[1][1] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[1][1]-m[0][0]-m[2][2])
+		return Quat{
+			(m[1][0] + m[0][1]) / s,
+			s / 4,
+			(m[2][1] + m[1][2]) / s,
+			(m[2][0] - m[0][2]) / s,
+		}
+	default:
+		s := 2 * math.Sqrt(1+m[2][2]-m[0][0]-m[1][1])
+		return Quat{
+			(m[2][0] + m[0][2]) / s,
+			(m[2][1] + m[1][2]) / s,
+			s / 4,
+			(m[0][1] - m[1][0]) / s,
+		}
+	}
+}
+
+// `QuatBetween` returns the shortest rotation carrying the direction of `from`
+// onto the direction of `to` (neither needs to be normalized). Its axis is
+// orthogonal to both vectors, except when they are nearly opposite (less than
+// about 0.26 degrees from a half turn): the result is then a half turn
+// combined with a small correction, whose axis is only approximately
+// orthogonal to them.
+//
+// If the vectors are opposite, the rotation is by Pi around an axis
+// orthogonal to `from`, chosen deterministically. If they have the same
+// direction, or if either is zero, the result is the identity.
+func QuatBetween(from, to Vec3) Quat {
+	lf, lt := from.LengthRobust(), to.LengthRobust()
+	if lf == 0 || lt == 0 {
+		return QuatIdentity()
+	}
+	f, t := from.Slash(lf), to.Slash(lt)
+	if f.Dot(t) > -0.99999 {
+		return quatHalfway(f, t)
+	}
+	// Nearly opposite: the axis of f×t is inaccurate, so rotate by Pi around
+	// an axis orthogonal to f, then from -f to t.
+	a := Vec3{1, 0, 0}
+	switch x, y, z := math.Abs(f.X), math.Abs(f.Y), math.Abs(f.Z); {
+	case y < x && y <= z:
+		a = Vec3{0, 1, 0}
+	case z < x && z < y:
+		a = Vec3{0, 0, 1}
+	}
+	axis := f.Cross(a).Normalized()
+	return quatHalfway(f.Inverse(), t).Times(Quat{axis.X, axis.Y, axis.Z, 0})
+}
+
+// `quatHalfway` returns the shortest rotation from `f` to `t`, which must be
+// normalized and not opposite: the quaternion (f×t, 1+f·t), normalized.
+func quatHalfway(f, t Vec3) Quat {
+	c := f.Cross(t)
+	q := Quat{c.X, c.Y, c.Z, 1 + f.Dot(t)}
+	q.Normalize()
+	return q
+}
+
+// `QuatLookRotation` returns the orientation looking towards `forward`, with
+// its top towards `up`: it rotates -Z to the direction of `forward`, and +Y to
+// the projection of `up` onto the plane orthogonal to it. Neither vector needs
+// to be normalized.
+//
+// This is the quaternion form of `LookRotation`, and the boolean has the same
+// meaning.
+func QuatLookRotation(forward, up Vec3) (Quat, bool) {
+	m, ok := LookRotation(forward, up)
+	return QuatFromMat3(m), ok
+}
+
+// `QuatFromMat4` returns the quaternion corresponding to the rotation part of
+// `m` (its upper-left 3x3 matrix). See `QuatFromMat3`.
+func QuatFromMat4(m Mat4) Quat {
+	return QuatFromMat3(m.Mat3())
+}
+
+// `Mat3` returns the rotation matrix corresponding to `q`, which must be
+// normalized.
+//
+// See also `QuatFromMat3` and `Mat4`.
+func (q Quat) Mat3() Mat3 {
+	xx, yy, zz := q.X*q.X, q.Y*q.Y, q.Z*q.Z
+	xy, xz, yz := q.X*q.Y, q.X*q.Z, q.Y*q.Z
+	wx, wy, wz := q.W*q.X, q.W*q.Y, q.W*q.Z
+
+	return Mat3{
+		{1 - 2*(yy+zz), 2 * (xy + wz), 2 * (xz - wy)},
+		{2 * (xy - wz), 1 - 2*(xx+zz), 2 * (yz + wx)},
+		{2 * (xz + wy), 2 * (yz - wx), 1 - 2*(xx+yy)},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Mat4` returns the 4x4 rotation matrix corresponding to `q`, which must be
+// normalized. See `Quat.Mat3`.
+func (q Quat) Mat4() Mat4 {
+	return q.Mat3().Mat4()
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,755 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func isRoughlyEqualQuat(a, b Quat, epsilon float32) bool {
+	return math.IsRoughlyEqual(a.X, b.X, epsilon) &&
+		math.IsRoughlyEqual(a.Y, b.Y, epsilon) &&
+		math.IsRoughlyEqual(a.Z, b.Z, epsilon) &&
+		math.IsRoughlyEqual(a.W, b.W, epsilon)
+}
+
+//------------------------------------------------------------------------------
+
+func TestQuatIdentity(t *testing.T) {
+	q := QuatIdentity()
+	if m := q.Mat3(); m != Mat3Identity() {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	if p := QuatFromMat3(Mat3Identity()); p != q {
+		t.Errorf("Wrong result: %#v", p)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestQuatFromMat3(t *testing.T) {
+	axes := []Vec3{
+		{1, 0, 0}, {0, 1, 0}, {0, 0, 1},
+		Vec3{1, 1, 0}.Normalized(), Vec3{-1, 2, 0.5}.Normalized(),
+	}
+	angles := []float32{0.1, 1, 2.5, math.Pi, -math.Pi / 2}
+	for _, axis := range axes {
+		for _, angle := range angles {
+			m := Rotation(angle, axis).Mat3()
+			q := QuatFromMat3(m)
+			if l := q.X*q.X + q.Y*q.Y + q.Z*q.Z + q.W*q.W; !math.IsRoughlyEqual(l, 1, 1e-5) {
+				t.Errorf("Not normalized for %v around %#v: %#v", angle, axis, q)
+			}
+			// The rotation axis is the vector part
+			v := Vec3{q.X, q.Y, q.Z}
+			if c := v.Cross(axis); c.Length() > 1e-5 {
+				t.Errorf("Wrong axis for %v around %#v: %#v", angle, axis, q)
+			}
+			if r := q.Mat3(); !isRoughlyEqualMat3(r, m, 1e-5) {
+				t.Errorf("No round-trip for %v around %#v: %#v", angle, axis, r)
+			}
+		}
+	}
+}
+
+func TestQuatFromAxisAngle(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		axis := Vec3{r.Float32()*2 - 1, r.Float32()*2 - 1, r.Float32()*2 - 1}
+		if axis.Length() < 0.1 {
+			continue
+		}
+		angle := r.Float32() * 2 * math.Pi
+		q := QuatFromAxisAngle(axis, angle)
+		if n := q.Norm(); !math.IsRoughlyEqual(n, 1, 1e-6) {
+			t.Errorf("Not normalized for %v around %v: %#v", angle, axis, q)
+		}
+		v := Vec3{r.Float32()*2 - 1, r.Float32()*2 - 1, r.Float32()*2 - 1}
+		if p, e := q.Rotate(v), v.RotateAxis(axis, angle); !isRoughlyEqualVec3(p, e, 1e-5) {
+			t.Errorf("Wrong rotation for %v around %v: %#v instead of %#v", angle, axis, p, e)
+		}
+		a, b := q.AxisAngle()
+		if !isRoughlyEqualVec3(a, axis.Normalized(), 1e-3) || !math.IsRoughlyEqual(b, angle, 1e-4) {
+			t.Errorf("No round-trip for %v around %v: %v around %v", angle, axis, b, a)
+		}
+		if p := QuatFromAxisAngle(a, b); !isRoughlyEqualQuat(p, q, 1e-6) {
+			t.Errorf("No round-trip for %#v: %#v", q, p)
+		}
+	}
+	// Near the identity, and near 2*Pi
+	for _, angle := range []float32{0, 1e-7, 1e-3, 2*math.Pi - 1e-3, 2 * math.Pi} {
+		axis := Vec3{0, 3, -4}
+		q := QuatFromAxisAngle(axis, angle)
+		a, b := q.AxisAngle()
+		if math.IsNaN(a.X) || math.IsNaN(a.Y) || math.IsNaN(a.Z) || math.IsNaN(b) {
+			t.Errorf("NaN for %v: %v around %v", angle, b, a)
+		}
+		if !math.IsRoughlyEqual(a.Length(), 1, 1e-6) || !math.IsRoughlyEqual(b, angle, 1e-6) {
+			t.Errorf("Wrong result for %v: %v around %v", angle, b, a)
+		}
+		if p := QuatFromAxisAngle(a, b); !isRoughlyEqualQuat(p, q, 1e-6) {
+			t.Errorf("No round-trip for %v: %#v instead of %#v", angle, p, q)
+		}
+	}
+	if a, b := QuatIdentity().AxisAngle(); a != (Vec3{1, 0, 0}) || b != 0 {
+		t.Errorf("Wrong result for identity: %v around %v", b, a)
+	}
+}
+
+func TestQuatFromMat4(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var ms []Mat4
+	for _, axis := range []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 0}, {-1, 1, 1}} {
+		ms = append(ms, Rotation(math.Pi, axis.Normalized()))
+	}
+	// Exact 180 degree rotations, with a negative trace
+	ms = append(ms,
+		Scaling(Vec3{1, -1, -1}),
+		Scaling(Vec3{-1, 1, -1}),
+		Scaling(Vec3{-1, -1, 1}),
+	)
+	for i := 0; i < 100; i++ {
+		q := randomQuat(r)
+		ms = append(ms, q.Mat4())
+	}
+	vs := []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0.3, -2, 1.5}}
+	for _, m := range ms {
+		q := QuatFromMat4(m)
+		if n := q.Norm(); !math.IsRoughlyEqual(n, 1, 1e-5) {
+			t.Errorf("Not normalized for %#v: %#v", m, q)
+		}
+		for _, v := range vs {
+			e := m.TimesVec4(Vec4{v.X, v.Y, v.Z, 0})
+			if p := q.Rotate(v); !isRoughlyEqualVec3(p, Vec3{e.X, e.Y, e.Z}, 1e-5) {
+				t.Errorf("Wrong rotation of %v for %#v: %#v instead of %#v", v, m, p, e)
+			}
+		}
+		if n := q.Mat4(); !isRoughlyEqualMat4(n, m, 1e-5) {
+			t.Errorf("No round-trip for %#v: %#v", m, n)
+		}
+		if n := q.Mat4(); n.Mat3() != q.Mat3() || n[3] != [4]float32{0, 0, 0, 1} {
+			t.Errorf("Mat4 and Mat3 differ: %#v", n)
+		}
+	}
+	// Translation is ignored
+	m := TRS(Vec3{1, 2, 3}, QuatFromAxisAngle(Vec3{0, 1, 0}, 2), Vec3{1, 1, 1})
+	if q := QuatFromMat4(m); !isRoughlyEqualQuat(q, QuatFromAxisAngle(Vec3{0, 1, 0}, 2), 1e-6) {
+		t.Errorf("Wrong result for TRS: %#v", q)
+	}
+}
+
+func TestQuatBetween(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	random := func() Vec3 {
+		return Vec3{r.Float32()*4 - 2, r.Float32()*4 - 2, r.Float32()*4 - 2}
+	}
+	for i := 0; i < 200; i++ {
+		from, to := random(), random()
+		switch i % 4 {
+		case 1:
+			// Opposite
+			to = from.Times(-0.5)
+		case 2:
+			// Same direction
+			to = from.Times(3)
+		case 3:
+			// Nearly opposite
+			to = from.Inverse().Plus(Vec3{1e-4, 0, 0})
+		}
+		q := QuatBetween(from, to)
+		if n := q.Norm(); !math.IsRoughlyEqual(n, 1, 1e-6) || math.IsNaN(n) {
+			t.Errorf("Not normalized for %v to %v: %#v", from, to, q)
+		}
+		if v := q.Rotate(from.Normalized()); !isRoughlyEqualVec3(v, to.Normalized(), 1e-5) {
+			t.Errorf("Wrong rotation of %v to %v: %#v", from, to, v)
+		}
+		// Shortest rotation: the axis is orthogonal to both
+		axis := Vec3{q.X, q.Y, q.Z}
+		if l := axis.Length(); l > 1e-3 {
+			axis = axis.Slash(l)
+			if d := math.Abs(axis.Dot(from.Normalized())); d > 1e-3 {
+				t.Errorf("Not the shortest rotation of %v to %v: %#v", from, to, q)
+			}
+		}
+		if i%4 == 2 && !isRoughlyEqualQuat(q, QuatIdentity(), 1e-6) {
+			t.Errorf("Not identity for %v to %v: %#v", from, to, q)
+		}
+	}
+	for _, v := range []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0, -2, 0}} {
+		q := QuatBetween(v, v.Inverse())
+		if p := q.Rotate(v); !isRoughlyEqualVec3(p, v.Inverse(), 1e-6) {
+			t.Errorf("Wrong rotation of %v to opposite: %#v", v, p)
+		}
+		if q != QuatBetween(v, v.Inverse()) {
+			t.Errorf("Not deterministic")
+		}
+		if q := QuatBetween(v, v); q != QuatIdentity() {
+			t.Errorf("Not identity for %v: %#v", v, q)
+		}
+	}
+	if q := QuatBetween(Vec3{}, Vec3{1, 0, 0}); q != QuatIdentity() {
+		t.Errorf("Not identity for zero vector: %#v", q)
+	}
+}
+
+func TestQuatLookRotation(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		forward := Vec3{r.Float32()*4 - 2, r.Float32()*4 - 2, r.Float32()*4 - 2}
+		up := Vec3{r.Float32()*4 - 2, r.Float32()*4 - 2, r.Float32()*4 - 2}
+		if forward.Length() < 0.1 || forward.Normalized().Cross(up.Normalized()).Length() < 0.1 {
+			continue
+		}
+		q, ok := QuatLookRotation(forward, up)
+		if !ok {
+			t.Errorf("Not determined for %v, %v", forward, up)
+		}
+		if n := q.Norm(); !math.IsRoughlyEqual(n, 1, 1e-5) {
+			t.Errorf("Not normalized for %v, %v: %#v", forward, up, q)
+		}
+		f := forward.Normalized()
+		if v := q.Rotate(Vec3{0, 0, -1}); !isRoughlyEqualVec3(v, f, 1e-5) {
+			t.Errorf("Wrong forward for %v, %v: %#v", forward, up, v)
+		}
+		// Up is the normalized projection of up
+		e := up.Minus(f.Times(f.Dot(up))).Normalized()
+		if v := q.Rotate(Vec3{0, 1, 0}); !isRoughlyEqualVec3(v, e, 1e-5) {
+			t.Errorf("Wrong up for %v, %v: %#v instead of %#v", forward, up, v, e)
+		}
+	}
+	// Degenerate cases
+	for _, c := range []struct{ forward, up Vec3 }{
+		{Vec3{0, 2, 0}, Vec3{0, 1, 0}},
+		{Vec3{0, -2, 0}, Vec3{0, 1, 0}},
+		{Vec3{0, 0, 1}, Vec3{0, 0, -3}},
+		{Vec3{1, 1, 0}, Vec3{2, 2, 0}},
+		{Vec3{0, 0, 1}, Vec3{}},
+		{Vec3{0, 1, 0}, Vec3{}},
+	} {
+		q, ok := QuatLookRotation(c.forward, c.up)
+		if ok {
+			t.Errorf("Reported as determined for %v, %v", c.forward, c.up)
+		}
+		if math.IsNaN(q.X) || math.IsNaN(q.Y) || math.IsNaN(q.Z) || math.IsNaN(q.W) {
+			t.Errorf("NaN for %v, %v: %#v", c.forward, c.up, q)
+			continue
+		}
+		if v := q.Rotate(Vec3{0, 0, -1}); !isRoughlyEqualVec3(v, c.forward.Normalized(), 1e-5) {
+			t.Errorf("Wrong forward for %v, %v: %#v", c.forward, c.up, v)
+		}
+		if v := q.Rotate(Vec3{0, 1, 0}); math.Abs(v.Dot(c.forward)) > 1e-5 {
+			t.Errorf("Up not orthogonal to forward for %v, %v: %#v", c.forward, c.up, v)
+		}
+	}
+	if q, ok := QuatLookRotation(Vec3{}, Vec3{0, 1, 0}); ok || q != QuatIdentity() {
+		t.Errorf("Wrong result for zero forward: %#v, %v", q, ok)
+	}
+	if q, ok := QuatLookRotation(Vec3{0, 0, -5}, Vec3{0, 0.1, 0}); !ok || !isRoughlyEqualQuat(q, QuatIdentity(), 1e-6) {
+		t.Errorf("Wrong result for default orientation: %#v, %v", q, ok)
+	}
+}
+
+func TestQuatBetween_align(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	forward := Vec3{0, 0, -1}
+	for i := 0; i < 100; i++ {
+		target := Vec3{r.Float32()*2 - 1, r.Float32()*2 - 1, r.Float32()*2 - 1}.Normalized()
+		for _, to := range []Vec3{target, forward.Inverse(), target.Inverse()} {
+			q := QuatBetween(forward, to)
+			if v := q.Rotate(forward); !isRoughlyEqualVec3(v, to, 1e-5) {
+				t.Errorf("Wrong alignment on %v: %#v", to, v)
+			}
+			// Composes with an already aligned orientation
+			p := QuatBetween(to, target).Times(q)
+			if v := p.Rotate(forward); !isRoughlyEqualVec3(v, target, 1e-5) {
+				t.Errorf("Wrong composed alignment on %v: %#v", target, v)
+			}
+		}
+	}
+}
+
+func TestQuat_Times(t *testing.T) {
+	a := QuatFromMat3(Mat3RotationX(0.7))
+	b := QuatFromMat3(Mat3RotationZ(-1.2))
+	ma, mb := a.Mat3(), b.Mat3()
+	e := ma.Times(&mb)
+	if m := a.Times(b).Mat3(); !isRoughlyEqualMat3(m, e, 1e-6) {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	if r := a.Times(QuatIdentity()); r != a {
+		t.Errorf("Wrong result for identity: %#v", r)
+	}
+	if r := a.Times(a.Conjugate()); !isRoughlyEqualQuat(r, QuatIdentity(), 1e-6) {
+		t.Errorf("Conjugate is not the inverse: %#v", r)
+	}
+}
+
+func TestQuat_Times_identities(t *testing.T) {
+	one := QuatIdentity()
+	i, j, k := Quat{1, 0, 0, 0}, Quat{0, 1, 0, 0}, Quat{0, 0, 1, 0}
+	minus := func(q Quat) Quat { return Quat{-q.X, -q.Y, -q.Z, -q.W} }
+	tests := []struct {
+		a, b, e Quat
+	}{
+		{i, j, k}, {j, k, i}, {k, i, j},
+		{j, i, minus(k)}, {k, j, minus(i)}, {i, k, minus(j)},
+		{i, i, minus(one)}, {j, j, minus(one)}, {k, k, minus(one)},
+		{one, i, i}, {j, one, j},
+	}
+	for _, tt := range tests {
+		if r := tt.a.Times(tt.b); r != tt.e {
+			t.Errorf("Wrong result for %v * %v: %#v", tt.a, tt.b, r)
+		}
+		r := tt.a
+		r.Multiply(tt.b)
+		if r != tt.e {
+			t.Errorf("Multiply and Times differ for %v * %v: %#v", tt.a, tt.b, r)
+		}
+	}
+	// ijk = -1
+	if r := i.Times(j).Times(k); r != minus(one) {
+		t.Errorf("Wrong result for ijk: %#v", r)
+	}
+}
+
+func TestQuat_Inverse(t *testing.T) {
+	qs := []Quat{
+		{1, 2, 3, 4},
+		{-0.5, 0.25, 0, 2},
+		QuatFromMat3(Mat3RotationAxis(Vec3{1, -2, 0.5}.Normalized(), 2.2)),
+	}
+	for _, q := range qs {
+		inv := q.Inverse()
+		if r := q.Times(inv); !isRoughlyEqualQuat(r, QuatIdentity(), 1e-6) {
+			t.Errorf("Wrong result for %v * inverse: %#v", q, r)
+		}
+		if r := inv.Times(q); !isRoughlyEqualQuat(r, QuatIdentity(), 1e-6) {
+			t.Errorf("Wrong result for inverse * %v: %#v", q, r)
+		}
+		r := q
+		r.Invert()
+		if r != inv {
+			t.Errorf("Invert and Inverse differ for %v: %#v", q, r)
+		}
+	}
+	q := Quat{1, 2, 3, 4}
+	if n := q.Norm(); !math.IsRoughlyEqual(n, math.Sqrt(30), 1e-6) {
+		t.Errorf("Wrong norm: %v", n)
+	}
+	if n := q.Normalized().Norm(); !math.IsRoughlyEqual(n, 1, 1e-6) {
+		t.Errorf("Wrong norm for normalized quaternion: %v", n)
+	}
+	if d := q.Dot(Quat{1, 0, -1, 0.5}); d != 0 {
+		t.Errorf("Wrong dot product: %v", d)
+	}
+}
+
+func TestQuat_Rotate(t *testing.T) {
+	q := QuatFromMat3(Mat3RotationZ(math.Pi / 2))
+	if v := q.Rotate(Vec3{1, 0, 0}); !isRoughlyEqualVec3(v, Vec3{0, 1, 0}, 1e-6) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+	q = QuatFromMat3(Rotation(2.1, Vec3{1, -2, 0.5}.Normalized()).Mat3())
+	m := q.Mat3()
+	for _, v := range []Vec3{{1, 0, 0}, {0, 1, 0}, {3, -1, 2}} {
+		if r := q.Rotate(v); !isRoughlyEqualVec3(r, m.TimesVec3(v), 1e-5) {
+			t.Errorf("Differs from matrix for %#v: %#v", v, r)
+		}
+	}
+}
+
+func TestQuat_RotateSlice(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	src := make([]Vec3, 64)
+	for i := range src {
+		src[i] = Vec3{r.Float32()*4 - 2, r.Float32()*4 - 2, r.Float32()*4 - 2}
+	}
+	dst := make([]Vec3, len(src))
+	for i := 0; i < 20; i++ {
+		q := randomQuat(r)
+		m := q.Mat3()
+		q.RotateSlice(dst, src)
+		for j, v := range src {
+			e := m.TimesVec3(v)
+			if p := q.Rotate(v); !isRoughlyEqualVec3(p, e, 1e-5) {
+				t.Errorf("Rotate differs from matrix for %#v: %#v instead of %#v", v, p, e)
+			}
+			if !isRoughlyEqualVec3(dst[j], e, 1e-5) {
+				t.Errorf("RotateSlice differs from matrix for %#v: %#v instead of %#v", v, dst[j], e)
+			}
+			if l, e := q.Rotate(v).Length(), v.Length(); !math.IsRoughlyEqual(l, e, 1e-5) {
+				t.Errorf("Length not preserved for %#v: %v instead of %v", v, l, e)
+			}
+		}
+	}
+	// In place
+	q := randomQuat(r)
+	in := append([]Vec3(nil), src...)
+	q.RotateSlice(in, in)
+	q.RotateSlice(dst, src)
+	for i := range in {
+		if in[i] != dst[i] {
+			t.Errorf("Wrong result in place: %#v instead of %#v", in[i], dst[i])
+		}
+	}
+	short := make([]Vec3, 3)
+	if err := q.RotateSlice(short, src); err == nil ||
+		err.Error() != "glam.Quat.RotateSlice: 3 destinations for 64 sources" {
+		t.Errorf("Wrong error for different lengths: %v", err)
+	}
+	for i := range short {
+		if short[i] != (Vec3{}) {
+			t.Errorf("Destination written despite different lengths: %#v", short)
+			break
+		}
+	}
+}
+
+func BenchmarkQuat_Rotate(b *testing.B) {
+	q := randomQuat(rand.New(rand.NewSource(1)))
+	src := benchmarkPoints(1024)
+	dst := make([]Vec3, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range src {
+			dst[j] = q.Rotate(src[j])
+		}
+	}
+}
+
+// For comparison with Rotate: converting to a matrix for each vector
+func BenchmarkQuat_Rotate_matrix(b *testing.B) {
+	q := randomQuat(rand.New(rand.NewSource(1)))
+	src := benchmarkPoints(1024)
+	dst := make([]Vec3, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range src {
+			m := q.Mat3()
+			dst[j] = m.TimesVec3(src[j])
+		}
+	}
+}
+
+func BenchmarkQuat_RotateSlice(b *testing.B) {
+	q := randomQuat(rand.New(rand.NewSource(1)))
+	src := benchmarkPoints(1024)
+	dst := make([]Vec3, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.RotateSlice(dst, src)
+	}
+}
+
+func TestQuat_SwingTwist(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vs := []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0.3, -2, 1.5}}
+	for i := 0; i < 100; i++ {
+		q := randomQuat(r)
+		axis := Vec3{r.Float32()*2 - 1, r.Float32()*2 - 1, r.Float32()*2 - 1}
+		if axis.Length() < 0.1 {
+			continue
+		}
+		swing, twist := q.SwingTwist(axis)
+		p := swing.Times(twist)
+		for _, v := range vs {
+			if a, b := p.Rotate(v), q.Rotate(v); !isRoughlyEqualVec3(a, b, 1e-5) {
+				t.Errorf("No recomposition for %#v around %v: %#v instead of %#v", q, axis, a, b)
+			}
+		}
+		if n, m := swing.Norm(), twist.Norm(); !math.IsRoughlyEqual(n, 1, 1e-5) || !math.IsRoughlyEqual(m, 1, 1e-5) {
+			t.Errorf("Not normalized for %#v: %v, %v", q, n, m)
+		}
+		// The twist is around the axis, and the swing orthogonal to it
+		if c := (Vec3{twist.X, twist.Y, twist.Z}).Cross(axis.Normalized()); c.Length() > 1e-5 {
+			t.Errorf("Twist not around %v: %#v", axis, twist)
+		}
+		if d := (Vec3{swing.X, swing.Y, swing.Z}).Dot(axis.Normalized()); math.Abs(d) > 1e-5 {
+			t.Errorf("Swing not orthogonal to %v: %#v", axis, swing)
+		}
+	}
+
+	axis := Vec3{0, 0, 2}
+	// Pure twist
+	q := QuatFromAxisAngle(axis, 1.2)
+	if s, tw := q.SwingTwist(axis); !isRoughlyEqualQuat(tw, q, 1e-6) || !isRoughlyEqualQuat(s, QuatIdentity(), 1e-6) {
+		t.Errorf("Wrong result for pure twist: %#v, %#v", s, tw)
+	}
+	// Pure swing
+	q = QuatFromAxisAngle(Vec3{1, 1, 0}, 0.7)
+	if s, tw := q.SwingTwist(axis); tw != QuatIdentity() || !isRoughlyEqualQuat(s, q, 1e-6) {
+		t.Errorf("Wrong result for pure swing: %#v, %#v", s, tw)
+	}
+	// Half turn orthogonal to the axis
+	q = QuatFromAxisAngle(Vec3{0, 1, 0}, math.Pi)
+	s, tw := q.SwingTwist(axis)
+	if math.IsNaN(tw.W) || !isRoughlyEqualQuat(tw, QuatIdentity(), 1e-6) || !isRoughlyEqualQuat(s, q, 1e-6) {
+		t.Errorf("Wrong result for half turn: %#v, %#v", s, tw)
+	}
+	// A twist beyond Pi is returned with the opposite angle
+	q = QuatFromAxisAngle(axis, 4)
+	if _, tw := q.SwingTwist(axis); !isRoughlyEqualQuat(tw, QuatFromAxisAngle(axis, 4-2*math.Pi), 1e-6) {
+		t.Errorf("Wrong twist: %#v", tw)
+	}
+}
+
+func TestQuat_Integrated(t *testing.T) {
+	q := QuatFromAxisAngle(Vec3{1, 0, 0}, 0.4)
+	w := Vec3{0, 0, 2.5}
+	const n, dt = 100, float32(1) / 240
+	p := q
+	for i := 0; i < n; i++ {
+		p = p.Integrated(w, dt)
+	}
+	e := QuatFromAxisAngle(Vec3{0, 0, 1}, 2.5*n*dt).Times(q)
+	if !isRoughlyEqualQuat(p, e, 1e-5) {
+		t.Errorf("Wrong result after %d steps: %#v instead of %#v", n, p, e)
+	}
+	if s := q.Integrated(w, n*dt); !isRoughlyEqualQuat(s, e, 1e-6) {
+		t.Errorf("Wrong result for a single step: %#v instead of %#v", s, e)
+	}
+	r := q
+	r.Integrate(w, dt)
+	if r != q.Integrated(w, dt) {
+		t.Errorf("Integrate and Integrated differ: %#v", r)
+	}
+
+	// Small angles
+	if s := q.Integrated(Vec3{0, 1e-3, 0}, 0.5); !isRoughlyEqualQuat(s, QuatFromAxisAngle(Vec3{0, 1, 0}, 5e-4).Times(q), 1e-7) {
+		t.Errorf("Wrong result for small angle: %#v", s)
+	}
+	if s := q.Integrated(Vec3{}, 1); s != q.Normalized() {
+		t.Errorf("Wrong result for zero velocity: %#v", s)
+	}
+
+	// Stays normalized over many steps
+	w = Vec3{1.3, -0.4, 2.2}
+	p = QuatIdentity()
+	for i := 0; i < 10000; i++ {
+		p.Integrate(w, 1.0/60)
+	}
+	if l := p.Norm(); !math.IsRoughlyEqual(l, 1, 1e-6) {
+		t.Errorf("Not normalized after many steps: %v", l)
+	}
+	// Rotating around a fixed axis, the axis itself doesn't move
+	if v := p.Rotate(w); !isRoughlyEqualVec3(v, w, 1e-4) {
+		t.Errorf("Axis moved: %#v", v)
+	}
+}
+
+func TestQuat_Normalized(t *testing.T) {
+	q := Quat{1, -2, 2, 4}
+	n := q.Normalized()
+	if n != (Quat{0.2, -0.4, 0.4, 0.8}) {
+		t.Errorf("Wrong result: %#v", n)
+	}
+	q.Normalize()
+	if q != n {
+		t.Errorf("Normalize differs: %#v", q)
+	}
+	if d := n.Dot(Quat{1, 1, 1, 1}); !math.IsRoughlyEqual(d, 1, 1e-6) {
+		t.Errorf("Wrong dot product: %v", d)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestQuat_Nlerp(t *testing.T) {
+	a := QuatFromMat3(Mat3RotationZ(0.2))
+	b := QuatFromMat3(Mat3RotationZ(1.4))
+	if r := a.Nlerp(b, 0); !isRoughlyEqualQuat(r, a, 1e-6) {
+		t.Errorf("Wrong result at 0: %#v", r)
+	}
+	if r := a.Nlerp(b, 1); !isRoughlyEqualQuat(r, b, 1e-6) {
+		t.Errorf("Wrong result at 1: %#v", r)
+	}
+	m := a.Nlerp(b, 0.5)
+	if e := QuatFromMat3(Mat3RotationZ(0.8)); !isRoughlyEqualQuat(m, e, 1e-6) {
+		t.Errorf("Wrong result at 0.5: %#v", m)
+	}
+	for i := 0; i <= 10; i++ {
+		r := a.Nlerp(b, float32(i)/10)
+		if l := r.Dot(r); !math.IsRoughlyEqual(l, 1, 1e-6) {
+			t.Errorf("Not normalized: %#v", r)
+		}
+	}
+
+	// Shortest path: -b is the same rotation as b
+	nb := Quat{-b.X, -b.Y, -b.Z, -b.W}
+	if r := a.Nlerp(nb, 0.5); !isRoughlyEqualQuat(r, m, 1e-6) {
+		t.Errorf("Shortest path not taken: %#v", r)
+	}
+
+	// Small angles are accurate
+	c := QuatFromMat3(Mat3RotationX(0.1))
+	r := QuatIdentity().Nlerp(c, 0.3)
+	if e := QuatFromMat3(Mat3RotationX(0.03)); !isRoughlyEqualQuat(r, e, 1e-5) {
+		t.Errorf("Wrong result for small angle: %#v", r)
+	}
+}
+
+func TestQuat_Slerp(t *testing.T) {
+	axis := Vec3{1, -2, 0.5}
+	a := QuatFromAxisAngle(axis, 0.3)
+	b := QuatFromAxisAngle(axis, 2.7)
+	if r := a.Slerp(b, 0); r != a {
+		t.Errorf("Wrong result at 0: %#v", r)
+	}
+	if r := a.Slerp(b, 1); r != b {
+		t.Errorf("Wrong result at 1: %#v", r)
+	}
+	// Constant angular velocity
+	for i := 0; i <= 20; i++ {
+		f := float32(i) / 20
+		r := a.Slerp(b, f)
+		if n := r.Norm(); !math.IsRoughlyEqual(n, 1, 1e-6) {
+			t.Errorf("Not normalized at %v: %#v", f, r)
+		}
+		e := QuatFromAxisAngle(axis, 0.3+f*2.4)
+		if !isRoughlyEqualQuat(r, e, 1e-5) {
+			t.Errorf("Wrong result at %v: %#v instead of %#v", f, r, e)
+		}
+	}
+
+	// Shortest path, around the other side
+	c := QuatFromAxisAngle(axis, -2.7)
+	for i := 0; i <= 10; i++ {
+		f := float32(i) / 10
+		r := b.Slerp(c, f)
+		e := QuatFromAxisAngle(axis, 2.7+f*(2*math.Pi-5.4))
+		if r.Dot(e) < 0 {
+			e = Quat{-e.X, -e.Y, -e.Z, -e.W}
+		}
+		if !isRoughlyEqualQuat(r, e, 1e-5) {
+			t.Errorf("Long path taken at %v: %#v instead of %#v", f, r, e)
+		}
+	}
+
+	// Antipodal representation: -a is the same rotation, nothing moves
+	na := Quat{-a.X, -a.Y, -a.Z, -a.W}
+	for _, f := range []float32{0, 0.25, 0.5, 1} {
+		r := a.Slerp(na, f)
+		if !isRoughlyEqualQuat(r, a, 1e-6) {
+			t.Errorf("Spinning at %v for antipodal quaternion: %#v", f, r)
+		}
+	}
+
+	// Nearly identical rotations
+	d := QuatFromAxisAngle(axis, 0.3001)
+	if r := a.Slerp(d, 0.5); !isRoughlyEqualQuat(r, QuatFromAxisAngle(axis, 0.30005), 1e-6) {
+		t.Errorf("Wrong result for close rotations: %#v", r)
+	}
+	if m := a.Slerp(b, 0.5); !isRoughlyEqualQuat(m, a.Nlerp(b, 0.5), 1e-6) {
+		t.Errorf("Differs from Nlerp at 0.5: %#v", m)
+	}
+}
+
+// `quatAngle` returns the angle of the rotation from `a` to `b`.
+func quatAngle(a, b Quat) float32 {
+	_, angle := a.Conjugate().Times(b).AxisAngle()
+	if angle > math.Pi {
+		angle = 2*math.Pi - angle
+	}
+	return angle
+}
+
+func TestQuat_Nlerp_Slerp(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		a := randomQuat(r)
+		// At most 10 degrees apart
+		axis := Vec3{r.Float32()*2 - 1, r.Float32()*2 - 1, r.Float32() + 0.1}
+		b := a.Times(QuatFromAxisAngle(axis, (r.Float32()*2-1)*math.Pi/18))
+		for j := 0; j <= 10; j++ {
+			f := float32(j) / 10
+			n, s := a.Nlerp(b, f), a.Slerp(b, f)
+			// The error is about angle³/32
+			if e := quatAngle(n, s); e > 1e-4 {
+				t.Errorf("Nlerp and Slerp differ by %v radians at %v", e, f)
+			}
+		}
+	}
+}
+
+func TestSquad(t *testing.T) {
+	keys := []Quat{
+		QuatIdentity(),
+		QuatFromAxisAngle(Vec3{0, 0, 1}, 0.8),
+		QuatFromAxisAngle(Vec3{1, 1, 0}, 1.2),
+		QuatFromAxisAngle(Vec3{0, 1, 0}, -0.5),
+		QuatFromAxisAngle(Vec3{1, 0, 1}, 0.3),
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i].Dot(keys[i-1]) < 0 {
+			keys[i] = Quat{-keys[i].X, -keys[i].Y, -keys[i].Z, -keys[i].W}
+		}
+	}
+	s := SquadControlPoints(keys)
+	if len(s) != len(keys) || s[0] != keys[0] || s[4] != keys[4] {
+		t.Fatalf("Wrong control points: %#v", s)
+	}
+	segment := func(i int, f float32) Quat {
+		return Squad(keys[i], s[i], s[i+1], keys[i+1], f)
+	}
+	for i := 0; i < len(keys)-1; i++ {
+		if q := segment(i, 0); !isRoughlyEqualQuat(q, keys[i], 1e-6) {
+			t.Errorf("Segment %d doesn't start at key: %#v", i, q)
+		}
+		if q := segment(i, 1); !isRoughlyEqualQuat(q, keys[i+1], 1e-6) {
+			t.Errorf("Segment %d doesn't end at key: %#v", i, q)
+		}
+		for j := 0; j <= 10; j++ {
+			if n := segment(i, float32(j)/10).Norm(); !math.IsRoughlyEqual(n, 1, 1e-5) {
+				t.Errorf("Not normalized: %v", n)
+			}
+		}
+	}
+	// Continuous derivative at the inner keys, with second order one-sided
+	// finite differences
+	const h = 1.0 / 128
+	vec := func(q Quat) Vec4 { return Vec4{q.X, q.Y, q.Z, q.W} }
+	derivative := func(f0, f1, f2 Quat) Vec4 {
+		return vec(f1).Times(4).Minus(vec(f0).Times(3)).Minus(vec(f2)).Slash(2 * h)
+	}
+	for i := 1; i < len(keys)-1; i++ {
+		before := derivative(segment(i-1, 1), segment(i-1, 1-h), segment(i-1, 1-2*h)).Inverse()
+		after := derivative(segment(i, 0), segment(i, h), segment(i, 2*h))
+		if d := after.Minus(before).Length(); d > 2e-3*after.Length() {
+			t.Errorf("Discontinuous derivative at key %d: %#v before, %#v after", i, before, after)
+		}
+	}
+	// Without the control points, the derivative is not continuous
+	before := derivative(keys[1], keys[0].Slerp(keys[1], 1-h), keys[0].Slerp(keys[1], 1-2*h)).Inverse()
+	after := derivative(keys[1], keys[1].Slerp(keys[2], h), keys[1].Slerp(keys[2], 2*h))
+	if d := after.Minus(before).Length(); d < 0.1*after.Length() {
+		t.Errorf("Test not discriminating: %v", d)
+	}
+}
+
+func BenchmarkQuat_Slerp(b *testing.B) {
+	q := QuatFromMat3(Mat3RotationZ(0.2))
+	p := QuatFromMat3(Mat3RotationY(1.4))
+	var o Quat
+	for i := 0; i < b.N; i++ {
+		o = q.Slerp(p, 0.3)
+	}
+	_ = o
+}
+
+func BenchmarkQuat_Nlerp(b *testing.B) {
+	q := QuatFromMat3(Mat3RotationZ(0.2))
+	p := QuatFromMat3(Mat3RotationY(1.4))
+	var o Quat
+	for i := 0; i < b.N; i++ {
+		o = q.Nlerp(p, 0.3)
+	}
+	_ = o
+}
+
+//------------------------------------------------------------------------------
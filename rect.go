@@ -0,0 +1,83 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+//------------------------------------------------------------------------------
+
+// `Rect` is an axis-aligned 2D rectangle, from its lower corner `Min` to its
+// upper corner `Max` (both included).
+//
+// A rectangle with `Min.X > Max.X` or `Min.Y > Max.Y` is empty; a rectangle
+// with `Min == Max` is a single point.
+type Rect struct {
+	Min, Max Vec2
+}
+
+// `RectFromSize` returns the rectangle whose lower corner is `origin`, with
+// the given `size`.
+func RectFromSize(origin, size Vec2) Rect {
+	return Rect{origin, origin.Plus(size)}
+}
+
+//------------------------------------------------------------------------------
+
+// `Size` returns the width and height of `r`.
+func (r Rect) Size() Vec2 {
+	return r.Max.Minus(r.Min)
+}
+
+// `Center` returns the center of `r`.
+func (r Rect) Center() Vec2 {
+	return Vec2{(r.Min.X + r.Max.X) / 2, (r.Min.Y + r.Max.Y) / 2}
+}
+
+// `IsEmpty` returns true if `r` contains no point.
+func (r Rect) IsEmpty() bool {
+	return r.Min.X > r.Max.X || r.Min.Y > r.Max.Y
+}
+
+//------------------------------------------------------------------------------
+
+// `Contains` returns true if `p` is inside `r`, or on its boundary.
+func (r Rect) Contains(p Vec2) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X &&
+		p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// `Intersects` returns true if `r` and `o` overlap, or touch.
+//
+// See also `Intersection`.
+func (r Rect) Intersects(o Rect) bool {
+	return r.Min.X <= o.Max.X && o.Min.X <= r.Max.X &&
+		r.Min.Y <= o.Max.Y && o.Min.Y <= r.Max.Y
+}
+
+// `Intersection` returns the rectangle common to `r` and `o`, and true; or, if
+// they don't intersect, the zero value and false.
+//
+// If the rectangles only touch, the intersection is a segment or a point.
+//
+// See also `Intersects`.
+func (r Rect) Intersection(o Rect) (Rect, bool) {
+	i := Rect{r.Min.Max(o.Min), r.Max.Min(o.Max)}
+	if i.IsEmpty() {
+		return Rect{}, false
+	}
+	return i, true
+}
+
+// `Union` returns the smallest rectangle containing both `r` and `o`.
+//
+// Empty rectangles are ignored, so the union of two empty rectangles is empty.
+func (r Rect) Union(o Rect) Rect {
+	switch {
+	case r.IsEmpty():
+		return o
+	case o.IsEmpty():
+		return r
+	}
+	return Rect{r.Min.Min(o.Min), r.Max.Max(o.Max)}
+}
+
+//------------------------------------------------------------------------------
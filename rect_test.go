@@ -0,0 +1,93 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "testing"
+
+//------------------------------------------------------------------------------
+
+func TestRect(t *testing.T) {
+	r := RectFromSize(Vec2{1, 2}, Vec2{4, 2})
+	if r != (Rect{Vec2{1, 2}, Vec2{5, 4}}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if s := r.Size(); s != (Vec2{4, 2}) {
+		t.Errorf("Wrong size: %#v", s)
+	}
+	if c := r.Center(); c != (Vec2{3, 3}) {
+		t.Errorf("Wrong center: %#v", c)
+	}
+	if r.IsEmpty() || (Rect{Vec2{1, 1}, Vec2{1, 1}}).IsEmpty() || !(Rect{Vec2{1, 1}, Vec2{0, 2}}).IsEmpty() {
+		t.Errorf("Wrong emptiness")
+	}
+	for _, p := range []Vec2{{1, 2}, {5, 4}, {3, 3}, {5, 2}} {
+		if !r.Contains(p) {
+			t.Errorf("%v not contained", p)
+		}
+	}
+	for _, p := range []Vec2{{0.9, 3}, {5.1, 3}, {3, 1.9}, {3, 4.1}} {
+		if r.Contains(p) {
+			t.Errorf("%v contained", p)
+		}
+	}
+}
+
+func TestRect_Intersection(t *testing.T) {
+	r := Rect{Vec2{0, 0}, Vec2{4, 2}}
+	tests := []struct {
+		o  Rect
+		ok bool
+		i  Rect
+	}{
+		// Disjoint
+		{Rect{Vec2{5, 0}, Vec2{6, 2}}, false, Rect{}},
+		{Rect{Vec2{0, -3}, Vec2{4, -1}}, false, Rect{}},
+		{Rect{Vec2{5, 3}, Vec2{6, 4}}, false, Rect{}},
+		// Overlapping only along one axis
+		{Rect{Vec2{1, 3}, Vec2{2, 4}}, false, Rect{}},
+		// Touching
+		{Rect{Vec2{4, 1}, Vec2{6, 3}}, true, Rect{Vec2{4, 1}, Vec2{4, 2}}},
+		{Rect{Vec2{4, 2}, Vec2{6, 3}}, true, Rect{Vec2{4, 2}, Vec2{4, 2}}},
+		// Overlapping
+		{Rect{Vec2{3, 1}, Vec2{6, 3}}, true, Rect{Vec2{3, 1}, Vec2{4, 2}}},
+		{Rect{Vec2{-1, -1}, Vec2{5, 3}}, true, r},
+		{Rect{Vec2{1, 0.5}, Vec2{2, 1.5}}, true, Rect{Vec2{1, 0.5}, Vec2{2, 1.5}}},
+	}
+	for _, tt := range tests {
+		if ok := r.Intersects(tt.o); ok != tt.ok {
+			t.Errorf("Wrong result for %v: %v", tt.o, ok)
+		}
+		if ok := tt.o.Intersects(r); ok != tt.ok {
+			t.Errorf("Not symmetric for %v: %v", tt.o, ok)
+		}
+		i, ok := r.Intersection(tt.o)
+		if ok != tt.ok || i != tt.i {
+			t.Errorf("Wrong intersection with %v: %v, %v", tt.o, i, ok)
+		}
+	}
+}
+
+func TestRect_Union(t *testing.T) {
+	a := Rect{Vec2{0, 0}, Vec2{4, 2}}
+	b := Rect{Vec2{5, -1}, Vec2{6, 1}}
+	e := Rect{Vec2{0, -1}, Vec2{6, 2}}
+	if u := a.Union(b); u != e {
+		t.Errorf("Wrong result: %#v", u)
+	}
+	if u := b.Union(a); u != e {
+		t.Errorf("Not symmetric: %#v", u)
+	}
+	if u := a.Union(Rect{Vec2{1, 1}, Vec2{2, 1.5}}); u != a {
+		t.Errorf("Wrong result for contained rectangle: %#v", u)
+	}
+	empty := Rect{Vec2{1, 1}, Vec2{-1, -1}}
+	if u := a.Union(empty); u != a {
+		t.Errorf("Wrong result for empty rectangle: %#v", u)
+	}
+	if u := empty.Union(a); u != a {
+		t.Errorf("Wrong result for empty rectangle: %#v", u)
+	}
+}
+
+//------------------------------------------------------------------------------
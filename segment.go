@@ -0,0 +1,129 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "github.com/drakmaniso/glam/math"
+
+//------------------------------------------------------------------------------
+
+// `ClosestPointOnSegment` returns the point of the segment from `a` to `b`
+// that is nearest to `p`.
+//
+// Unlike a projection onto the infinite line, the result is clamped to the
+// endpoints. If `a` and `b` are equal, `a` is returned.
+//
+// See also `DistanceToSegment`.
+func ClosestPointOnSegment(p, a, b Vec3) Vec3 {
+	ab := b.Minus(a)
+	l2 := ab.Dot(ab)
+	if l2 == 0 {
+		return a
+	}
+	t := math.Saturate(p.Minus(a).Dot(ab) / l2)
+	return a.Plus(ab.Times(t))
+}
+
+// `DistanceToSegment` returns the distance from `p` to the nearest point of
+// the segment from `a` to `b`.
+//
+// See also `ClosestPointOnSegment`.
+func DistanceToSegment(p, a, b Vec3) float32 {
+	return p.Minus(ClosestPointOnSegment(p, a, b)).Length()
+}
+
+//------------------------------------------------------------------------------
+
+// `ClosestPointsSegmentSegment` returns the pair of nearest points between the
+// segment from `p1` to `q1` and the segment from `p2` to `q2`: `c1` is on the
+// first segment and `c2` on the second one.
+//
+// If the segments are parallel, there are infinitely many such pairs, and one
+// of them is returned. Degenerate segments (reduced to a point) are handled.
+func ClosestPointsSegmentSegment(p1, q1, p2, q2 Vec3) (c1, c2 Vec3) {
+	// Source: "Real-Time Collision Detection" by Christer Ericson, 5.1.9
+
+	const epsilon = 1e-12
+
+	d1 := q1.Minus(p1)
+	d2 := q2.Minus(p2)
+	r := p1.Minus(p2)
+	a := d1.Dot(d1)
+	e := d2.Dot(d2)
+	f := d2.Dot(r)
+
+	var s, t float32
+	switch {
+	case a <= epsilon && e <= epsilon:
+		return p1, p2
+
+	case a <= epsilon:
+		t = math.Saturate(f / e)
+
+	default:
+		c := d1.Dot(r)
+		if e <= epsilon {
+			s = math.Saturate(-c / a)
+		} else {
+			b := d1.Dot(d2)
+			denom := a*e - b*b
+			if denom > 0 {
+				s = math.Saturate((b*f - c*e) / denom)
+			}
+			// Closest point on the second line, clamped; then recompute s
+			t = (b*s + f) / e
+			if t < 0 {
+				t = 0
+				s = math.Saturate(-c / a)
+			} else if t > 1 {
+				t = 1
+				s = math.Saturate((b - c) / a)
+			}
+		}
+	}
+
+	return p1.Plus(d1.Times(s)), p2.Plus(d2.Times(t))
+}
+
+//------------------------------------------------------------------------------
+
+// `IntersectLinePlane` returns the intersection of the segment from `p0` to
+// `p1` with `plane`, and true; or, if the segment is parallel to the plane
+// (including when it lies in it) or does not reach it, the zero value and
+// false.
+func IntersectLinePlane(p0, p1 Vec3, plane Plane) (Vec3, bool) {
+	d0 := plane.SignedDistance(p0)
+	d1 := plane.SignedDistance(p1)
+	if d0 == d1 {
+		return Vec3{}, false
+	}
+	t := d0 / (d0 - d1)
+	if t < 0 || t > 1 {
+		return Vec3{}, false
+	}
+	return p0.Plus(p1.Minus(p0).Times(t)), true
+}
+
+// `IntersectLines2D` returns the intersection of the segment from `a0` to `a1`
+// with the segment from `b0` to `b1`, and true; or, if the segments do not
+// cross, the zero value and false.
+//
+// Parallel segments are reported as not crossing, even if they are collinear
+// and overlap (in which case there is no single intersection point).
+func IntersectLines2D(a0, a1, b0, b1 Vec2) (Vec2, bool) {
+	r := a1.Minus(a0)
+	s := b1.Minus(b0)
+	den := r.Cross(s)
+	if den == 0 {
+		return Vec2{}, false
+	}
+	q := b0.Minus(a0)
+	t := q.Cross(s) / den
+	u := q.Cross(r) / den
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Vec2{}, false
+	}
+	return a0.Plus(r.Times(t)), true
+}
+
+//------------------------------------------------------------------------------
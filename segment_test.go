@@ -0,0 +1,152 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func TestClosestPointOnSegment(t *testing.T) {
+	a, b := Vec3{0, 0, 0}, Vec3{4, 0, 0}
+	for _, c := range []struct{ p, e Vec3 }{
+		{Vec3{1, 3, 0}, Vec3{1, 0, 0}},  // interior
+		{Vec3{2, -1, 5}, Vec3{2, 0, 0}}, // interior
+		{Vec3{-3, 2, 0}, Vec3{0, 0, 0}}, // clamped to a
+		{Vec3{7, 0, -1}, Vec3{4, 0, 0}}, // clamped to b
+		{Vec3{4, 0, 0}, Vec3{4, 0, 0}},  // on an endpoint
+		{Vec3{3, 0, 0}, Vec3{3, 0, 0}},  // on the segment
+	} {
+		if r := ClosestPointOnSegment(c.p, a, b); r != c.e {
+			t.Errorf("Wrong result for %#v: %#v", c.p, r)
+		}
+	}
+	if d := DistanceToSegment(Vec3{1, 3, 4}, a, b); d != 5 {
+		t.Errorf("Wrong distance: %v", d)
+	}
+	if d := DistanceToSegment(Vec3{7, 4, 0}, a, b); d != 5 {
+		t.Errorf("Wrong clamped distance: %v", d)
+	}
+	if r := ClosestPointOnSegment(Vec3{1, 2, 3}, a, a); r != a {
+		t.Errorf("Wrong result for degenerate segment: %#v", r)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestClosestPointsSegmentSegment(t *testing.T) {
+	for _, c := range []struct {
+		p1, q1, p2, q2 Vec3
+		c1, c2         Vec3
+	}{
+		// Crossing, interior
+		{Vec3{-1, 0, 0}, Vec3{1, 0, 0}, Vec3{0, -1, 2}, Vec3{0, 1, 2}, Vec3{0, 0, 0}, Vec3{0, 0, 2}},
+		// Clamped on the first segment
+		{Vec3{1, 0, 0}, Vec3{3, 0, 0}, Vec3{0, -1, 2}, Vec3{0, 1, 2}, Vec3{1, 0, 0}, Vec3{0, 0, 2}},
+		// Clamped on both
+		{Vec3{1, 0, 0}, Vec3{3, 0, 0}, Vec3{-1, 2, 0}, Vec3{-1, 5, 0}, Vec3{1, 0, 0}, Vec3{-1, 2, 0}},
+		// Degenerate first segment
+		{Vec3{0, 0, 3}, Vec3{0, 0, 3}, Vec3{-1, 0, 0}, Vec3{1, 0, 0}, Vec3{0, 0, 3}, Vec3{0, 0, 0}},
+		// Degenerate second segment
+		{Vec3{-1, 0, 0}, Vec3{1, 0, 0}, Vec3{5, 1, 0}, Vec3{5, 1, 0}, Vec3{1, 0, 0}, Vec3{5, 1, 0}},
+		// Both degenerate
+		{Vec3{1, 2, 3}, Vec3{1, 2, 3}, Vec3{4, 5, 6}, Vec3{4, 5, 6}, Vec3{1, 2, 3}, Vec3{4, 5, 6}},
+	} {
+		c1, c2 := ClosestPointsSegmentSegment(c.p1, c.q1, c.p2, c.q2)
+		if !isRoughlyEqualVec3(c1, c.c1, 1e-6) || !isRoughlyEqualVec3(c2, c.c2, 1e-6) {
+			t.Errorf("Wrong result for %#v: %#v, %#v", c, c1, c2)
+		}
+	}
+
+	// Parallel segments: distance must still be right
+	c1, c2 := ClosestPointsSegmentSegment(Vec3{0, 0, 0}, Vec3{2, 0, 0}, Vec3{1, 1, 0}, Vec3{3, 1, 0})
+	if d := c2.Minus(c1).Length(); !math.IsRoughlyEqual(d, 1, 1e-6) {
+		t.Errorf("Wrong distance for parallel segments: %v (%#v, %#v)", d, c1, c2)
+	}
+
+	// Compare against brute force sampling
+	rnd := rand.New(rand.NewSource(1))
+	rv := func() Vec3 { return Vec3{rnd.Float32()*4 - 2, rnd.Float32()*4 - 2, rnd.Float32()*4 - 2} }
+	for i := 0; i < 100; i++ {
+		p1, q1, p2, q2 := rv(), rv(), rv(), rv()
+		c1, c2 := ClosestPointsSegmentSegment(p1, q1, p2, q2)
+		d := c2.Minus(c1).Length()
+		best := float32(1e9)
+		for j := 0; j <= 100; j++ {
+			a := p1.Plus(q1.Minus(p1).Times(float32(j) / 100))
+			if l := DistanceToSegment(a, p2, q2); l < best {
+				best = l
+			}
+		}
+		if d > best+1e-5 {
+			t.Errorf("Not the closest pair: %v > %v", d, best)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestIntersectLinePlane(t *testing.T) {
+	pl := Plane{Vec3{0, 1, 0}, -2}
+	for _, c := range []struct {
+		p0, p1 Vec3
+		ok     bool
+		e      Vec3
+	}{
+		{Vec3{1, 0, 3}, Vec3{1, 4, 3}, true, Vec3{1, 2, 3}},   // crossing
+		{Vec3{0, 5, 0}, Vec3{4, 1, -4}, true, Vec3{3, 2, -3}}, // crossing, downwards
+		{Vec3{0, 2, 0}, Vec3{1, 7, 1}, true, Vec3{0, 2, 0}},   // touching at p0
+		{Vec3{0, 0, 0}, Vec3{0, 1, 0}, false, Vec3{}},         // too short
+		{Vec3{0, 3, 0}, Vec3{5, 3, 1}, false, Vec3{}},         // parallel
+		{Vec3{0, 2, 0}, Vec3{5, 2, 1}, false, Vec3{}},         // in the plane
+		{Vec3{1, 1, 1}, Vec3{1, 1, 1}, false, Vec3{}},         // degenerate
+	} {
+		p, ok := IntersectLinePlane(c.p0, c.p1, pl)
+		if ok != c.ok || !isRoughlyEqualVec3(p, c.e, 1e-6) {
+			t.Errorf("Wrong result for %v, %v: %#v, %v", c.p0, c.p1, p, ok)
+		}
+	}
+}
+
+func TestIntersectLines2D(t *testing.T) {
+	for _, c := range []struct {
+		a0, a1, b0, b1 Vec2
+		ok             bool
+		e              Vec2
+	}{
+		{Vec2{0, 0}, Vec2{4, 4}, Vec2{0, 4}, Vec2{4, 0}, true, Vec2{2, 2}},  // crossing
+		{Vec2{0, 0}, Vec2{4, 0}, Vec2{1, -1}, Vec2{1, 3}, true, Vec2{1, 0}}, // crossing
+		{Vec2{0, 0}, Vec2{4, 0}, Vec2{4, 0}, Vec2{5, 2}, true, Vec2{4, 0}},  // touching at an end
+		{Vec2{0, 0}, Vec2{1, 1}, Vec2{0, 4}, Vec2{4, 0}, false, Vec2{}},     // lines cross, segments don't
+		{Vec2{0, 0}, Vec2{4, 0}, Vec2{0, 1}, Vec2{4, 1}, false, Vec2{}},     // parallel
+		{Vec2{0, 0}, Vec2{4, 0}, Vec2{2, 0}, Vec2{6, 0}, false, Vec2{}},     // collinear, overlapping
+		{Vec2{0, 0}, Vec2{1, 0}, Vec2{2, 0}, Vec2{3, 0}, false, Vec2{}},     // collinear, disjoint
+		{Vec2{1, 1}, Vec2{1, 1}, Vec2{0, 0}, Vec2{2, 2}, false, Vec2{}},     // degenerate
+	} {
+		p, ok := IntersectLines2D(c.a0, c.a1, c.b0, c.b1)
+		if ok != c.ok || !isRoughlyEqualVec2(p, c.e, 1e-6) {
+			t.Errorf("Wrong result for %v, %v, %v, %v: %#v, %v", c.a0, c.a1, c.b0, c.b1, p, ok)
+		}
+		if math.IsNaN(p.X) || math.IsNaN(p.Y) {
+			t.Errorf("NaN for %v, %v, %v, %v", c.a0, c.a1, c.b0, c.b1)
+		}
+	}
+	// Random crossings agree with the parametric equations
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		a0, a1 := Vec2{r.Float32()*10 - 5, r.Float32()*10 - 5}, Vec2{r.Float32()*10 - 5, r.Float32()*10 - 5}
+		p := a0.Plus(a1.Minus(a0).Times(r.Float32()))
+		d := Vec2{r.Float32() - 0.5, r.Float32() - 0.5}
+		b0, b1 := p.Minus(d.Times(3)), p.Plus(d.Times(2))
+		if q, ok := IntersectLines2D(a0, a1, b0, b1); !ok || !isRoughlyEqualVec2(q, p, 1e-4) {
+			t.Errorf("Wrong result for %v, %v, %v, %v: %#v instead of %#v", a0, a1, b0, b1, q, p)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
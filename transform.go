@@ -0,0 +1,89 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+//------------------------------------------------------------------------------
+
+// `Transform` is an affine transformation stored as separate position,
+// rotation and scale. It is equivalent to the matrix T * R * S, i.e. points
+// are first scaled, then rotated, then translated.
+//
+// `Rotation` must be normalized. Note that the zero value is not the identity,
+// since its scale is zero: see `TransformIdentity`.
+type Transform struct {
+	Position Vec3
+	Rotation Quat
+	Scale    Vec3
+}
+
+//------------------------------------------------------------------------------
+
+// `TransformIdentity` returns the transform that leaves every point unchanged.
+func TransformIdentity() Transform {
+	return Transform{
+		Rotation: QuatIdentity(),
+		Scale:    Vec3{1, 1, 1},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Mat4` returns the matrix equivalent to `t`.
+func (t Transform) Mat4() Mat4 {
+	return TRS(t.Position, t.Rotation, t.Scale)
+}
+
+// `TransformPoint` returns the point `p` transformed by `t`.
+func (t Transform) TransformPoint(p Vec3) Vec3 {
+	p = t.Rotation.Rotate(Vec3{p.X * t.Scale.X, p.Y * t.Scale.Y, p.Z * t.Scale.Z})
+	return p.Plus(t.Position)
+}
+
+//------------------------------------------------------------------------------
+
+// `Times` returns the composition of `t` with `child`, i.e. the transform
+// from the local space of `child` to the parent space of `t`. This is the
+// operation used to walk down a scene graph.
+//
+// The result is exact when the scale of `t` is uniform, or when the rotation
+// of `child` maps each axis onto an axis (e.g. multiples of a quarter turn
+// around X, Y or Z): the scale factors of `t` are then permuted to follow the
+// axes of `child`. Otherwise the combination contains a shear, which cannot be
+// represented: each axis of `child` is scaled by an average of the scale
+// factors of `t`, weighted by the squared components of that axis.
+func (t Transform) Times(child Transform) Transform {
+	p := child.Position
+	p = Vec3{p.X * t.Scale.X, p.Y * t.Scale.Y, p.Z * t.Scale.Z}
+	// Diagonal of R⁻¹ * S * R, where R is the rotation of child and S the
+	// scale of t
+	r := child.Rotation.Mat3()
+	s := t.Scale
+	return Transform{
+		Position: t.Rotation.Rotate(p).Plus(t.Position),
+		Rotation: t.Rotation.Times(child.Rotation),
+		Scale: Vec3{
+			(r[0][0]*r[0][0]*s.X + r[0][1]*r[0][1]*s.Y + r[0][2]*r[0][2]*s.Z) * child.Scale.X,
+			(r[1][0]*r[1][0]*s.X + r[1][1]*r[1][1]*s.Y + r[1][2]*r[1][2]*s.Z) * child.Scale.Y,
+			(r[2][0]*r[2][0]*s.X + r[2][1]*r[2][1]*s.Y + r[2][2]*r[2][2]*s.Z) * child.Scale.Z,
+		},
+	}
+}
+
+// `Inverse` returns the transform undoing `t`. The scale factors of `t` must
+// be non-zero.
+//
+// The result is exact only when the scale of `t` is uniform (otherwise the
+// inverse contains a shear, which cannot be represented).
+func (t Transform) Inverse() Transform {
+	s := Vec3{1 / t.Scale.X, 1 / t.Scale.Y, 1 / t.Scale.Z}
+	r := t.Rotation.Conjugate()
+	p := r.Rotate(t.Position.Inverse())
+	return Transform{
+		Position: Vec3{p.X * s.X, p.Y * s.Y, p.Z * s.Z},
+		Rotation: r,
+		Scale:    s,
+	}
+}
+
+//------------------------------------------------------------------------------
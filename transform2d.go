@@ -0,0 +1,231 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "github.com/drakmaniso/glam/math"
+
+//------------------------------------------------------------------------------
+
+// `Transform2D` is a single-precision 2D affine transformation, stored as a
+// matrix with 3 columns and 2 rows. It is equivalent to a 3x3 matrix whose
+// bottom row is (0, 0, 1).
+//
+// The first two columns are the linear part, and the last one is the
+// translation.
+//
+// Note: matrices are stored in column-major order, so when writing literals
+// remember to use the transpose.
+type Transform2D [3][2]float32
+
+//------------------------------------------------------------------------------
+
+// `Transform2DIdentity` returns the transformation that leaves every point
+// unchanged.
+func Transform2DIdentity() Transform2D {
+	return Transform2D{
+		{1, 0},
+		{0, 1},
+		{0, 0},
+	}
+}
+
+// `Transform2DTranslation` returns a transformation translating by `t`.
+func Transform2DTranslation(t Vec2) Transform2D {
+	return Transform2D{
+		{1, 0},
+		{0, 1},
+		{t.X, t.Y},
+	}
+}
+
+// `Transform2DRotation` returns a transformation rotating counter-clockwise by
+// `angle` around the origin.
+func Transform2DRotation(angle float32) Transform2D {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+
+	return Transform2D{
+		{c, s},
+		{-s, c},
+		{0, 0},
+	}
+}
+
+// `Transform2DScaling` returns a transformation scaling by `s.X` along the X
+// axis, and by `s.Y` along the Y axis.
+func Transform2DScaling(s Vec2) Transform2D {
+	return Transform2D{
+		{s.X, 0},
+		{0, s.Y},
+		{0, 0},
+	}
+}
+
+// `Transform2DShear` returns a shearing transformation, with the same
+// parameters as `Shear2D`.
+func Transform2DShear(x, y float32) Transform2D {
+	return Transform2D{
+		{1, y},
+		{x, 1},
+		{0, 0},
+	}
+}
+
+// `Transform2DTRS` returns the transformation that scales by `scale`, then
+// rotates counter-clockwise by `angle`, then translates by `translation`.
+//
+// This is the same as the product of the three corresponding transformations,
+// but faster. See also `Decompose`.
+func Transform2DTRS(translation Vec2, angle float32, scale Vec2) Transform2D {
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+
+	return Transform2D{
+		{c * scale.X, s * scale.X},
+		{-s * scale.Y, c * scale.Y},
+		{translation.X, translation.Y},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Mat4` returns the 4x4 matrix equivalent to `t`, acting on the XY plane and
+// leaving the Z coordinate unchanged.
+func (t Transform2D) Mat4() Mat4 {
+	return Mat4{
+		{t[0][0], t[0][1], 0, 0},
+		{t[1][0], t[1][1], 0, 0},
+		{0, 0, 1, 0},
+		{t[2][0], t[2][1], 0, 1},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Times` returns the composition of `t` and `o`, i.e. the transformation
+// applying `o` first, then `t`.
+//
+// See also `Multiply`.
+func (t *Transform2D) Times(o *Transform2D) Transform2D {
+	return Transform2D{
+		{
+			t[0][0]*o[0][0] + t[1][0]*o[0][1],
+			t[0][1]*o[0][0] + t[1][1]*o[0][1],
+		},
+		{
+			t[0][0]*o[1][0] + t[1][0]*o[1][1],
+			t[0][1]*o[1][0] + t[1][1]*o[1][1],
+		},
+		{
+			t[0][0]*o[2][0] + t[1][0]*o[2][1] + t[2][0],
+			t[0][1]*o[2][0] + t[1][1]*o[2][1] + t[2][1],
+		},
+	}
+}
+
+// `Multiply` sets `r` to the composition of `t` and `o`.
+//
+// `r` must not be `t` or `o`.
+//
+// See also `Times`.
+func (r *Transform2D) Multiply(t, o *Transform2D) {
+	r[0][0] = t[0][0]*o[0][0] + t[1][0]*o[0][1]
+	r[0][1] = t[0][1]*o[0][0] + t[1][1]*o[0][1]
+
+	r[1][0] = t[0][0]*o[1][0] + t[1][0]*o[1][1]
+	r[1][1] = t[0][1]*o[1][0] + t[1][1]*o[1][1]
+
+	r[2][0] = t[0][0]*o[2][0] + t[1][0]*o[2][1] + t[2][0]
+	r[2][1] = t[0][1]*o[2][0] + t[1][1]*o[2][1] + t[2][1]
+}
+
+//------------------------------------------------------------------------------
+
+// `TransformPoint` returns the point `p` transformed by `t` (i.e. with the
+// translation applied).
+//
+// See also `TransformVector`.
+func (t *Transform2D) TransformPoint(p Vec2) Vec2 {
+	return Vec2{
+		t[0][0]*p.X + t[1][0]*p.Y + t[2][0],
+		t[0][1]*p.X + t[1][1]*p.Y + t[2][1],
+	}
+}
+
+// `TransformVector` returns the vector `v` transformed by the linear part of
+// `t` (i.e. ignoring the translation).
+//
+// See also `TransformPoint`.
+func (t *Transform2D) TransformVector(v Vec2) Vec2 {
+	return Vec2{
+		t[0][0]*v.X + t[1][0]*v.Y,
+		t[0][1]*v.X + t[1][1]*v.Y,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Inverse` returns the inverse of `t`, and true; or, if the linear part of
+// `t` is singular (with the same criterion as `Mat2.Inverse`), the zero value
+// and false.
+func (t Transform2D) Inverse() (Transform2D, bool) {
+	l, ok := Mat2{t[0], t[1]}.Inverse()
+	if !ok {
+		return Transform2D{}, false
+	}
+	p := l.TimesVec2(Vec2{t[2][0], t[2][1]})
+	return Transform2D{l[0], l[1], {-p.X, -p.Y}}, true
+}
+
+// `Decompose` returns the translation, counter-clockwise rotation angle (in
+// [-Pi, Pi]) and scale such that `Transform2DTRS` rebuilds `t`.
+//
+// A reflection is returned as a negative `scale.Y`. The decomposition is exact
+// only if `t` has no shear, i.e. if its first two columns are orthogonal;
+// otherwise the shear is lost.
+func (t Transform2D) Decompose() (translation Vec2, angle float32, scale Vec2) {
+	translation = Vec2{t[2][0], t[2][1]}
+	scale.X = math.Sqrt(t[0][0]*t[0][0] + t[0][1]*t[0][1])
+	if scale.X == 0 {
+		scale.Y = math.Sqrt(t[1][0]*t[1][0] + t[1][1]*t[1][1])
+		return translation, 0, scale
+	}
+	angle = math.Atan2(t[0][1], t[0][0])
+	scale.Y = (t[0][0]*t[1][1] - t[1][0]*t[0][1]) / scale.X
+	return translation, angle, scale
+}
+
+//------------------------------------------------------------------------------
+
+// `Affine2` is another name for `Transform2D`, the 2x3 affine matrix used by
+// 2D engines and canvas APIs.
+//
+// `Rotation2D` and `Scaling2D` already build a `Mat2`; use
+// `Transform2DRotation` and `Transform2DScaling` (or `Transform2DFromMat2`)
+// to get an `Affine2`.
+type Affine2 = Transform2D
+
+// `Affine2Identity` is the same as `Transform2DIdentity`.
+func Affine2Identity() Affine2 {
+	return Transform2DIdentity()
+}
+
+// `Translation2D` is the same as `Transform2DTranslation`.
+func Translation2D(t Vec2) Affine2 {
+	return Transform2DTranslation(t)
+}
+
+// `Transform2DFromMat2` returns the transformation whose linear part is `m`,
+// and without translation.
+func Transform2DFromMat2(m Mat2) Transform2D {
+	return Transform2D{m[0], m[1], {0, 0}}
+}
+
+// `Mul` is the same as `Times`: it returns the transformation applying `o`
+// first, then `t`.
+func (t *Transform2D) Mul(o *Transform2D) Transform2D {
+	return t.Times(o)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,167 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func isRoughlyEqualTransform2D(a, b Transform2D, epsilon float32) bool {
+	for c := range a {
+		if !isRoughlyEqualVec2(Vec2{a[c][0], a[c][1]}, Vec2{b[c][0], b[c][1]}, epsilon) {
+			return false
+		}
+	}
+	return true
+}
+
+//------------------------------------------------------------------------------
+
+func TestTransform2DTRS(t *testing.T) {
+	tr := Transform2DTranslation(Vec2{3, -2})
+	ro := Transform2DRotation(0.6)
+	sc := Transform2DScaling(Vec2{2, 0.5})
+	rs := ro.Times(&sc)
+	p := tr.Times(&rs)
+	m := Transform2DTRS(Vec2{3, -2}, 0.6, Vec2{2, 0.5})
+	if !isRoughlyEqualTransform2D(m, p, 1e-6) {
+		t.Errorf("Wrong result: %#v instead of %#v", m, p)
+	}
+	var q Transform2D
+	q.Multiply(&tr, &rs)
+	if q != p {
+		t.Errorf("Multiply and Times differ: %#v", q)
+	}
+	if v := ro.TransformVector(Vec2{1, 0}); !isRoughlyEqualVec2(v, Vec2{math.Cos(0.6), math.Sin(0.6)}, 1e-6) {
+		t.Errorf("Wrong rotation: %#v", v)
+	}
+}
+
+func TestTransform2D_Times(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	steps := []Transform2D{
+		Transform2DScaling(Vec2{2, 0.5}),
+		Transform2DShear(0.3, -0.2),
+		Transform2DRotation(1.1),
+		Transform2DTranslation(Vec2{3, -2}),
+	}
+	// Composed with the first step on the right
+	c := Transform2DIdentity()
+	for i := range steps {
+		c = steps[i].Times(&c)
+	}
+	for i := 0; i < 100; i++ {
+		p := Vec2{r.Float32()*10 - 5, r.Float32()*10 - 5}
+		e := p
+		for j := range steps {
+			e = steps[j].TransformPoint(e)
+		}
+		if q := c.TransformPoint(p); !isRoughlyEqualVec2(q, e, 1e-5) {
+			t.Errorf("Wrong result for %v: %#v instead of %#v", p, q, e)
+		}
+	}
+	s := Transform2DShear(0.25, 0)
+	if p := s.TransformPoint(Vec2{1, 2}); p != (Vec2{1.5, 2}) {
+		t.Errorf("Wrong shear: %#v", p)
+	}
+	if m := Shear2D(0.3, -0.2); Transform2DShear(0.3, -0.2) != (Transform2D{m[0], m[1], {0, 0}}) {
+		t.Errorf("Differs from Shear2D")
+	}
+}
+
+func TestAffine2(t *testing.T) {
+	if a := Affine2Identity(); a != Transform2DIdentity() {
+		t.Errorf("Wrong identity: %#v", a)
+	}
+	if a := Translation2D(Vec2{3, -2}); a != Transform2DTranslation(Vec2{3, -2}) {
+		t.Errorf("Wrong translation: %#v", a)
+	}
+	if a := Transform2DFromMat2(Rotation2D(1.1)); a != Transform2DRotation(1.1) {
+		t.Errorf("Wrong rotation: %#v", a)
+	}
+	if a := Transform2DFromMat2(Scaling2D(Vec2{2, 0.5})); a != Transform2DScaling(Vec2{2, 0.5}) {
+		t.Errorf("Wrong scaling: %#v", a)
+	}
+	tr, ro := Translation2D(Vec2{3, -2}), Transform2DRotation(1.1)
+	m := tr.Mul(&ro)
+	if e := tr.Times(&ro); m != e {
+		t.Errorf("Mul differs from Times: %#v instead of %#v", m, e)
+	}
+	p := Vec2{1, 0.5}
+	if q, e := m.TransformPoint(p), tr.TransformPoint(ro.TransformPoint(p)); !isRoughlyEqualVec2(q, e, 1e-6) {
+		t.Errorf("Wrong composition order: %#v instead of %#v", q, e)
+	}
+}
+
+func TestTransform2D_TransformPoint(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		m := Transform2DTRS(
+			Vec2{r.Float32()*10 - 5, r.Float32()*10 - 5},
+			r.Float32()*2*math.Pi,
+			Vec2{0.5 + r.Float32(), 0.5 + r.Float32()},
+		)
+		v := Vec2{r.Float32() - 0.5, r.Float32() - 0.5}
+		p := m.TransformPoint(v)
+		d := m.TransformVector(v)
+		if !isRoughlyEqualVec2(p.Minus(d), Vec2{m[2][0], m[2][1]}, 1e-5) {
+			t.Errorf("Point and vector differ by %#v", p.Minus(d))
+		}
+		m4 := m.Mat4()
+		e := m4.TimesVec4(Vec4{v.X, v.Y, 7, 1})
+		if !isRoughlyEqualVec4(e, Vec4{p.X, p.Y, 7, 1}, 1e-6) {
+			t.Errorf("Wrong Mat4: %#v instead of %#v", e, p)
+		}
+	}
+	// With exactly representable values, the difference is exact
+	m := Transform2D{{0, 2}, {-0.5, 0}, {3, -2}}
+	v := Vec2{0.25, 1.5}
+	if p, d := m.TransformPoint(v), m.TransformVector(v); d != (Vec2{-0.75, 0.5}) || p != (Vec2{2.25, -1.5}) {
+		t.Errorf("Wrong result: %#v, %#v", p, d)
+	}
+}
+
+func TestTransform2D_Inverse(t *testing.T) {
+	m := Transform2DTRS(Vec2{3, -2}, 0.6, Vec2{2, 0.5})
+	inv, ok := m.Inverse()
+	if !ok {
+		t.Fatalf("Reported singular")
+	}
+	if p := m.Times(&inv); !isRoughlyEqualTransform2D(p, Transform2DIdentity(), 1e-6) {
+		t.Errorf("Wrong result: %#v", p)
+	}
+	if p := inv.TransformPoint(Vec2{3, -2}); !isRoughlyEqualVec2(p, Vec2{0, 0}, 1e-6) {
+		t.Errorf("Wrong result: %#v", p)
+	}
+	if _, ok := Transform2DScaling(Vec2{1, 0}).Inverse(); ok {
+		t.Errorf("Singular transformation not detected")
+	}
+}
+
+func TestTransform2D_Decompose(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		tr := Vec2{r.Float32()*10 - 5, r.Float32()*10 - 5}
+		a := (r.Float32()*2 - 1) * 3
+		s := Vec2{0.5 + r.Float32(), r.Float32()*3 - 1.5}
+		m := Transform2DTRS(tr, a, s)
+		tr2, a2, s2 := m.Decompose()
+		if !isRoughlyEqualVec2(tr2, tr, 1e-6) || math.Abs(a2-a) > 1e-5 || !isRoughlyEqualVec2(s2, s, 1e-5) {
+			t.Errorf("Wrong result for %v, %v, %v: %v, %v, %v", tr, a, s, tr2, a2, s2)
+		}
+		if n := Transform2DTRS(tr2, a2, s2); !isRoughlyEqualTransform2D(n, m, 1e-5) {
+			t.Errorf("No round-trip for %#v: %#v", m, n)
+		}
+	}
+	if tr, a, s := Transform2DIdentity().Decompose(); tr != (Vec2{}) || a != 0 || s != (Vec2{1, 1}) {
+		t.Errorf("Wrong result for identity: %v, %v, %v", tr, a, s)
+	}
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,144 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func randomQuat(r *rand.Rand) Quat {
+	axis := Vec3{r.Float32() - 0.5, r.Float32() - 0.5, r.Float32() - 0.5}.Normalized()
+	return QuatFromMat3(Rotation(r.Float32()*2*math.Pi-math.Pi, axis).Mat3())
+}
+
+func randomTransform(r *rand.Rand, uniform bool) Transform {
+	s := Vec3{0.5 + r.Float32()*1.5, 0.5 + r.Float32()*1.5, 0.5 + r.Float32()*1.5}
+	if uniform {
+		s.Y, s.Z = s.X, s.X
+	}
+	return Transform{
+		Position: Vec3{r.Float32()*10 - 5, r.Float32()*10 - 5, r.Float32()*10 - 5},
+		Rotation: randomQuat(r),
+		Scale:    s,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestTransformIdentity(t *testing.T) {
+	if m := TransformIdentity().Mat4(); m != Identity() {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	p := Vec3{1, -2, 3}
+	if q := TransformIdentity().TransformPoint(p); q != p {
+		t.Errorf("Wrong result: %#v", q)
+	}
+}
+
+func TestTransform_Mat4(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		tr := randomTransform(rnd, false)
+		m := tr.Mat4()
+		p, q, s := m.DecomposeTRS()
+		if !isRoughlyEqualVec3(p, tr.Position, 1e-5) || !isRoughlyEqualVec3(s, tr.Scale, 1e-5) ||
+			!isRoughlyEqualMat3(q.Mat3(), tr.Rotation.Mat3(), 1e-5) {
+			t.Errorf("Wrong result for %#v: %#v", tr, m)
+		}
+		v := Vec3{rnd.Float32()*4 - 2, rnd.Float32()*4 - 2, rnd.Float32()*4 - 2}
+		a := tr.TransformPoint(v)
+		b := m.TimesVec4(v.Homogenized()).Dehomogenized()
+		if !isRoughlyEqualVec3(a, b, 1e-4) {
+			t.Errorf("TransformPoint differs from matrix: %#v instead of %#v", a, b)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestTransform_Times(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		// Uniform parent scale: exact for any child
+		parent := randomTransform(rnd, true)
+		child := randomTransform(rnd, false)
+		pm, cm := parent.Mat4(), child.Mat4()
+		e := pm.Times(&cm)
+		if m := parent.Times(child).Mat4(); !isRoughlyEqualMat4(m, e, 1e-4) {
+			t.Errorf("Composition differs from matrix product: %#v instead of %#v", m, e)
+		}
+
+		// Non-uniform parent scale, child without rotation
+		parent = randomTransform(rnd, false)
+		child.Rotation = QuatIdentity()
+		pm, cm = parent.Mat4(), child.Mat4()
+		e = pm.Times(&cm)
+		if m := parent.Times(child).Mat4(); !isRoughlyEqualMat4(m, e, 1e-4) {
+			t.Errorf("Composition differs from matrix product: %#v instead of %#v", m, e)
+		}
+	}
+
+	// Non-uniform parent scale, child rotated by quarter turns: the scale of
+	// the parent follows the axes of the child
+	parent := Transform{Vec3{1, -2, 3}, QuatFromMat3(Mat3RotationX(0.7)), Vec3{2, 1, 3}}
+	for _, r := range []Quat{
+		QuatFromAxisAngle(Vec3{0, 0, 1}, math.Pi/2),
+		QuatFromAxisAngle(Vec3{0, 1, 0}, -math.Pi/2),
+		QuatFromAxisAngle(Vec3{1, 0, 0}, math.Pi),
+		QuatFromAxisAngle(Vec3{1, 1, 1}.Normalized(), 2*math.Pi/3),
+	} {
+		child := Transform{Vec3{0.5, 1, -1}, r, Vec3{1, 0.5, 4}}
+		w := parent.Times(child)
+		for _, v := range []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {-1, 2, 0.5}} {
+			e := parent.TransformPoint(child.TransformPoint(v))
+			if p := w.TransformPoint(v); !isRoughlyEqualVec3(p, e, 1e-5) {
+				t.Errorf("Wrong point %#v for child rotation %#v: %#v instead of %#v", v, r, p, e)
+			}
+		}
+		pm, cm := parent.Mat4(), child.Mat4()
+		e := pm.Times(&cm)
+		if m := w.Mat4(); !isRoughlyEqualMat4(m, e, 1e-5) {
+			t.Errorf("Composition differs from matrix product: %#v instead of %#v", m, e)
+		}
+	}
+	p := Transform{Scale: Vec3{2, 1, 1}, Rotation: QuatIdentity()}
+	c := Transform{Scale: Vec3{1, 1, 1}, Rotation: QuatFromAxisAngle(Vec3{0, 0, 1}, math.Pi/2)}
+	if v := p.Times(c).TransformPoint(Vec3{1, 0, 0}); !isRoughlyEqualVec3(v, Vec3{0, 1, 0}, 1e-6) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+
+	// A three-level hierarchy
+	root := Transform{Vec3{10, 0, 0}, QuatFromMat3(Mat3RotationY(math.Pi / 2)), Vec3{2, 2, 2}}
+	arm := Transform{Vec3{0, 1, 0}, QuatFromMat3(Mat3RotationZ(math.Pi / 2)), Vec3{1, 1, 1}}
+	hand := Transform{Vec3{1, 0, 0}, QuatIdentity(), Vec3{1, 1, 1}}
+	w := root.Times(arm).Times(hand)
+	if p := w.TransformPoint(Vec3{}); !isRoughlyEqualVec3(p, Vec3{10, 4, 0}, 1e-5) {
+		t.Errorf("Wrong world position: %#v", p)
+	}
+}
+
+func TestTransform_Inverse(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	for i := 0; i < 100; i++ {
+		tr := randomTransform(rnd, true)
+		inv := tr.Inverse()
+		if m := tr.Times(inv).Mat4(); !isRoughlyEqualMat4(m, Identity(), 1e-5) {
+			t.Errorf("Not the inverse: %#v", m)
+		}
+		if m := inv.Times(tr).Mat4(); !isRoughlyEqualMat4(m, Identity(), 1e-5) {
+			t.Errorf("Not the inverse: %#v", m)
+		}
+		v := Vec3{rnd.Float32()*4 - 2, rnd.Float32()*4 - 2, rnd.Float32()*4 - 2}
+		if p := inv.TransformPoint(tr.TransformPoint(v)); !isRoughlyEqualVec3(p, v, 1e-4) {
+			t.Errorf("No round-trip for %#v: %#v", v, p)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
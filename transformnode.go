@@ -0,0 +1,145 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+//------------------------------------------------------------------------------
+
+// `TransformNode` is a node in a transform hierarchy (e.g. a scene graph). It
+// holds a local transform, relative to its parent, and caches its world
+// matrix.
+//
+// The world matrix is only recomputed when needed: changing the local
+// transform or the parent of a node invalidates the cached matrices of the
+// node and all its descendants, which are recomputed on the next call to
+// `World`.
+//
+// A `TransformNode` must be created with `NewTransformNode`. It is not safe
+// for concurrent use.
+type TransformNode struct {
+	local    Transform
+	parent   *TransformNode
+	children []*TransformNode
+
+	world      Mat4
+	dirty      bool
+	recomputes int
+}
+
+// `NewTransformNode` returns a new root node with the transform `local`.
+func NewTransformNode(local Transform) *TransformNode {
+	return &TransformNode{local: local, dirty: true}
+}
+
+//------------------------------------------------------------------------------
+
+// `Parent` returns the parent of `n`, or nil if `n` is a root.
+func (n *TransformNode) Parent() *TransformNode {
+	return n.parent
+}
+
+// `SetParent` moves `n` under `parent`, or makes it a root if `parent` is nil.
+// The local transform of `n` is kept, so its world matrix changes.
+//
+// `parent` must not be `n` or one of its descendants.
+func (n *TransformNode) SetParent(parent *TransformNode) {
+	if n.parent != nil {
+		c := n.parent.children
+		for i := range c {
+			if c[i] == n {
+				n.parent.children = append(c[:i], c[i+1:]...)
+				break
+			}
+		}
+	}
+	n.parent = parent
+	if parent != nil {
+		parent.children = append(parent.children, n)
+	}
+	n.invalidate()
+}
+
+//------------------------------------------------------------------------------
+
+// `Local` returns the transform of `n` relative to its parent.
+func (n *TransformNode) Local() Transform {
+	return n.local
+}
+
+// `SetLocal` sets the transform of `n` relative to its parent.
+func (n *TransformNode) SetLocal(t Transform) {
+	n.local = t
+	n.invalidate()
+}
+
+// `SetLocalPosition` sets the position of `n` relative to its parent.
+func (n *TransformNode) SetLocalPosition(p Vec3) {
+	n.local.Position = p
+	n.invalidate()
+}
+
+// `SetLocalRotation` sets the rotation of `n` relative to its parent.
+func (n *TransformNode) SetLocalRotation(r Quat) {
+	n.local.Rotation = r
+	n.invalidate()
+}
+
+// `SetLocalScale` sets the scale of `n` relative to its parent.
+func (n *TransformNode) SetLocalScale(s Vec3) {
+	n.local.Scale = s
+	n.invalidate()
+}
+
+// `invalidate` marks the world matrices of `n` and its descendants as out of
+// date. A node is never up to date while its parent is not, so the walk can
+// stop at nodes already invalidated.
+func (n *TransformNode) invalidate() {
+	if n.dirty {
+		return
+	}
+	n.dirty = true
+	for _, c := range n.children {
+		c.invalidate()
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `World` returns the matrix transforming the local space of `n` to world
+// space, i.e. the product of the local matrices of all its ancestors and its
+// own.
+func (n *TransformNode) World() Mat4 {
+	if n.dirty {
+		l := n.local.Mat4()
+		if n.parent != nil {
+			p := n.parent.World()
+			n.world = p.Times(&l)
+		} else {
+			n.world = l
+		}
+		n.dirty = false
+		n.recomputes++
+	}
+	return n.world
+}
+
+// `LocalToWorld` returns the world coordinates of `point`, given in the local
+// space of `n`.
+//
+// See also `WorldToLocal`.
+func (n *TransformNode) LocalToWorld(point Vec3) Vec3 {
+	m := n.World()
+	return m.TransformPoint(point)
+}
+
+// `WorldToLocal` returns the coordinates, in the local space of `n`, of
+// `point`, given in world coordinates. The scale factors of `n` and its
+// ancestors must be non-zero.
+//
+// See also `LocalToWorld`.
+func (n *TransformNode) WorldToLocal(point Vec3) Vec3 {
+	inv, _ := n.World().InverseAffine()
+	return inv.TransformPoint(point)
+}
+
+//------------------------------------------------------------------------------
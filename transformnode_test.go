@@ -0,0 +1,91 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"math/rand"
+	"testing"
+)
+
+//------------------------------------------------------------------------------
+
+func TestTransformNode(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	root := NewTransformNode(randomTransform(r, false))
+	mid := NewTransformNode(randomTransform(r, false))
+	leaf := NewTransformNode(randomTransform(r, false))
+	other := NewTransformNode(randomTransform(r, false))
+	mid.SetParent(root)
+	leaf.SetParent(mid)
+	other.SetParent(root)
+	if leaf.Parent() != mid || root.Parent() != nil {
+		t.Fatalf("Wrong parents")
+	}
+
+	expected := func(nodes ...*TransformNode) Mat4 {
+		m := Identity()
+		for _, n := range nodes {
+			l := n.Local().Mat4()
+			m = m.Times(&l)
+		}
+		return m
+	}
+	check := func(n *TransformNode, e Mat4) {
+		if w := n.World(); !isRoughlyEqualMat4(w, e, 1e-4) {
+			t.Errorf("Wrong world matrix: %#v instead of %#v", w, e)
+		}
+	}
+	check(leaf, expected(root, mid, leaf))
+	check(other, expected(root, other))
+	check(leaf, expected(root, mid, leaf))
+	if root.recomputes != 1 || mid.recomputes != 1 || leaf.recomputes != 1 || other.recomputes != 1 {
+		t.Errorf("Wrong recomputes: %d %d %d %d", root.recomputes, mid.recomputes, leaf.recomputes, other.recomputes)
+	}
+
+	mid.SetLocalPosition(Vec3{1, 2, 3})
+	mid.SetLocalRotation(randomQuat(r))
+	mid.SetLocalScale(Vec3{2, 1, 0.5})
+	check(leaf, expected(root, mid, leaf))
+	check(other, expected(root, other))
+	if root.recomputes != 1 || mid.recomputes != 2 || leaf.recomputes != 2 || other.recomputes != 1 {
+		t.Errorf("Wrong recomputes: %d %d %d %d", root.recomputes, mid.recomputes, leaf.recomputes, other.recomputes)
+	}
+
+	root.SetLocal(randomTransform(r, true))
+	check(leaf, expected(root, mid, leaf))
+	check(other, expected(root, other))
+	if root.recomputes != 2 || mid.recomputes != 3 || leaf.recomputes != 3 || other.recomputes != 2 {
+		t.Errorf("Wrong recomputes: %d %d %d %d", root.recomputes, mid.recomputes, leaf.recomputes, other.recomputes)
+	}
+
+	// Reparenting
+	leaf.SetParent(other)
+	check(leaf, expected(root, other, leaf))
+	if len(mid.children) != 0 || len(other.children) != 1 {
+		t.Errorf("Wrong children: %v, %v", mid.children, other.children)
+	}
+	leaf.SetParent(nil)
+	check(leaf, expected(leaf))
+}
+
+func TestTransformNode_LocalToWorld(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	root := NewTransformNode(randomTransform(r, false))
+	child := NewTransformNode(randomTransform(r, false))
+	child.SetParent(root)
+	for i := 0; i < 100; i++ {
+		p := Vec3{r.Float32()*10 - 5, r.Float32()*10 - 5, r.Float32()*10 - 5}
+		l, c := root.Local(), child.Local()
+		e := l.TransformPoint(c.TransformPoint(p))
+		w := child.LocalToWorld(p)
+		if !isRoughlyEqualVec3(w, e, 1e-4) {
+			t.Errorf("Wrong world point: %#v instead of %#v", w, e)
+		}
+		if q := child.WorldToLocal(w); !isRoughlyEqualVec3(q, p, 1e-4) {
+			t.Errorf("No round-trip for %#v: %#v", p, q)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
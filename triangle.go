@@ -0,0 +1,33 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+//------------------------------------------------------------------------------
+
+// `TriangleArea` returns the area of the triangle `abc`, i.e.
+// `0.5*|(b-a)×(c-a)|`.
+//
+// See also `TriangleNormal`.
+func TriangleArea(a, b, c Vec3) float32 {
+	return b.Minus(a).Cross(c.Minus(a)).Length() / 2
+}
+
+// `TriangleNormal` returns the normalized face normal of the triangle `abc`.
+// It points towards the side from which the vertices are in counter-clockwise
+// order (the usual convention for front faces).
+//
+// If the triangle is degenerate (i.e. its vertices are aligned), the result is
+// the zero vector.
+//
+// See also `TriangleArea`.
+func TriangleNormal(a, b, c Vec3) Vec3 {
+	n := b.Minus(a).Cross(c.Minus(a))
+	l := n.LengthRobust()
+	if l == 0 {
+		return Vec3{}
+	}
+	return n.Slash(l)
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,52 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//------------------------------------------------------------------------------
+
+func TestTriangleArea(t *testing.T) {
+	if a := TriangleArea(Vec3{0, 0, 0}, Vec3{1, 0, 0}, Vec3{0, 1, 0}); a != 0.5 {
+		t.Errorf("Wrong area for unit right triangle: %v", a)
+	}
+	if a := TriangleArea(Vec3{1, 2, 3}, Vec3{1, 5, 3}, Vec3{1, 2, 7}); a != 6 {
+		t.Errorf("Wrong area: %v", a)
+	}
+	if a := TriangleArea(Vec3{0, 0, 0}, Vec3{1, 1, 1}, Vec3{3, 3, 3}); a != 0 {
+		t.Errorf("Wrong area for degenerate triangle: %v", a)
+	}
+}
+
+func TestTriangleNormal(t *testing.T) {
+	if n := TriangleNormal(Vec3{0, 0, 0}, Vec3{1, 0, 0}, Vec3{0, 1, 0}); n != (Vec3{0, 0, 1}) {
+		t.Errorf("Wrong normal for counter-clockwise triangle: %#v", n)
+	}
+	if n := TriangleNormal(Vec3{0, 0, 0}, Vec3{0, 1, 0}, Vec3{1, 0, 0}); n != (Vec3{0, 0, -1}) {
+		t.Errorf("Wrong normal for clockwise triangle: %#v", n)
+	}
+	n := TriangleNormal(Vec3{1, 0, 0}, Vec3{0, 1, 0}, Vec3{0, 0, 1})
+	if e := math.Sqrt(1.0 / 3); !isRoughlyEqualVec3(n, Vec3{e, e, e}, 1e-6) {
+		t.Errorf("Wrong normal: %#v", n)
+	}
+	for _, c := range [][3]Vec3{
+		{{0, 0, 0}, {1, 1, 1}, {3, 3, 3}},
+		{{1, 2, 3}, {1, 2, 3}, {4, 5, 6}},
+		{{1, 2, 3}, {1, 2, 3}, {1, 2, 3}},
+	} {
+		if n := TriangleNormal(c[0], c[1], c[2]); n != (Vec3{}) {
+			t.Errorf("Wrong normal for degenerate triangle %v: %#v", c, n)
+		}
+	}
+	// Tiny triangles do not underflow
+	if n := TriangleNormal(Vec3{0, 0, 0}, Vec3{1e-20, 0, 0}, Vec3{0, 1e-20, 0}); n != (Vec3{0, 0, 1}) {
+		t.Errorf("Wrong normal for tiny triangle: %#v", n)
+	}
+}
+
+//------------------------------------------------------------------------------
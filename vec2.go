@@ -120,6 +120,13 @@ func (a Vec2) Dot(b Vec2) float32 {
 	return a.X*b.X + a.Y*b.Y
 }
 
+// `Cross` returns the Z coordinate of the cross product of `a` and `b` (seen
+// as 3D vectors in the XY plane). It is positive if `b` is counter-clockwise
+// from `a`.
+func (a Vec2) Cross(b Vec2) float32 {
+	return a.X*b.Y - a.Y*b.X
+}
+
 //------------------------------------------------------------------------------
 
 // `Length` returns `|a|` (the euclidian length of `a`).
@@ -147,3 +154,90 @@ func (a *Vec2) Normalize() {
 }
 
 //------------------------------------------------------------------------------
+
+// `Mix` returns the linear interpolation between `a` and `b`, i.e.
+// `a*(1-t) + b*t` (as the GLSL function of the same name).
+//
+// See also `math.Mix`.
+func (a Vec2) Mix(b Vec2, t float32) Vec2 {
+	return Vec2{math.Mix(a.X, b.X, t), math.Mix(a.Y, b.Y, t)}
+}
+
+//------------------------------------------------------------------------------
+
+// `Min` returns the component-wise minimum of `a` and `b`.
+func (a Vec2) Min(b Vec2) Vec2 {
+	if b.X < a.X {
+		a.X = b.X
+	}
+	if b.Y < a.Y {
+		a.Y = b.Y
+	}
+	return a
+}
+
+// `Max` returns the component-wise maximum of `a` and `b`.
+func (a Vec2) Max(b Vec2) Vec2 {
+	if b.X > a.X {
+		a.X = b.X
+	}
+	if b.Y > a.Y {
+		a.Y = b.Y
+	}
+	return a
+}
+
+//------------------------------------------------------------------------------
+
+// `Pow` returns `a` with each component raised to the power `e`.
+//
+// See `Vec3.Pow`.
+func (a Vec2) Pow(e float32) Vec2 {
+	return Vec2{math.Pow(a.X, e), math.Pow(a.Y, e)}
+}
+
+// `PowV` returns `a` with each component raised to the power of the
+// corresponding component of `e`.
+func (a Vec2) PowV(e Vec2) Vec2 {
+	return Vec2{math.Pow(a.X, e.X), math.Pow(a.Y, e.Y)}
+}
+
+// `Exp` returns the base-e exponential of each component of `a`.
+func (a Vec2) Exp() Vec2 {
+	return Vec2{math.Exp(a.X), math.Exp(a.Y)}
+}
+
+// `Log` returns the natural logarithm of each component of `a`.
+func (a Vec2) Log() Vec2 {
+	return Vec2{math.Log(a.X), math.Log(a.Y)}
+}
+
+// `Sqrt` returns the square root of each component of `a`.
+func (a Vec2) Sqrt() Vec2 {
+	return Vec2{math.Sqrt(a.X), math.Sqrt(a.Y)}
+}
+
+//------------------------------------------------------------------------------
+
+// `Equal` returns true if `a` and `b` are exactly equal (this is the same as
+// `a == b`).
+func (a Vec2) Equal(b Vec2) bool {
+	return a == b
+}
+
+// `RoughlyEqual` returns true if the absolute difference between each
+// component of `a` and `b` is less than `epsilon`.
+func (a Vec2) RoughlyEqual(b Vec2, epsilon float32) bool {
+	return roughlyEqual([]float32{a.X, a.Y}, []float32{b.X, b.Y}, epsilon)
+}
+
+// `NearlyEqual` returns true if the difference between each component of `a`
+// and `b` is less than `epsilon` times the largest absolute component of
+// either vector.
+//
+// See `Vec3.NearlyEqual`.
+func (a Vec2) NearlyEqual(b Vec2, epsilon float32) bool {
+	return nearlyEqual([]float32{a.X, a.Y}, []float32{b.X, b.Y}, epsilon)
+}
+
+//------------------------------------------------------------------------------
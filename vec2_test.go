@@ -0,0 +1,36 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import (
+	"testing"
+
+	"github.com/drakmaniso/glam/math"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestVec2_Pow(t *testing.T) {
+	a := Vec2{0.25, 9}
+	if r := a.Pow(0.5); r != (Vec2{0.5, 3}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.PowV(Vec2{2, -0.5}); r != (Vec2{0.0625, math.Pow(9, -0.5)}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.Sqrt(); r != (Vec2{0.5, 3}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := (Vec2{0, -1}).Exp(); r != (Vec2{1, math.Exp(-1)}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := (Vec2{4, 1}).Log(); r != (Vec2{math.Log(4), 0}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := (Vec2{0, -1}).Log(); !math.IsInf(r.X, -1) || !math.IsNaN(r.Y) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+}
+
+//-----------------------------------------------------------------------------
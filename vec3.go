@@ -3,7 +3,12 @@
 
 package glam
 
-import "github.com/drakmaniso/glam/math"
+import (
+	"fmt"
+	stdmath "math"
+
+	"github.com/drakmaniso/glam/math"
+)
 
 //------------------------------------------------------------------------------
 
@@ -141,6 +146,30 @@ func (a Vec3) Dot(b Vec3) float32 {
 	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
 }
 
+// `DotPrecise` returns the dot product of `a` and `b`, with the products
+// accumulated in double precision.
+//
+// The sum is computed in double precision and rounded once, to float32, at the
+// end. The products themselves are exact, and the additions round with about
+// 2^29 times less error than in single precision, so terms with large
+// magnitudes and opposite signs (e.g. with world coordinates far from the
+// origin) cancel much more accurately than with `Dot`. It is also slower.
+func (a Vec3) DotPrecise(b Vec3) float32 {
+	return float32(float64(a.X)*float64(b.X) +
+		float64(a.Y)*float64(b.Y) +
+		float64(a.Z)*float64(b.Z))
+}
+
+// `ProjectOntoPlane` returns the component of `a` lying in the plane
+// orthogonal to `normal`, i.e. `a - (a·n)n`. `normal` must be normalized.
+//
+// This removes the normal component of `a`, e.g. to make a movement slide
+// along a surface. See also `Plane`.
+func (a Vec3) ProjectOntoPlane(normal Vec3) Vec3 {
+	d := a.Dot(normal)
+	return Vec3{a.X - d*normal.X, a.Y - d*normal.Y, a.Z - d*normal.Z}
+}
+
 //------------------------------------------------------------------------------
 
 // `Length` returns `|a|` (the euclidian length of `a`).
@@ -148,6 +177,40 @@ func (a Vec3) Length() float32 {
 	return math.Sqrt(a.X*a.X + a.Y*a.Y + a.Z*a.Z)
 }
 
+// `LengthPrecise` returns `|a|`, with the squared components accumulated and
+// the square root taken in double precision.
+//
+// The result is correctly rounded in most cases, and neither overflows nor
+// underflows as long as `|a|` itself is a normal float32 (whereas `Length`
+// returns +Inf as soon as a component exceeds about 1.8e19, and 0 when they
+// are all below about 1e-23). It is slower than `Length`.
+func (a Vec3) LengthPrecise() float32 {
+	x, y, z := float64(a.X), float64(a.Y), float64(a.Z)
+	return float32(stdmath.Sqrt(x*x + y*y + z*z))
+}
+
+// `LengthRobust` returns `|a|`, computed by first dividing the components by
+// the largest absolute one, as in `hypot`.
+//
+// Unlike `Length`, the result neither overflows nor underflows for any finite
+// `a` whose length is representable, and it stays in single precision. It
+// costs a few divisions more; see also `LengthPrecise`.
+func (a Vec3) LengthRobust() float32 {
+	x, y, z := math.Abs(a.X), math.Abs(a.Y), math.Abs(a.Z)
+	m := x
+	if y > m {
+		m = y
+	}
+	if z > m {
+		m = z
+	}
+	if m == 0 || math.IsInf(m, 1) {
+		return m
+	}
+	x, y, z = x/m, y/m, z/m
+	return m * math.Sqrt(x*x+y*y+z*z)
+}
+
 // `Normalized` return `a/|a|` (i.e. the normalization of `a`).
 // `a` must be non-zero.
 //
@@ -157,6 +220,15 @@ func (a Vec3) Normalized() Vec3 {
 	return Vec3{a.X / length, a.Y / length, a.Z / length}
 }
 
+// `NormalizedFast` returns an approximation of `a/|a|`, using `math.InvSqrt`.
+// The length of the result is within 0.18% of 1. `a` must be non-zero.
+//
+// See also `Normalized`.
+func (a Vec3) NormalizedFast() Vec3 {
+	f := math.InvSqrt(a.X*a.X + a.Y*a.Y + a.Z*a.Z)
+	return Vec3{a.X * f, a.Y * f, a.Z * f}
+}
+
 // `Normalize` sets `a` to `a/|a|` (i.e. normalizes `a`).
 // `a` must be non-zero.
 //
@@ -168,6 +240,316 @@ func (a *Vec3) Normalize() {
 	a.Z /= length
 }
 
+// `NormalizeVec3s` normalizes in place every vector in `vs`.
+//
+// Vectors whose squared length is below `math.SmallestNormalFloat32` are left
+// unchanged, instead of being turned into NaNs.
+//
+// See also `Normalize`.
+func NormalizeVec3s(vs []Vec3) {
+	for i, v := range vs {
+		l2 := v.X*v.X + v.Y*v.Y + v.Z*v.Z
+		if l2 < math.SmallestNormalFloat32 {
+			continue
+		}
+		f := 1 / math.Sqrt(l2)
+		vs[i] = Vec3{v.X * f, v.Y * f, v.Z * f}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// `Min` returns the component-wise minimum of `a` and `b`.
+func (a Vec3) Min(b Vec3) Vec3 {
+	if b.X < a.X {
+		a.X = b.X
+	}
+	if b.Y < a.Y {
+		a.Y = b.Y
+	}
+	if b.Z < a.Z {
+		a.Z = b.Z
+	}
+	return a
+}
+
+// `Max` returns the component-wise maximum of `a` and `b`.
+func (a Vec3) Max(b Vec3) Vec3 {
+	if b.X > a.X {
+		a.X = b.X
+	}
+	if b.Y > a.Y {
+		a.Y = b.Y
+	}
+	if b.Z > a.Z {
+		a.Z = b.Z
+	}
+	return a
+}
+
+// `MinVec3` returns the component-wise minimum of all vectors in `vs`.
+//
+// If `vs` is empty, all components of the result are +Inf (the identity of
+// the minimum).
+//
+// See also `MaxVec3` and `Bounds`.
+func MinVec3(vs []Vec3) Vec3 {
+	inf := math.Inf(1)
+	m := Vec3{inf, inf, inf}
+	for _, v := range vs {
+		m = m.Min(v)
+	}
+	return m
+}
+
+// `MaxVec3` returns the component-wise maximum of all vectors in `vs`.
+//
+// If `vs` is empty, all components of the result are -Inf (the identity of
+// the maximum).
+//
+// See also `MinVec3` and `Bounds`.
+func MaxVec3(vs []Vec3) Vec3 {
+	inf := math.Inf(-1)
+	m := Vec3{inf, inf, inf}
+	for _, v := range vs {
+		m = m.Max(v)
+	}
+	return m
+}
+
+// `Bounds` returns the corners of the axis-aligned box enclosing all vectors
+// in `vs`, in a single pass.
+//
+// If `vs` is empty, `min` is +Inf and `max` is -Inf, i.e. an empty box that
+// becomes valid as soon as it is extended by any point.
+//
+// See also `MinVec3` and `MaxVec3`.
+func Bounds(vs []Vec3) (min, max Vec3) {
+	pinf, ninf := math.Inf(1), math.Inf(-1)
+	min = Vec3{pinf, pinf, pinf}
+	max = Vec3{ninf, ninf, ninf}
+	for _, v := range vs {
+		min = min.Min(v)
+		max = max.Max(v)
+	}
+	return min, max
+}
+
+//------------------------------------------------------------------------------
+
+// `Centroid` returns the arithmetic mean of `points`, or the zero vector if
+// `points` is empty.
+//
+// See also `WeightedSum`.
+func Centroid(points []Vec3) Vec3 {
+	if len(points) == 0 {
+		return Vec3{}
+	}
+	var c Vec3
+	for _, p := range points {
+		c.Add(p)
+	}
+	c.Divide(float32(len(points)))
+	return c
+}
+
+// `WeightedSum` returns the sum of `points[i] * weights[i]`. The result is not
+// divided by the sum of the weights (e.g. barycentric weights already sum to
+// one).
+//
+// `points` and `weights` must have the same length; otherwise `WeightedSum`
+// panics.
+//
+// See also `Centroid`.
+func WeightedSum(points []Vec3, weights []float32) Vec3 {
+	if len(points) != len(weights) {
+		panic(fmt.Sprintf("glam.WeightedSum: %d points but %d weights", len(points), len(weights)))
+	}
+	var s Vec3
+	for i, p := range points {
+		w := weights[i]
+		s.X += p.X * w
+		s.Y += p.Y * w
+		s.Z += p.Z * w
+	}
+	return s
+}
+
+//------------------------------------------------------------------------------
+
+// `Saturate` returns `a` with each component limited to the range [0, 1].
+//
+// See also `math.Saturate`.
+func (a Vec3) Saturate() Vec3 {
+	return Vec3{math.Saturate(a.X), math.Saturate(a.Y), math.Saturate(a.Z)}
+}
+
+// `Remap` returns `a` with each component linearly mapped from the range
+// given by the corresponding components of `inMin` and `inMax` to the range
+// given by `outMin` and `outMax`. The result is not clamped.
+//
+// See `math.Remap`, and also `RemapClamped`.
+func (a Vec3) Remap(inMin, inMax, outMin, outMax Vec3) Vec3 {
+	return Vec3{
+		math.Remap(a.X, inMin.X, inMax.X, outMin.X, outMax.X),
+		math.Remap(a.Y, inMin.Y, inMax.Y, outMin.Y, outMax.Y),
+		math.Remap(a.Z, inMin.Z, inMax.Z, outMin.Z, outMax.Z),
+	}
+}
+
+// `RemapClamped` is like `Remap`, but each component outside its input range
+// gives the nearest end of its output range. See `math.RemapClamped`.
+func (a Vec3) RemapClamped(inMin, inMax, outMin, outMax Vec3) Vec3 {
+	return Vec3{
+		math.RemapClamped(a.X, inMin.X, inMax.X, outMin.X, outMax.X),
+		math.RemapClamped(a.Y, inMin.Y, inMax.Y, outMin.Y, outMax.Y),
+		math.RemapClamped(a.Z, inMin.Z, inMax.Z, outMin.Z, outMax.Z),
+	}
+}
+
+// `Mix` returns the linear interpolation between `a` and `b`, i.e.
+// `a*(1-t) + b*t` (as the GLSL function of the same name).
+//
+// See also `math.Mix` and `Slerp`.
+func (a Vec3) Mix(b Vec3, t float32) Vec3 {
+	return Vec3{math.Mix(a.X, b.X, t), math.Mix(a.Y, b.Y, t), math.Mix(a.Z, b.Z, t)}
+}
+
+// `Slerp` returns the spherical linear interpolation between the directions
+// `a` and `b`, which must be normalized: the result moves along the great
+// circle from `a` (for `t = 0`) to `b` (for `t = 1`), at constant angular
+// velocity, and is normalized.
+//
+// When `a` and `b` are nearly parallel, a normalized linear interpolation is
+// used instead (the difference is negligible, and avoids a division by a
+// vanishing sine). When they are exactly opposite, there is no unique great
+// circle, and an arbitrary one is chosen (deterministically).
+func (a Vec3) Slerp(b Vec3, t float32) Vec3 {
+	d := a.Dot(b)
+
+	switch {
+	case d > 0.9995:
+		r := Vec3{a.X + t*(b.X-a.X), a.Y + t*(b.Y-a.Y), a.Z + t*(b.Z-a.Z)}
+		r.Normalize()
+		return r
+
+	case d < -0.9995:
+		// The sine formula loses precision here: rotate a towards the part of
+		// b perpendicular to it, whose length is the sine of the angle
+		p := Vec3{b.X - d*a.X, b.Y - d*a.Y, b.Z - d*a.Z}
+		l := p.Length()
+		theta := math.Atan2(l, d)
+		if l < 1e-6 {
+			// Opposite directions: any direction perpendicular to a
+			p = a.Cross(Vec3{1, 0, 0})
+			if p.Dot(p) < 0.01 {
+				p = a.Cross(Vec3{0, 1, 0})
+			}
+			p.Normalize()
+			theta = math.Pi
+		} else {
+			p = p.Slash(l)
+		}
+		c, s := math.Cos(t*theta), math.Sin(t*theta)
+		return Vec3{a.X*c + p.X*s, a.Y*c + p.Y*s, a.Z*c + p.Z*s}
+	}
+
+	theta := math.Acos(d)
+	s := math.Sin(theta)
+	ca := math.Sin((1-t)*theta) / s
+	cb := math.Sin(t*theta) / s
+	return Vec3{a.X*ca + b.X*cb, a.Y*ca + b.Y*cb, a.Z*ca + b.Z*cb}
+}
+
+//------------------------------------------------------------------------------
+
+// `Repeat` returns `a` with each component taken modulo `length`, in
+// [0, length) (e.g. for tiling texture coordinates).
+//
+// See `math.Repeat`.
+func (a Vec3) Repeat(length float32) Vec3 {
+	return Vec3{math.Repeat(a.X, length), math.Repeat(a.Y, length), math.Repeat(a.Z, length)}
+}
+
+// `Wrap` returns `a` with each component wrapped into [min, max).
+//
+// See `math.Wrap`.
+func (a Vec3) Wrap(min, max float32) Vec3 {
+	return Vec3{math.Wrap(a.X, min, max), math.Wrap(a.Y, min, max), math.Wrap(a.Z, min, max)}
+}
+
+// `PingPong` returns `a` with each component folded back and forth into
+// [0, length].
+//
+// See `math.PingPong`.
+func (a Vec3) PingPong(length float32) Vec3 {
+	return Vec3{math.PingPong(a.X, length), math.PingPong(a.Y, length), math.PingPong(a.Z, length)}
+}
+
+//------------------------------------------------------------------------------
+
+// `Pow` returns `a` with each component raised to the power `e`, e.g. for
+// gamma curves.
+//
+// As with `math.Pow`, negative components give NaN for non-integer `e`.
+//
+// See also `PowV`.
+func (a Vec3) Pow(e float32) Vec3 {
+	return Vec3{math.Pow(a.X, e), math.Pow(a.Y, e), math.Pow(a.Z, e)}
+}
+
+// `PowV` returns `a` with each component raised to the power of the
+// corresponding component of `e`.
+func (a Vec3) PowV(e Vec3) Vec3 {
+	return Vec3{math.Pow(a.X, e.X), math.Pow(a.Y, e.Y), math.Pow(a.Z, e.Z)}
+}
+
+// `Exp` returns the base-e exponential of each component of `a`.
+func (a Vec3) Exp() Vec3 {
+	return Vec3{math.Exp(a.X), math.Exp(a.Y), math.Exp(a.Z)}
+}
+
+// `Log` returns the natural logarithm of each component of `a`.
+func (a Vec3) Log() Vec3 {
+	return Vec3{math.Log(a.X), math.Log(a.Y), math.Log(a.Z)}
+}
+
+// `Sqrt` returns the square root of each component of `a`.
+func (a Vec3) Sqrt() Vec3 {
+	return Vec3{math.Sqrt(a.X), math.Sqrt(a.Y), math.Sqrt(a.Z)}
+}
+
+//------------------------------------------------------------------------------
+
+// `Equal` returns true if `a` and `b` are exactly equal (this is the same as
+// `a == b`).
+//
+// See also `NearlyEqual` and `RoughlyEqual`.
+func (a Vec3) Equal(b Vec3) bool {
+	return a == b
+}
+
+// `RoughlyEqual` returns true if the absolute difference between each
+// component of `a` and `b` is less than `epsilon`.
+//
+// See also `NearlyEqual` and `Equal`.
+func (a Vec3) RoughlyEqual(b Vec3, epsilon float32) bool {
+	return roughlyEqual([]float32{a.X, a.Y, a.Z}, []float32{b.X, b.Y, b.Z}, epsilon)
+}
+
+// `NearlyEqual` returns true if the difference between each component of `a`
+// and `b` is less than `epsilon` times the largest absolute component of
+// either vector.
+//
+// Unlike `math.IsNearlyEqual`, the tolerance is relative to the whole vector
+// and not to each component, so that components which should be zero (but
+// are the result of a computation) do not make the comparison fail.
+//
+// See also `RoughlyEqual` and `Equal`.
+func (a Vec3) NearlyEqual(b Vec3, epsilon float32) bool {
+	return nearlyEqual([]float32{a.X, a.Y, a.Z}, []float32{b.X, b.Y, b.Z}, epsilon)
+}
+
 //------------------------------------------------------------------------------
 
 func (v Vec3) RotateX(angle float32) (Vec3) {
@@ -216,14 +598,14 @@ func (v Vec3) RotateAxis(axis Vec3, angle float32) (Vec3) {
 
 	rm0.X = (u.X)*(u.X) + c*(1-(u.X)*(u.X))
 	rm0.Y = (u.X)*(u.Y)*(onemc) - s*u.Z
-	rm0.Z = (u.X)*(u.Z)*(onemc) + s*u.Z
+	rm0.Z = (u.X)*(u.Z)*(onemc) + s*u.Y
 
-	rm1.X = (u.X)*(u.X)*(onemc) + s*u.Z
+	rm1.X = (u.X)*(u.Y)*(onemc) + s*u.Z
 	rm1.Y = (u.Y)*(u.Y) + c*(1-(u.Y)*(u.Y))
 	rm1.Z = (u.Y)*(u.Z)*(onemc) - s*u.X
 	
 	rm2.X = (u.X)*(u.Z)*(onemc) - s*u.Y
-	rm2.Y = (u.Y)*(u.Z)*(c) + s*u.X
+	rm2.Y = (u.Y)*(u.Z)*(onemc) + s*u.X
 	rm2.Z = (u.Z)*(u.Z) + c*(1-(u.Z)*(u.Z))
 
 	return Vec3{v.Dot(rm0), v.Dot(rm1), v.Dot(rm2)}
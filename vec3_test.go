@@ -5,8 +5,11 @@ package glam
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 	"unsafe"
+
+	"github.com/drakmaniso/glam/math"
 )
 
 //-----------------------------------------------------------------------------
@@ -257,6 +260,23 @@ func TestVec3_Dot(t *testing.T) {
 	}
 }
 
+func TestVec3_DotPrecise(t *testing.T) {
+	a := Vec3{1.1, 2.2, 3.3}
+	b := Vec3{4.4, 5.5, 6.6}
+	if c := a.DotPrecise(b); c != 38.72 {
+		t.Errorf("Wrong result: %#v", c)
+	}
+	// The naive sum absorbs the middle term, which then cancels out
+	a = Vec3{1e8, 1, -1e8}
+	b = Vec3{1, 3, 1}
+	if c := a.Dot(b); c != 0 {
+		t.Errorf("Naive result not affected: %#v", c)
+	}
+	if c := a.DotPrecise(b); c != 3 {
+		t.Errorf("Wrong result: %#v", c)
+	}
+}
+
 //-----------------------------------------------------------------------------
 
 func TestVec3_Length(t *testing.T) {
@@ -270,6 +290,110 @@ func TestVec3_Length(t *testing.T) {
 	}
 }
 
+func TestVec3_LengthPrecise(t *testing.T) {
+	if l := (Vec3{1.1, 2.2, 3.3}).LengthPrecise(); l != 4.1158233 {
+		t.Errorf("Wrong result: %#v", l)
+	}
+	a := Vec3{3e19, 0, 4e19}
+	if l := a.Length(); !math.IsInf(l, 1) {
+		t.Errorf("Naive result not affected: %#v", l)
+	}
+	if l := a.LengthPrecise(); l != 5e19 {
+		t.Errorf("Wrong result: %#v", l)
+	}
+	a = Vec3{3e-25, 0, 4e-25}
+	if l := a.Length(); l != 0 {
+		t.Errorf("Naive result not affected: %#v", l)
+	}
+	if l := a.LengthPrecise(); math.Abs(l/5e-25-1) > 1e-6 {
+		t.Errorf("Wrong result: %#v", l)
+	}
+}
+
+func TestVec3_LengthRobust(t *testing.T) {
+	cases := []struct {
+		a Vec3
+		l float32
+	}{
+		{Vec3{0, 0, 0}, 0},
+		{Vec3{3, -4, 0}, 5},
+		{Vec3{1.1, 2.2, 3.3}, 4.1158233},
+		{Vec3{3e20, 0, -4e20}, 5e20},
+		{Vec3{1e20, 1e20, 1e20}, 1.7320508e20},
+		{Vec3{-2e-20, 3e-20, 6e-20}, 7e-20},
+		{Vec3{2e38, 2e38, 0}, 2.828427e38},
+		{Vec3{1e-45, 0, 0}, 1e-45},
+	}
+	for _, c := range cases {
+		l := c.a.LengthRobust()
+		if math.IsInf(l, 0) || math.IsNaN(l) || math.Abs(l-c.l) > 1e-6*c.l {
+			t.Errorf("Wrong result for %#v: %#v instead of %#v", c.a, l, c.l)
+		}
+	}
+	if l := (Vec3{0, math.Inf(-1), 1}).LengthRobust(); !math.IsInf(l, 1) {
+		t.Errorf("Wrong result for infinity: %#v", l)
+	}
+}
+
+func TestVec3_Repeat(t *testing.T) {
+	a := Vec3{1.25, -0.25, 3}
+	if r := a.Repeat(1); r != (Vec3{0.25, 0.75, 0}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.Wrap(-1, 1); r != (Vec3{-0.75, -0.25, -1}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.PingPong(1); r != (Vec3{0.75, 0.25, 1}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+}
+
+func TestVec3_Pow(t *testing.T) {
+	a := Vec3{0.25, 4, 1.5}
+	if r := a.Pow(0.5); r != (Vec3{0.5, 2, math.Pow(1.5, 0.5)}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.PowV(Vec3{2, -1, 0}); r != (Vec3{0.0625, 0.25, 1}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.Exp(); r != (Vec3{math.Exp(0.25), math.Exp(4), math.Exp(1.5)}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.Log(); r != (Vec3{math.Log(0.25), math.Log(4), math.Log(1.5)}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.Sqrt(); r != (Vec3{0.5, 2, math.Sqrt(1.5)}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.Log().Exp(); !isRoughlyEqualVec3(r, a, 1e-6) {
+		t.Errorf("No round-trip: %#v", r)
+	}
+}
+
+func TestVec3_NearlyEqual(t *testing.T) {
+	a := Vec3{1.1, 2.2, 3.3}
+	b := a.Times(3).Slash(3)
+	c := a.Plus(Vec3{0, 0, 1e-6})
+	if !a.Equal(a) || a.Equal(c) {
+		t.Errorf("Wrong exact comparison")
+	}
+	if !a.NearlyEqual(b, 1e-6) || !a.NearlyEqual(c, 1e-6) || a.NearlyEqual(c, 1e-7) {
+		t.Errorf("Wrong relative comparison")
+	}
+	if !a.RoughlyEqual(c, 2e-6) || a.RoughlyEqual(c, 1e-7) {
+		t.Errorf("Wrong absolute comparison")
+	}
+	// Tolerance relative to the whole vector
+	d := Vec3{1e6, 0, -1e6}
+	e := Vec3{1e6, 1e-3, -1e6}
+	if !d.NearlyEqual(e, 1e-6) || math.IsNearlyEqual(d.Y, e.Y, 1e-6) {
+		t.Errorf("Wrong relative comparison of large vectors")
+	}
+	if !(Vec3{}).NearlyEqual(Vec3{}, 0) || (Vec3{}).NearlyEqual(Vec3{0, 1e-30, 0}, 0.5) {
+		t.Errorf("Wrong comparison of zero vectors")
+	}
+}
+
 func TestVec3_Normalized(t *testing.T) {
 	a := Vec3{1.1, 2.2, 3.3}
 	b := a.Normalized()
@@ -286,6 +410,27 @@ func TestVec3_Normalize(t *testing.T) {
 	}
 }
 
+func TestVec3_NormalizedFast(t *testing.T) {
+	for _, a := range []Vec3{{1.1, 2.2, 3.3}, {1e-10, 0, 0}, {0, -5e10, 1}, {0.3, 0.4, 0}} {
+		b := a.NormalizedFast()
+		if l := b.Length(); l < 1-0.0018 || l > 1+0.0018 {
+			t.Errorf("Wrong length for %#v: %v", a, l)
+		}
+		if c := b.Cross(a.Normalized()); c.Length() > 1e-6 {
+			t.Errorf("Wrong direction for %#v: %#v", a, b)
+		}
+	}
+}
+
+func BenchmarkVec3_NormalizedFast(b *testing.B) {
+	m := Vec3{1.1, 2.2, 3.3}
+	var o Vec3
+	for i := 0; i < b.N; i++ {
+		o = m.NormalizedFast()
+	}
+	_ = o
+}
+
 func BenchmarkVec3_Normalize(b *testing.B) {
 	m := Vec3{1.1, 2.2, 3.3}
 	for i := 0; i < b.N; i++ {
@@ -302,4 +447,303 @@ func BenchmarkVec3_Normalized(b *testing.B) {
 	_ = o
 }
 
+func TestNormalizeVec3s(t *testing.T) {
+	vs := []Vec3{{1.1, 2.2, 3.3}, {0, -4, 0}, {0, 0, 0}, {1e-30, 0, 0}, {3, 0, 4}}
+	NormalizeVec3s(vs)
+	for _, i := range []int{0, 1, 4} {
+		if l := vs[i].Length(); l < 1-1e-6 || l > 1+1e-6 {
+			t.Errorf("Wrong result at %d: %#v", i, vs[i])
+		}
+	}
+	if vs[1] != (Vec3{0, -1, 0}) || vs[4] != (Vec3{0.6, 0, 0.8}) {
+		t.Errorf("Wrong result: %#v, %#v", vs[1], vs[4])
+	}
+	if vs[2] != (Vec3{0, 0, 0}) || vs[3] != (Vec3{1e-30, 0, 0}) {
+		t.Errorf("Near-zero vector modified: %#v, %#v", vs[2], vs[3])
+	}
+	NormalizeVec3s(nil)
+}
+
+func BenchmarkNormalizeVec3s(b *testing.B) {
+	vs := make([]Vec3, 1000)
+	for i := 0; i < b.N; i++ {
+		for j := range vs {
+			vs[j] = Vec3{float32(j%17) + 1, float32(j % 31), float32(j % 7)}
+		}
+		NormalizeVec3s(vs)
+	}
+}
+
+func BenchmarkNormalizeVec3s_loop(b *testing.B) {
+	vs := make([]Vec3, 1000)
+	for i := 0; i < b.N; i++ {
+		for j := range vs {
+			vs[j] = Vec3{float32(j%17) + 1, float32(j % 31), float32(j % 7)}
+		}
+		for j := range vs {
+			vs[j].Normalize()
+		}
+	}
+}
+
 //-----------------------------------------------------------------------------
+
+func TestVec3_Mix(t *testing.T) {
+	a, b := Vec3{1, 2, 3}, Vec3{5, -2, 3}
+	if m := a.Mix(b, 0.25); m != (Vec3{2, 1, 3}) {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	for _, x := range []float32{0, 0.3, 1, 1.7} {
+		m := a.Mix(b, x)
+		if m.X != math.Mix(a.X, b.X, x) || m.Y != math.Mix(a.Y, b.Y, x) || m.Z != math.Mix(a.Z, b.Z, x) {
+			t.Errorf("Differs from math.Mix for %v: %#v", x, m)
+		}
+		if m != Mix(a, b, x) {
+			t.Errorf("Differs from generic Mix for %v: %#v", x, m)
+		}
+	}
+}
+
+func TestVec3_Slerp(t *testing.T) {
+	cases := []struct{ a, b Vec3 }{
+		{Vec3{1, 0, 0}, Vec3{0, 1, 0}},
+		{Vec3{1, 2, 3}.Normalized(), Vec3{-2, 0.5, 1}.Normalized()},
+		{Vec3{0, 0, 1}, Vec3{0.0001, 0, 1}.Normalized()},
+		{Vec3{1, 0, 0}, Vec3{-1, 0, 0}},
+		{Vec3{0.6, 0.8, 0}, Vec3{-0.6, -0.8, 0}},
+		{Vec3{1, 1, 1}.Normalized(), Vec3{-1, -1, -1}.Normalized()},
+		{Vec3{0, 1, 0}, Vec3{0, 1, 0}},
+	}
+	for _, c := range cases {
+		angle := math.Acos(clampDot(c.a.Dot(c.b)))
+		if r := c.a.Slerp(c.b, 0); !isRoughlyEqualVec3(r, c.a, 1e-6) {
+			t.Errorf("Wrong result at 0 for %#v: %#v", c, r)
+		}
+		if r := c.a.Slerp(c.b, 1); !isRoughlyEqualVec3(r, c.b, 1e-6) {
+			t.Errorf("Wrong result at 1 for %#v: %#v", c, r)
+		}
+		for i := 0; i <= 20; i++ {
+			tt := float32(i) / 20
+			r := c.a.Slerp(c.b, tt)
+			if l := r.Length(); !math.IsRoughlyEqual(l, 1, 1e-6) {
+				t.Errorf("Not normalized at %v for %#v: %v", tt, c, l)
+			}
+			if math.IsNaN(r.X) || math.IsNaN(r.Y) || math.IsNaN(r.Z) {
+				t.Errorf("NaN at %v for %#v", tt, c)
+			}
+			// Constant angular velocity
+			d := math.Acos(clampDot(c.a.Dot(r)))
+			if !math.IsRoughlyEqual(d, tt*angle, 1e-3) {
+				t.Errorf("Wrong angle at %v for %#v: %v instead of %v", tt, c, d, tt*angle)
+			}
+		}
+	}
+	m := Vec3{1, 0, 0}.Slerp(Vec3{0, 1, 0}, 0.5)
+	if !isRoughlyEqualVec3(m, Vec3{1, 1, 0}.Normalized(), 1e-6) {
+		t.Errorf("Wrong midpoint: %#v", m)
+	}
+
+	// Nearly opposite but distinct: the result stays on the great circle
+	// through a and b
+	for _, c := range []struct{ a, b Vec3 }{
+		{Vec3{1, 0, 0}, Vec3{math.Cos(179.2 * math.Pi / 180), math.Sin(179.2 * math.Pi / 180), 0}},
+		{Vec3{0, 0, 1}, Vec3{0.02, -0.01, -1}.Normalized()},
+		{Vec3{1, 2, 3}.Normalized(), Vec3{-1, -2.01, -3}.Normalized()},
+	} {
+		if r := c.a.Slerp(c.b, 1); !isRoughlyEqualVec3(r, c.b, 1e-6) {
+			t.Errorf("Wrong result at 1 for %#v: %#v", c, r)
+		}
+		n := c.a.Cross(c.b).Normalized()
+		angle := math.Atan2(c.a.Cross(c.b).Length(), c.a.Dot(c.b))
+		for _, tt := range []float32{0.25, 0.5, 0.75} {
+			r := c.a.Slerp(c.b, tt)
+			if d := r.Dot(n); !math.IsRoughlyEqual(d, 0, 1e-4) {
+				t.Errorf("Not in the plane of a and b at %v for %#v: %#v", tt, c, r)
+			}
+			if d := math.Atan2(c.a.Cross(r).Length(), c.a.Dot(r)); !math.IsRoughlyEqual(d, tt*angle, 1e-4) {
+				t.Errorf("Wrong angle at %v for %#v: %v instead of %v", tt, c, d, tt*angle)
+			}
+		}
+	}
+}
+
+func clampDot(d float32) float32 {
+	if d > 1 {
+		return 1
+	}
+	if d < -1 {
+		return -1
+	}
+	return d
+}
+
+//-----------------------------------------------------------------------------
+
+func TestVec3_Min(t *testing.T) {
+	a := Vec3{1, -2, 3}
+	b := Vec3{0, 5, 3}
+	if m := a.Min(b); m != (Vec3{0, -2, 3}) {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	if m := a.Max(b); m != (Vec3{1, 5, 3}) {
+		t.Errorf("Wrong result: %#v", m)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	vs := []Vec3{{1, 2, 3}, {-1, 5, 0}, {4, -3, 2}, {0, 0, 7}}
+	min, max := Bounds(vs)
+	if min != (Vec3{-1, -3, 0}) || max != (Vec3{4, 5, 7}) {
+		t.Errorf("Wrong result: %#v, %#v", min, max)
+	}
+	if m := MinVec3(vs); m != min {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	if m := MaxVec3(vs); m != max {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	min, max = Bounds(vs[:1])
+	if min != vs[0] || max != vs[0] {
+		t.Errorf("Wrong result for single point: %#v, %#v", min, max)
+	}
+
+	min, max = Bounds(nil)
+	if !math.IsInf(min.X, 1) || !math.IsInf(min.Y, 1) || !math.IsInf(min.Z, 1) ||
+		!math.IsInf(max.X, -1) || !math.IsInf(max.Y, -1) || !math.IsInf(max.Z, -1) {
+		t.Errorf("Wrong result for empty slice: %#v, %#v", min, max)
+	}
+	if m := MinVec3(nil); m != min {
+		t.Errorf("Wrong result for empty slice: %#v", m)
+	}
+	if m := MaxVec3(nil); m != max {
+		t.Errorf("Wrong result for empty slice: %#v", m)
+	}
+}
+
+func BenchmarkBounds(b *testing.B) {
+	vs := make([]Vec3, 1000)
+	for i := range vs {
+		vs[i] = Vec3{float32(i % 17), float32(i % 31), float32(i % 7)}
+	}
+	var min, max Vec3
+	for i := 0; i < b.N; i++ {
+		min, max = Bounds(vs)
+	}
+	_, _ = min, max
+}
+
+//-----------------------------------------------------------------------------
+
+func TestVec3_RotateAxis(t *testing.T) {
+	v := Vec3{1, -2, 3}
+	for _, angle := range []float32{0.3, -1.2, 2.9} {
+		if r := v.RotateAxis(Vec3{1, 0, 0}, angle); !isRoughlyEqualVec3(r, v.RotateX(angle), 1e-5) {
+			t.Errorf("Wrong result around X: %#v", r)
+		}
+		if r := v.RotateAxis(Vec3{0, 1, 0}, angle); !isRoughlyEqualVec3(r, v.RotateY(angle), 1e-5) {
+			t.Errorf("Wrong result around Y: %#v", r)
+		}
+		if r := v.RotateAxis(Vec3{0, 0, 1}, angle); !isRoughlyEqualVec3(r, v.RotateZ(angle), 1e-5) {
+			t.Errorf("Wrong result around Z: %#v", r)
+		}
+		axis := Vec3{2, 1, -1}
+		r := v.RotateAxis(axis, angle)
+		if !math.IsRoughlyEqual(r.Length(), v.Length(), 1e-5) {
+			t.Errorf("Length not preserved: %#v", r)
+		}
+		if !math.IsRoughlyEqual(r.Dot(axis), v.Dot(axis), 1e-5) {
+			t.Errorf("Component along axis not preserved: %#v", r)
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+func TestCentroid(t *testing.T) {
+	square := []Vec3{{1, 1, 0}, {-1, 1, 0}, {-1, -1, 0}, {1, -1, 0}}
+	if c := Centroid(square); c != (Vec3{}) {
+		t.Errorf("Wrong result: %#v", c)
+	}
+	cube := []Vec3{
+		{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, {2, 2, 0},
+		{0, 0, 2}, {2, 0, 2}, {0, 2, 2}, {2, 2, 2},
+	}
+	if c := Centroid(cube); c != (Vec3{1, 1, 1}) {
+		t.Errorf("Wrong result: %#v", c)
+	}
+	if c := Centroid(nil); c != (Vec3{}) {
+		t.Errorf("Wrong result for empty slice: %#v", c)
+	}
+}
+
+func TestWeightedSum(t *testing.T) {
+	tri := []Vec3{{0, 0, 0}, {3, 0, 0}, {0, 3, 0}}
+	if s := WeightedSum(tri, []float32{1.0 / 3, 1.0 / 3, 1.0 / 3}); !isRoughlyEqualVec3(s, Vec3{1, 1, 0}, 1e-6) {
+		t.Errorf("Wrong result: %#v", s)
+	}
+	if s := WeightedSum(tri, []float32{0, 1, 0}); s != tri[1] {
+		t.Errorf("Wrong result: %#v", s)
+	}
+	if s := WeightedSum(tri, []float32{2, 2, 2}); s != (Vec3{6, 6, 0}) {
+		t.Errorf("Weights normalized: %#v", s)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("No panic on length mismatch")
+		}
+	}()
+	WeightedSum(tri, []float32{1, 2})
+}
+
+//-----------------------------------------------------------------------------
+
+func TestVec3_Saturate(t *testing.T) {
+	if v := (Vec3{-0.5, 0.25, 3}).Saturate(); v != (Vec3{0, 0.25, 1}) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestVec3_ProjectOntoPlane(t *testing.T) {
+	if p := (Vec3{3, -2, 5}).ProjectOntoPlane(Vec3{0, 1, 0}); p != (Vec3{3, 0, 5}) {
+		t.Errorf("Wrong result: %#v", p)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		v := Vec3{r.Float32()*4 - 2, r.Float32()*4 - 2, r.Float32()*4 - 2}
+		n := Vec3{r.Float32()*2 - 1, r.Float32()*2 - 1, r.Float32()*2 - 1}.Normalized()
+		p := v.ProjectOntoPlane(n)
+		if d := p.Dot(n); math.Abs(d) > 1e-5 {
+			t.Errorf("Not perpendicular to %v: %#v (dot %v)", n, p, d)
+		}
+		// The removed part is along the normal
+		if c := v.Minus(p).Cross(n); c.Length() > 1e-5 {
+			t.Errorf("Wrong removed component for %v, %v: %#v", v, n, p)
+		}
+		if q := p.ProjectOntoPlane(n); !isRoughlyEqualVec3(q, p, 1e-6) {
+			t.Errorf("Not idempotent for %v, %v: %#v", v, n, q)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func TestVec3_Remap(t *testing.T) {
+	inMin, inMax := Vec3{0, 10, -1}, Vec3{10, 0, 1}
+	outMin, outMax := Vec3{0, 0, 1}, Vec3{1, 1, -1}
+	v := Vec3{2.5, 2.5, 3}
+	if r := v.Remap(inMin, inMax, outMin, outMax); !isRoughlyEqualVec3(r, Vec3{0.25, 0.75, -3}, 1e-6) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := v.RemapClamped(inMin, inMax, outMin, outMax); !isRoughlyEqualVec3(r, Vec3{0.25, 0.75, -1}, 1e-6) {
+		t.Errorf("Wrong clamped result: %#v", r)
+	}
+	// Normalizing into a bounding box
+	lo, hi := Vec3{-2, 0, 4}, Vec3{2, 8, 6}
+	if r := (Vec3{0, 8, 4}).Remap(lo, hi, Vec3{}, Vec3{1, 1, 1}); r != (Vec3{0.5, 1, 0}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+}
+
+//------------------------------------------------------------------------------
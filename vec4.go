@@ -167,3 +167,73 @@ func (a *Vec4) Normalize() {
 }
 
 //------------------------------------------------------------------------------
+
+// `Saturate` returns `a` with each component limited to the range [0, 1].
+//
+// See also `math.Saturate`.
+func (a Vec4) Saturate() Vec4 {
+	return Vec4{math.Saturate(a.X), math.Saturate(a.Y), math.Saturate(a.Z), math.Saturate(a.W)}
+}
+
+// `Mix` returns the linear interpolation between `a` and `b`, i.e.
+// `a*(1-t) + b*t` (as the GLSL function of the same name).
+//
+// See also `math.Mix`.
+func (a Vec4) Mix(b Vec4, t float32) Vec4 {
+	return Vec4{math.Mix(a.X, b.X, t), math.Mix(a.Y, b.Y, t), math.Mix(a.Z, b.Z, t), math.Mix(a.W, b.W, t)}
+}
+
+//------------------------------------------------------------------------------
+
+// `Pow` returns `a` with each component raised to the power `e`.
+//
+// See `Vec3.Pow`.
+func (a Vec4) Pow(e float32) Vec4 {
+	return Vec4{math.Pow(a.X, e), math.Pow(a.Y, e), math.Pow(a.Z, e), math.Pow(a.W, e)}
+}
+
+// `PowV` returns `a` with each component raised to the power of the
+// corresponding component of `e`.
+func (a Vec4) PowV(e Vec4) Vec4 {
+	return Vec4{math.Pow(a.X, e.X), math.Pow(a.Y, e.Y), math.Pow(a.Z, e.Z), math.Pow(a.W, e.W)}
+}
+
+// `Exp` returns the base-e exponential of each component of `a`.
+func (a Vec4) Exp() Vec4 {
+	return Vec4{math.Exp(a.X), math.Exp(a.Y), math.Exp(a.Z), math.Exp(a.W)}
+}
+
+// `Log` returns the natural logarithm of each component of `a`.
+func (a Vec4) Log() Vec4 {
+	return Vec4{math.Log(a.X), math.Log(a.Y), math.Log(a.Z), math.Log(a.W)}
+}
+
+// `Sqrt` returns the square root of each component of `a`.
+func (a Vec4) Sqrt() Vec4 {
+	return Vec4{math.Sqrt(a.X), math.Sqrt(a.Y), math.Sqrt(a.Z), math.Sqrt(a.W)}
+}
+
+//------------------------------------------------------------------------------
+
+// `Equal` returns true if `a` and `b` are exactly equal (this is the same as
+// `a == b`).
+func (a Vec4) Equal(b Vec4) bool {
+	return a == b
+}
+
+// `RoughlyEqual` returns true if the absolute difference between each
+// component of `a` and `b` is less than `epsilon`.
+func (a Vec4) RoughlyEqual(b Vec4, epsilon float32) bool {
+	return roughlyEqual([]float32{a.X, a.Y, a.Z, a.W}, []float32{b.X, b.Y, b.Z, b.W}, epsilon)
+}
+
+// `NearlyEqual` returns true if the difference between each component of `a`
+// and `b` is less than `epsilon` times the largest absolute component of
+// either vector.
+//
+// See `Vec3.NearlyEqual`.
+func (a Vec4) NearlyEqual(b Vec4, epsilon float32) bool {
+	return nearlyEqual([]float32{a.X, a.Y, a.Z, a.W}, []float32{b.X, b.Y, b.Z, b.W}, epsilon)
+}
+
+//------------------------------------------------------------------------------
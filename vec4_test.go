@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"testing"
 	"unsafe"
+
+	"github.com/drakmaniso/glam/math"
 )
 
 //-----------------------------------------------------------------------------
@@ -266,3 +268,30 @@ func BenchmarkVec4_Normalized(b *testing.B) {
 }
 
 //-----------------------------------------------------------------------------
+
+func TestVec4_Pow(t *testing.T) {
+	a := Vec4{0.25, 4, 9, 1}
+	if r := a.Pow(0.5); r != (Vec4{0.5, 2, 3, 1}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.PowV(Vec4{2, 0.5, -0.5, 7}); r != (Vec4{0.0625, 2, math.Pow(9, -0.5), 1}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := a.Sqrt(); r != (Vec4{0.5, 2, 3, 1}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := (Vec4{0, 1, -1, 2}).Exp(); r != (Vec4{1, math.E, math.Exp(-1), math.Exp(2)}) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+	if r := (Vec4{1, 4, 0, -1}).Log(); r.X != 0 || r.Y != math.Log(4) || !math.IsInf(r.Z, -1) || !math.IsNaN(r.W) {
+		t.Errorf("Wrong result: %#v", r)
+	}
+}
+
+func TestVec4_Saturate(t *testing.T) {
+	if v := (Vec4{1, -1e30, 0.75, 1.0001}).Saturate(); v != (Vec4{1, 0, 0.75, 1}) {
+		t.Errorf("Wrong result: %#v", v)
+	}
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,50 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+//------------------------------------------------------------------------------
+
+// `Vector` is the set of operations shared by `Vec2`, `Vec3` and `Vec4`. It is
+// meant to be used as a type constraint, with the vector type itself as `T`,
+// so that an algorithm can be written once for all dimensions:
+//
+//	func Midpoint[T Vector[T]](a, b T) T {
+//		return a.Plus(b).Times(0.5)
+//	}
+type Vector[T any] interface {
+	Plus(b T) T
+	Minus(b T) T
+	Times(s float32) T
+	Dot(b T) float32
+	Length() float32
+}
+
+var (
+	_ Vector[Vec2] = Vec2{}
+	_ Vector[Vec3] = Vec3{}
+	_ Vector[Vec4] = Vec4{}
+)
+
+//------------------------------------------------------------------------------
+
+// `LengthOf` returns `|v|`, for any vector type.
+func LengthOf[T Vector[T]](v T) float32 {
+	return v.Length()
+}
+
+// `Distance` returns `|b - a|`, the euclidian distance between the points `a`
+// and `b`, for any vector type.
+func Distance[T Vector[T]](a, b T) float32 {
+	return b.Minus(a).Length()
+}
+
+// `Mix` returns the linear interpolation between `a` and `b`, using `t` to
+// weight between them (i.e. `a*(1-t) + b*t`), for any vector type.
+//
+// See also `math.Mix`.
+func Mix[T Vector[T]](a, b T, t float32) T {
+	return a.Times(1 - t).Plus(b.Times(t))
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,53 @@
+// Copyright (c) 2013 Laurent Moussault. All rights reserved.
+// Licensed under a simplified BSD license (see LICENSE file).
+
+package glam
+
+import "testing"
+
+//------------------------------------------------------------------------------
+
+func TestLengthOf(t *testing.T) {
+	if l := LengthOf(Vec2{3, 4}); l != 5 {
+		t.Errorf("Wrong result: %v", l)
+	}
+	if l := LengthOf(Vec3{2, 3, 6}); l != 7 {
+		t.Errorf("Wrong result: %v", l)
+	}
+	if l := LengthOf(Vec4{1, 1, 1, 1}); l != 2 {
+		t.Errorf("Wrong result: %v", l)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	if d := Distance(Vec2{1, 1}, Vec2{4, 5}); d != 5 {
+		t.Errorf("Wrong result: %v", d)
+	}
+	if d := Distance(Vec3{1, 1, 1}, Vec3{3, 4, 7}); d != 7 {
+		t.Errorf("Wrong result: %v", d)
+	}
+	if d := Distance(Vec4{0, 0, 0, 1}, Vec4{0, 0, 0, 1}); d != 0 {
+		t.Errorf("Wrong result: %v", d)
+	}
+}
+
+func TestMix(t *testing.T) {
+	a, b := Vec3{1, 2, 3}, Vec3{5, -2, 3}
+	if m := Mix(a, b, 0); m != a {
+		t.Errorf("Wrong result at 0: %#v", m)
+	}
+	if m := Mix(a, b, 1); m != b {
+		t.Errorf("Wrong result at 1: %#v", m)
+	}
+	if m := Mix(a, b, 0.25); m != (Vec3{2, 1, 3}) {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	if m := Mix(Vec2{0, 4}, Vec2{8, 0}, 0.5); m != (Vec2{4, 2}) {
+		t.Errorf("Wrong result: %#v", m)
+	}
+	if m := Mix(Vec4{0, 0, 0, 0}, Vec4{1, 2, 3, 4}, 2); m != (Vec4{2, 4, 6, 8}) {
+		t.Errorf("Wrong result: %#v", m)
+	}
+}
+
+//------------------------------------------------------------------------------